@@ -0,0 +1,62 @@
+// Команда bitmap печатает карту битов схем Person и Monster из кода,
+// чтобы документация не расходилась с раскладкой Layout.
+//
+// Использование:
+//
+//	go run ./cmd/bitmap                    // текстовая карта обеих схем
+//	go run ./cmd/bitmap -format markdown   // таблицы для README
+//	go run ./cmd/bitmap -schema monster
+//
+// Код возврата 1, если в схеме есть пересечения или выход за размер.
+package main
+
+import (
+	"GamePerson/internal/bitpack"
+	monsterbitpack "GamePerson/internal/model/bitpack/monster"
+	personbitpack "GamePerson/internal/model/bitpack/person"
+	"flag"
+	"fmt"
+	"os"
+)
+
+func main() {
+	format := flag.String("format", "text", "формат вывода: text или markdown")
+	only := flag.String("schema", "", "имя схемы (person, monster); пусто — все")
+	flag.Parse()
+
+	if err := run(*format, *only); err != nil {
+		fmt.Fprintln(os.Stderr, "bitmap:", err)
+		os.Exit(1)
+	}
+}
+
+func run(format, only string) error {
+	schemas := []*bitpack.Schema{personbitpack.Schema(), monsterbitpack.Schema()}
+
+	printed := 0
+	for _, schema := range schemas {
+		if only != "" && schema.Name() != only {
+			continue
+		}
+		if printed > 0 {
+			fmt.Println()
+		}
+		switch format {
+		case "text":
+			fmt.Print(schema.BitMap())
+		case "markdown":
+			fmt.Print(schema.Markdown())
+		default:
+			return fmt.Errorf("unknown format %q (want text or markdown)", format)
+		}
+		printed++
+
+		if cov := schema.Coverage(); !cov.OK() {
+			return fmt.Errorf("schema %s: %s", schema.Name(), cov)
+		}
+	}
+	if printed == 0 {
+		return fmt.Errorf("unknown schema %q", only)
+	}
+	return nil
+}
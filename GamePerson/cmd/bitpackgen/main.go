@@ -0,0 +1,41 @@
+// Команда bitpackgen генерирует пакет доступа к битовым полям по schema.yaml.
+//
+// Использование (из директории пакета схемы):
+//
+//	//go:generate go run GamePerson/cmd/bitpackgen -spec schema.yaml -out bit_pack_gen.go
+package main
+
+import (
+	"GamePerson/internal/bitpackgen"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+func main() {
+	specPath := flag.String("spec", "schema.yaml", "путь к YAML-описанию схемы")
+	outPath := flag.String("out", "bit_pack_gen.go", "путь к генерируемому файлу")
+	flag.Parse()
+
+	if err := run(*specPath, *outPath); err != nil {
+		fmt.Fprintln(os.Stderr, "bitpackgen:", err)
+		os.Exit(1)
+	}
+}
+
+func run(specPath, outPath string) error {
+	data, err := os.ReadFile(specPath)
+	if err != nil {
+		return err
+	}
+	spec, err := bitpackgen.ParseSpec(data)
+	if err != nil {
+		return err
+	}
+	src, err := bitpackgen.Generate(spec, filepath.Base(specPath))
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(outPath, src, 0644)
+}
@@ -47,7 +47,7 @@ func main() {
 	}
 
 	// Типичный случай создания — просто и понятно
-	personJSON := []byte(`{"name":"Aragon","type":2, "health":500 }`)
+	personJSON := []byte(`{"name":"Aragon","type":2, "health":500, "level":1 }`)
 	per, err := person.NewFromJSON(personJSON)
 	if err != nil {
 		panic(err)
@@ -82,6 +82,16 @@ func main() {
 	if err := os.WriteFile("save_person.json", jsonData, 0644); err != nil {
 		panic(err)
 	}
+
+	// === Двоичный формат: 64-байтовая запись + заголовок ===
+	binData, err := personSerializer.ToBinary(p)
+	if err != nil {
+		panic(err)
+	}
+	fmt.Printf("Person binary: %d байт (JSON: %d байт)\n", len(binData), len(jsonData))
+	if _, err := personSerializer.FromBinary(binData); err != nil {
+		panic(err)
+	}
 }
 
 /*
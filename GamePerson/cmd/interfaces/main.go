@@ -94,3 +94,5 @@ func TestHeal(t *testing.T) {
 
 
 */
+
+func main() {}
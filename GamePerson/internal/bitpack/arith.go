@@ -0,0 +1,422 @@
+package bitpack
+
+import "math"
+
+// =================  Арифметика над полями =================================
+// ======== Сложение и вычитание в двух режимах =============================
+//
+// Saturating — результат прижимается к [Min, Max] поля (здоровье не уходит
+// ниже нуля, опыт не превышает максимум). Checked — при выходе за границы
+// возвращается ErrValueOverflow / ErrValueUnderflow, значение не меняется.
+//
+//	bits = healthField.SubSaturating(bits, damage)
+//	bits, err = manaField.Sub(bits, cost) // ErrValueUnderflow, если маны не хватает
+//
+// Переполнение разрядов uint64/int64 обрабатывается так же, как выход за Max/Min.
+
+// ==================== UIntBitField ====================
+
+// Add прибавляет delta, отклоняя результат больше Max
+func (bf UIntBitField) Add(bitSet BitSet64, delta uint64) (BitSet64, error) {
+	value, err := bf.add(bf.Get(bitSet), delta)
+	if err != nil {
+		return bitSet, err
+	}
+	return bf.UpdateUnchecked(bitSet, value), nil
+}
+
+// Sub вычитает delta, отклоняя результат меньше Min
+func (bf UIntBitField) Sub(bitSet BitSet64, delta uint64) (BitSet64, error) {
+	value, err := bf.sub(bf.Get(bitSet), delta)
+	if err != nil {
+		return bitSet, err
+	}
+	return bf.UpdateUnchecked(bitSet, value), nil
+}
+
+// AddSaturating прибавляет delta с прижатием к Max
+func (bf UIntBitField) AddSaturating(bitSet BitSet64, delta uint64) BitSet64 {
+	return bf.UpdateUnchecked(bitSet, addUIntSaturated(bf.Get(bitSet), delta, bf.Max))
+}
+
+// SubSaturating вычитает delta с прижатием к Min
+func (bf UIntBitField) SubSaturating(bitSet BitSet64, delta uint64) BitSet64 {
+	return bf.UpdateUnchecked(bitSet, subUIntSaturated(bf.Get(bitSet), delta, bf.Min))
+}
+
+// ==================== IntBitField ====================
+
+// Add прибавляет delta (любого знака), отклоняя результат вне [Min, Max]
+func (bf IntBitField) Add(bitSet BitSet64, delta int64) (BitSet64, error) {
+	value, err := bf.add(bf.Get(bitSet), delta)
+	if err != nil {
+		return bitSet, err
+	}
+	return bf.UpdateUnchecked(bitSet, value), nil
+}
+
+// Sub вычитает delta (любого знака), отклоняя результат вне [Min, Max]
+func (bf IntBitField) Sub(bitSet BitSet64, delta int64) (BitSet64, error) {
+	value, err := bf.sub(bf.Get(bitSet), delta)
+	if err != nil {
+		return bitSet, err
+	}
+	return bf.UpdateUnchecked(bitSet, value), nil
+}
+
+// AddSaturating прибавляет delta с прижатием к [Min, Max]
+func (bf IntBitField) AddSaturating(bitSet BitSet64, delta int64) BitSet64 {
+	return bf.UpdateUnchecked(bitSet, addIntSaturated(bf.Get(bitSet), delta, bf.Min, bf.Max))
+}
+
+// SubSaturating вычитает delta с прижатием к [Min, Max]
+func (bf IntBitField) SubSaturating(bitSet BitSet64, delta int64) BitSet64 {
+	return bf.UpdateUnchecked(bitSet, subIntSaturated(bf.Get(bitSet), delta, bf.Min, bf.Max))
+}
+
+// ==================== Функции над срезом ====================
+// Возвращают значение поля после операции (при ошибке — прежнее значение).
+
+func AddUIntFieldAs[T UnsignedInteger](packed []byte, field UIntBitField, delta T) (T, error) {
+	if err := validatePacked(packed, field.End); err != nil {
+		return 0, field.label.annotate(err)
+	}
+	old := GetUIntFieldAs[uint64](packed, field)
+	value, err := field.add(old, uint64(delta))
+	if err != nil {
+		return T(old), err
+	}
+	SetUIntFieldUncheckedAs(packed, field, value)
+	return T(value), nil
+}
+
+func SubUIntFieldAs[T UnsignedInteger](packed []byte, field UIntBitField, delta T) (T, error) {
+	if err := validatePacked(packed, field.End); err != nil {
+		return 0, field.label.annotate(err)
+	}
+	old := GetUIntFieldAs[uint64](packed, field)
+	value, err := field.sub(old, uint64(delta))
+	if err != nil {
+		return T(old), err
+	}
+	SetUIntFieldUncheckedAs(packed, field, value)
+	return T(value), nil
+}
+
+func AddUIntFieldSaturatingAs[T UnsignedInteger](packed []byte, field UIntBitField, delta T) T {
+	value := addUIntSaturated(GetUIntFieldAs[uint64](packed, field), uint64(delta), field.Max)
+	SetUIntFieldUncheckedAs(packed, field, value)
+	return T(value)
+}
+
+func SubUIntFieldSaturatingAs[T UnsignedInteger](packed []byte, field UIntBitField, delta T) T {
+	value := subUIntSaturated(GetUIntFieldAs[uint64](packed, field), uint64(delta), field.Min)
+	SetUIntFieldUncheckedAs(packed, field, value)
+	return T(value)
+}
+
+func AddIntFieldAs[T SignedInteger](packed []byte, field IntBitField, delta T) (T, error) {
+	if err := validatePacked(packed, field.End); err != nil {
+		return 0, field.label.annotate(err)
+	}
+	old := GetIntFieldAs[int64](packed, field)
+	value, err := field.add(old, int64(delta))
+	if err != nil {
+		return T(old), err
+	}
+	SetIntFieldUncheckedAs(packed, field, value)
+	return T(value), nil
+}
+
+func SubIntFieldAs[T SignedInteger](packed []byte, field IntBitField, delta T) (T, error) {
+	if err := validatePacked(packed, field.End); err != nil {
+		return 0, field.label.annotate(err)
+	}
+	old := GetIntFieldAs[int64](packed, field)
+	value, err := field.sub(old, int64(delta))
+	if err != nil {
+		return T(old), err
+	}
+	SetIntFieldUncheckedAs(packed, field, value)
+	return T(value), nil
+}
+
+func AddIntFieldSaturatingAs[T SignedInteger](packed []byte, field IntBitField, delta T) T {
+	value := addIntSaturated(GetIntFieldAs[int64](packed, field), int64(delta), field.Min, field.Max)
+	SetIntFieldUncheckedAs(packed, field, value)
+	return T(value)
+}
+
+func SubIntFieldSaturatingAs[T SignedInteger](packed []byte, field IntBitField, delta T) T {
+	value := subIntSaturated(GetIntFieldAs[int64](packed, field), int64(delta), field.Min, field.Max)
+	SetIntFieldUncheckedAs(packed, field, value)
+	return T(value)
+}
+
+// Для nullable поля незаданное значение считается нулём, успешная операция задаёт его
+
+func AddNullableUIntFieldAs[T UnsignedInteger](packed []byte, field NullableUIntBitField, delta T) (T, error) {
+	if err := validatePacked(packed, field.Code.End); err != nil {
+		return 0, field.Code.label.annotate(err)
+	}
+	old, _ := GetNullableUIntFieldAs[uint64](packed, field)
+	value, err := field.valueField().add(old, uint64(delta))
+	if err != nil {
+		return T(old), err
+	}
+	SetNullableUIntFieldUncheckedAs(packed, field, value)
+	return T(value), nil
+}
+
+func SubNullableUIntFieldAs[T UnsignedInteger](packed []byte, field NullableUIntBitField, delta T) (T, error) {
+	if err := validatePacked(packed, field.Code.End); err != nil {
+		return 0, field.Code.label.annotate(err)
+	}
+	old, _ := GetNullableUIntFieldAs[uint64](packed, field)
+	value, err := field.valueField().sub(old, uint64(delta))
+	if err != nil {
+		return T(old), err
+	}
+	SetNullableUIntFieldUncheckedAs(packed, field, value)
+	return T(value), nil
+}
+
+func AddNullableUIntFieldSaturatingAs[T UnsignedInteger](packed []byte, field NullableUIntBitField, delta T) T {
+	old, _ := GetNullableUIntFieldAs[uint64](packed, field)
+	value := addUIntSaturated(old, uint64(delta), field.Max)
+	SetNullableUIntFieldUncheckedAs(packed, field, value)
+	return T(value)
+}
+
+func SubNullableUIntFieldSaturatingAs[T UnsignedInteger](packed []byte, field NullableUIntBitField, delta T) T {
+	old, _ := GetNullableUIntFieldAs[uint64](packed, field)
+	value := subUIntSaturated(old, uint64(delta), 0)
+	SetNullableUIntFieldUncheckedAs(packed, field, value)
+	return T(value)
+}
+
+// ==================== Заданный порядок байтов ====================
+
+func (o ByteOrder) AddUInt(packed []byte, field UIntBitField, delta uint64) (uint64, error) {
+	old, err := o.GetUInt(packed, field)
+	if err != nil {
+		return 0, err
+	}
+	value, err := field.add(old, delta)
+	if err != nil {
+		return old, err
+	}
+	o.SetUIntUnchecked(packed, field, value)
+	return value, nil
+}
+
+func (o ByteOrder) SubUInt(packed []byte, field UIntBitField, delta uint64) (uint64, error) {
+	old, err := o.GetUInt(packed, field)
+	if err != nil {
+		return 0, err
+	}
+	value, err := field.sub(old, delta)
+	if err != nil {
+		return old, err
+	}
+	o.SetUIntUnchecked(packed, field, value)
+	return value, nil
+}
+
+func (o ByteOrder) AddUIntSaturating(packed []byte, field UIntBitField, delta uint64) uint64 {
+	value := addUIntSaturated(o.GetUIntUnchecked(packed, field), delta, field.Max)
+	o.SetUIntUnchecked(packed, field, value)
+	return value
+}
+
+func (o ByteOrder) SubUIntSaturating(packed []byte, field UIntBitField, delta uint64) uint64 {
+	value := subUIntSaturated(o.GetUIntUnchecked(packed, field), delta, field.Min)
+	o.SetUIntUnchecked(packed, field, value)
+	return value
+}
+
+func (o ByteOrder) AddInt(packed []byte, field IntBitField, delta int64) (int64, error) {
+	old, err := o.GetInt(packed, field)
+	if err != nil {
+		return 0, err
+	}
+	value, err := field.add(old, delta)
+	if err != nil {
+		return old, err
+	}
+	o.SetIntUnchecked(packed, field, value)
+	return value, nil
+}
+
+func (o ByteOrder) SubInt(packed []byte, field IntBitField, delta int64) (int64, error) {
+	old, err := o.GetInt(packed, field)
+	if err != nil {
+		return 0, err
+	}
+	value, err := field.sub(old, delta)
+	if err != nil {
+		return old, err
+	}
+	o.SetIntUnchecked(packed, field, value)
+	return value, nil
+}
+
+func (o ByteOrder) AddIntSaturating(packed []byte, field IntBitField, delta int64) int64 {
+	value := addIntSaturated(o.GetIntUnchecked(packed, field), delta, field.Min, field.Max)
+	o.SetIntUnchecked(packed, field, value)
+	return value
+}
+
+func (o ByteOrder) SubIntSaturating(packed []byte, field IntBitField, delta int64) int64 {
+	value := subIntSaturated(o.GetIntUnchecked(packed, field), delta, field.Min, field.Max)
+	o.SetIntUnchecked(packed, field, value)
+	return value
+}
+
+func (o ByteOrder) AddNullableUInt(packed []byte, field NullableUIntBitField, delta uint64) (uint64, error) {
+	old, _, err := o.GetNullableUInt(packed, field)
+	if err != nil {
+		return 0, err
+	}
+	value, err := field.valueField().add(old, delta)
+	if err != nil {
+		return old, err
+	}
+	o.SetNullableUIntUnchecked(packed, field, value)
+	return value, nil
+}
+
+func (o ByteOrder) SubNullableUInt(packed []byte, field NullableUIntBitField, delta uint64) (uint64, error) {
+	old, _, err := o.GetNullableUInt(packed, field)
+	if err != nil {
+		return 0, err
+	}
+	value, err := field.valueField().sub(old, delta)
+	if err != nil {
+		return old, err
+	}
+	o.SetNullableUIntUnchecked(packed, field, value)
+	return value, nil
+}
+
+func (o ByteOrder) AddNullableUIntSaturating(packed []byte, field NullableUIntBitField, delta uint64) uint64 {
+	old, _ := o.GetNullableUIntUnchecked(packed, field)
+	value := addUIntSaturated(old, delta, field.Max)
+	o.SetNullableUIntUnchecked(packed, field, value)
+	return value
+}
+
+func (o ByteOrder) SubNullableUIntSaturating(packed []byte, field NullableUIntBitField, delta uint64) uint64 {
+	old, _ := o.GetNullableUIntUnchecked(packed, field)
+	value := subUIntSaturated(old, delta, 0)
+	o.SetNullableUIntUnchecked(packed, field, value)
+	return value
+}
+
+// ------------- Сервисные методы --------------------------------
+
+// add — checked value+delta для беззнакового поля
+func (bf UIntBitField) add(value, delta uint64) (uint64, error) {
+	if delta > bf.Max || value > bf.Max-delta {
+		return value, bf.label.annotate(newValueOverflowError(addUIntSaturated(value, delta, math.MaxUint64), bf.Max, bf.Width()))
+	}
+	return value + delta, nil
+}
+
+// sub — checked value-delta для беззнакового поля
+func (bf UIntBitField) sub(value, delta uint64) (uint64, error) {
+	if value < bf.Min || value-bf.Min < delta {
+		result := int64(value) - int64(min(delta, math.MaxInt64))
+		return value, bf.label.annotate(newValueOutOfRangeError(result, result, int64(bf.Min), int64(bf.Max), bf.Width()))
+	}
+	return value - delta, nil
+}
+
+// add — checked value+delta для знакового поля
+func (bf IntBitField) add(value, delta int64) (int64, error) {
+	sum, ok := addIntChecked(value, delta)
+	if !ok {
+		sum = clampOverflow(delta > 0)
+	}
+	if !ok || sum < bf.Min || sum > bf.Max {
+		return value, bf.label.annotate(newValueOutOfRangeError(sum, sum, bf.Min, bf.Max, bf.Width()))
+	}
+	return sum, nil
+}
+
+// sub — checked value-delta для знакового поля
+func (bf IntBitField) sub(value, delta int64) (int64, error) {
+	diff, ok := subIntChecked(value, delta)
+	if !ok {
+		diff = clampOverflow(delta < 0)
+	}
+	if !ok || diff < bf.Min || diff > bf.Max {
+		return value, bf.label.annotate(newValueOutOfRangeError(diff, diff, bf.Min, bf.Max, bf.Width()))
+	}
+	return diff, nil
+}
+
+// addUIntSaturated — value+delta, но не больше max (без переполнения uint64)
+func addUIntSaturated(value, delta, max uint64) uint64 {
+	if value >= max || delta > max-value {
+		return max
+	}
+	return value + delta
+}
+
+// subUIntSaturated — value-delta, но не меньше min
+func subUIntSaturated(value, delta, min uint64) uint64 {
+	if value <= min || delta > value-min {
+		return min
+	}
+	return value - delta
+}
+
+// addIntSaturated — value+delta с прижатием к [min, max] (без переполнения int64)
+func addIntSaturated(value, delta, min, max int64) int64 {
+	sum, ok := addIntChecked(value, delta)
+	if !ok {
+		sum = clampOverflow(delta > 0)
+	}
+	return clampInt(sum, min, max)
+}
+
+// subIntSaturated — value-delta с прижатием к [min, max] (без переполнения int64)
+func subIntSaturated(value, delta, min, max int64) int64 {
+	diff, ok := subIntChecked(value, delta)
+	if !ok {
+		diff = clampOverflow(delta < 0)
+	}
+	return clampInt(diff, min, max)
+}
+
+// addIntChecked — value+delta; ok == false при переполнении int64
+func addIntChecked(value, delta int64) (int64, bool) {
+	sum := value + delta
+	return sum, (sum > value) == (delta > 0) || delta == 0
+}
+
+// subIntChecked — value-delta; ok == false при переполнении int64
+func subIntChecked(value, delta int64) (int64, bool) {
+	diff := value - delta
+	return diff, (diff < value) == (delta > 0) || delta == 0
+}
+
+// clampOverflow — граница int64, к которой ушло переполнение
+func clampOverflow(up bool) int64 {
+	if up {
+		return math.MaxInt64
+	}
+	return math.MinInt64
+}
+
+func clampInt(value, min, max int64) int64 {
+	switch {
+	case value > max:
+		return max
+	case value < min:
+		return min
+	default:
+		return value
+	}
+}
@@ -0,0 +1,150 @@
+package bitpack
+
+import (
+	"errors"
+	"math"
+	"testing"
+)
+
+// ============ Тесты арифметики над полями ============
+
+func TestUIntBitFieldAddSub(t *testing.T) {
+	health := MustNewUIntBitField(4, 13, 1000)
+	level := MustNewBiasedUIntBitField(14, 17, 1, 10)
+
+	tests := []struct {
+		name    string
+		field   UIntBitField
+		start   uint64
+		op      func(UIntBitField, BitSet64) (BitSet64, error)
+		want    uint64
+		wantErr error
+	}{
+		{"add", health, 100, func(f UIntBitField, b BitSet64) (BitSet64, error) { return f.Add(b, 50) }, 150, nil},
+		{"add to max", health, 990, func(f UIntBitField, b BitSet64) (BitSet64, error) { return f.Add(b, 10) }, 1000, nil},
+		{"add over max", health, 990, func(f UIntBitField, b BitSet64) (BitSet64, error) { return f.Add(b, 11) }, 990, ErrValueOverflow},
+		{"add MaxUint64", health, 1, func(f UIntBitField, b BitSet64) (BitSet64, error) { return f.Add(b, math.MaxUint64) }, 1, ErrValueOverflow},
+		{"sub", health, 100, func(f UIntBitField, b BitSet64) (BitSet64, error) { return f.Sub(b, 100) }, 0, nil},
+		{"sub below zero", health, 100, func(f UIntBitField, b BitSet64) (BitSet64, error) { return f.Sub(b, 101) }, 100, ErrValueUnderflow},
+		{"sub to biased min", level, 5, func(f UIntBitField, b BitSet64) (BitSet64, error) { return f.Sub(b, 4) }, 1, nil},
+		{"sub below biased min", level, 5, func(f UIntBitField, b BitSet64) (BitSet64, error) { return f.Sub(b, 5) }, 5, ErrValueUnderflow},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			bits := tt.field.UpdateUnchecked(BitSet64(0b1010), tt.start)
+			got, err := tt.op(tt.field, bits)
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("err = %v, want %v", err, tt.wantErr)
+			}
+			if v := tt.field.Get(got); v != tt.want {
+				t.Errorf("value = %d, want %d", v, tt.want)
+			}
+			if got&0b1111 != 0b1010 {
+				t.Errorf("neighbour bits changed: %b", got)
+			}
+		})
+	}
+}
+
+func TestUIntBitFieldSaturating(t *testing.T) {
+	health := MustNewUIntBitField(0, 9, 1000)
+	level := MustNewBiasedUIntBitField(10, 13, 1, 10)
+
+	bits := health.UpdateUnchecked(0, 990)
+	if got := health.Get(health.AddSaturating(bits, 50)); got != 1000 {
+		t.Errorf("AddSaturating over max = %d, want 1000", got)
+	}
+	if got := health.Get(health.AddSaturating(bits, math.MaxUint64)); got != 1000 {
+		t.Errorf("AddSaturating(MaxUint64) = %d, want 1000", got)
+	}
+	if got := health.Get(health.SubSaturating(bits, 5000)); got != 0 {
+		t.Errorf("SubSaturating below zero = %d, want 0", got)
+	}
+
+	bits = level.UpdateUnchecked(0, 3)
+	if got := level.Get(level.SubSaturating(bits, 7)); got != 1 {
+		t.Errorf("SubSaturating below biased min = %d, want 1", got)
+	}
+}
+
+func TestIntBitFieldAddSub(t *testing.T) {
+	field := MustNewIntBitField(8, 15, -100, 100)
+	bits := field.UpdateUnchecked(0, 90)
+
+	if got, err := field.Add(bits, -150); err != nil || field.Get(got) != -60 {
+		t.Errorf("Add(-150) = %d, %v; want -60", field.Get(got), err)
+	}
+	if _, err := field.Add(bits, 11); !errors.Is(err, ErrValueOverflow) {
+		t.Errorf("Add(11) err = %v, want overflow", err)
+	}
+	if _, err := field.Sub(bits, 191); !errors.Is(err, ErrValueUnderflow) {
+		t.Errorf("Sub(191) err = %v, want underflow", err)
+	}
+	if _, err := field.Sub(bits, math.MinInt64); !errors.Is(err, ErrValueOverflow) {
+		t.Errorf("Sub(MinInt64) err = %v, want overflow", err)
+	}
+	if _, err := field.Add(bits, math.MaxInt64); !errors.Is(err, ErrValueOverflow) {
+		t.Errorf("Add(MaxInt64) err = %v, want overflow", err)
+	}
+
+	tests := []struct {
+		name string
+		got  BitSet64
+		want int64
+	}{
+		{"add saturating up", field.AddSaturating(bits, 50), 100},
+		{"add saturating MinInt64", field.AddSaturating(bits, math.MinInt64), -100},
+		{"sub saturating down", field.SubSaturating(bits, 500), -100},
+		{"sub saturating MinInt64", field.SubSaturating(bits, math.MinInt64), 100},
+		{"sub negative", field.SubSaturating(bits, -5), 95},
+	}
+	for _, tt := range tests {
+		if v := field.Get(tt.got); v != tt.want {
+			t.Errorf("%s = %d, want %d", tt.name, v, tt.want)
+		}
+	}
+}
+
+func TestArithSliceFunctions(t *testing.T) {
+	var packed Packed96
+	mana := MustNewUIntBitField(3, 12, 1000)
+	wide := NewLayout("wide", 96)
+	wide.Seek(70)
+	balance := wide.AddInt("balance", -1000, 1000)
+	if err := wide.Err(); err != nil {
+		t.Fatalf("layout error: %v", err)
+	}
+
+	if got, err := AddUIntFieldAs(packed[:], mana, uint32(700)); err != nil || got != 700 {
+		t.Errorf("AddUIntFieldAs = %d, %v; want 700", got, err)
+	}
+	if got, err := SubUIntFieldAs(packed[:], mana, uint32(701)); !errors.Is(err, ErrValueUnderflow) || got != 700 {
+		t.Errorf("SubUIntFieldAs(701) = %d, %v; want 700 and underflow", got, err)
+	}
+	if got := AddUIntFieldSaturatingAs(packed[:], mana, uint32(500)); got != 1000 {
+		t.Errorf("AddUIntFieldSaturatingAs = %d, want 1000", got)
+	}
+	if got := SubUIntFieldSaturatingAs(packed[:], mana, uint32(1500)); got != 0 {
+		t.Errorf("SubUIntFieldSaturatingAs = %d, want 0", got)
+	}
+
+	if got, err := SubIntFieldAs(packed[:], balance, int16(400)); err != nil || got != -400 {
+		t.Errorf("SubIntFieldAs = %d, %v; want -400", got, err)
+	}
+	if got, err := AddIntFieldAs(packed[:], balance, int16(1401)); !errors.Is(err, ErrValueOverflow) || got != -400 {
+		t.Errorf("AddIntFieldAs(1401) = %d, %v; want -400 and overflow", got, err)
+	}
+	if got := AddIntFieldSaturatingAs(packed[:], balance, int16(2000)); got != 1000 {
+		t.Errorf("AddIntFieldSaturatingAs = %d, want 1000", got)
+	}
+	if got := SubIntFieldSaturatingAs(packed[:], balance, int16(3000)); got != -1000 {
+		t.Errorf("SubIntFieldSaturatingAs = %d, want -1000", got)
+	}
+	if got := GetIntFieldAs[int16](packed[:], balance); got != -1000 {
+		t.Errorf("stored balance = %d, want -1000", got)
+	}
+	if _, err := AddUIntFieldAs(packed[:1], mana, uint32(1)); !errors.Is(err, ErrFieldOutOfSlice) {
+		t.Errorf("AddUIntFieldAs(short slice) err = %v, want field out of slice", err)
+	}
+}
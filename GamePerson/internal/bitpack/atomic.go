@@ -0,0 +1,157 @@
+package bitpack
+
+import "sync/atomic"
+
+// =================  Атомарные обновления ==================================
+// ======== Lock-free изменение полей в общем 64-битном слове ===============
+//
+// Несколько горутин могут менять разные поля одного слова без мьютекса:
+// каждое обновление читает слово, применяет изменение к BitSet64 и
+// записывает его через compare-and-swap, повторяя попытку при конфликте.
+// Значения соседних полей, изменённые другими горутинами, не теряются.
+//
+//	var word atomic.Uint64
+//	go bitpack.AtomicSetUIntField(&word, healthField, 900)
+//	go bitpack.AtomicAddUIntField(&word, manaField, 5) // с насыщением до Max
+//
+// atomic.Uint64 гарантирует выравнивание по 8 байт на всех платформах.
+//
+// Поле должно лежать в пределах слова: поле многословного буфера (End ≥ 64,
+// см. Layout) checked-функции отвергают ошибкой ErrEndOutOfRange или
+// ErrPositionOutOfRange, а Unchecked-варианты не проверяют.
+
+// AtomicLoad читает слово целиком
+func AtomicLoad(word *atomic.Uint64) BitSet64 {
+	return BitSet64(word.Load())
+}
+
+// AtomicUpdate применяет update к слову через CAS-цикл и возвращает новое слово.
+// update может вызываться несколько раз и не должен иметь побочных эффектов.
+// Если update вернул ошибку, слово не меняется.
+func AtomicUpdate(word *atomic.Uint64, update func(BitSet64) (BitSet64, error)) (BitSet64, error) {
+	for {
+		old := word.Load()
+		bits, err := update(BitSet64(old))
+		if err != nil {
+			return BitSet64(old), err
+		}
+		if word.CompareAndSwap(old, uint64(bits)) {
+			return bits, nil
+		}
+	}
+}
+
+// ==================== Set ====================
+
+func AtomicSetUIntField(word *atomic.Uint64, field UIntBitField, value uint64) error {
+	if err := checkWordEnd(field.End, field.label); err != nil {
+		return err
+	}
+	_, err := AtomicUpdate(word, func(bits BitSet64) (BitSet64, error) {
+		return field.Update(bits, value)
+	})
+	return err
+}
+
+func AtomicSetUIntFieldUnchecked(word *atomic.Uint64, field UIntBitField, value uint64) {
+	_, _ = AtomicUpdate(word, func(bits BitSet64) (BitSet64, error) {
+		return field.UpdateUnchecked(bits, value), nil
+	})
+}
+
+func AtomicSetIntField(word *atomic.Uint64, field IntBitField, value int64) error {
+	if err := checkWordEnd(field.End, field.label); err != nil {
+		return err
+	}
+	_, err := AtomicUpdate(word, func(bits BitSet64) (BitSet64, error) {
+		return field.Update(bits, value)
+	})
+	return err
+}
+
+func AtomicSetIntFieldUnchecked(word *atomic.Uint64, field IntBitField, value int64) {
+	_, _ = AtomicUpdate(word, func(bits BitSet64) (BitSet64, error) {
+		return field.UpdateUnchecked(bits, value), nil
+	})
+}
+
+// AtomicSetBoolField для одного бита обходится без CAS-цикла: Or/And атомарны
+func AtomicSetBoolField(word *atomic.Uint64, field BoolBitField, value bool) error {
+	if err := checkWordPosition(field); err != nil {
+		return err
+	}
+	AtomicSetBoolFieldUnchecked(word, field, value)
+	return nil
+}
+
+func AtomicSetBoolFieldUnchecked(word *atomic.Uint64, field BoolBitField, value bool) {
+	if value {
+		word.Or(field.bitMask)
+	} else {
+		word.And(^field.bitMask)
+	}
+}
+
+// AtomicToggleBoolField инвертирует флаг и возвращает новое значение
+func AtomicToggleBoolField(word *atomic.Uint64, field BoolBitField) (bool, error) {
+	if err := checkWordPosition(field); err != nil {
+		return false, err
+	}
+	bits, _ := AtomicUpdate(word, func(bits BitSet64) (BitSet64, error) {
+		return field.Toggle(bits), nil
+	})
+	return field.Get(bits), nil
+}
+
+// ==================== Add / Sub с насыщением ====================
+// Результат прижимается к [Min, Max] поля, переполнения разрядов не бывает.
+// Возвращается значение поля после обновления; ошибка — только если поле
+// не лежит в слове.
+
+func AtomicAddUIntField(word *atomic.Uint64, field UIntBitField, delta uint64) (uint64, error) {
+	if err := checkWordEnd(field.End, field.label); err != nil {
+		return 0, err
+	}
+	bits, _ := AtomicUpdate(word, func(bits BitSet64) (BitSet64, error) {
+		return field.AddSaturating(bits, delta), nil
+	})
+	return field.Get(bits), nil
+}
+
+func AtomicSubUIntField(word *atomic.Uint64, field UIntBitField, delta uint64) (uint64, error) {
+	if err := checkWordEnd(field.End, field.label); err != nil {
+		return 0, err
+	}
+	bits, _ := AtomicUpdate(word, func(bits BitSet64) (BitSet64, error) {
+		return field.SubSaturating(bits, delta), nil
+	})
+	return field.Get(bits), nil
+}
+
+func AtomicAddIntField(word *atomic.Uint64, field IntBitField, delta int64) (int64, error) {
+	if err := checkWordEnd(field.End, field.label); err != nil {
+		return 0, err
+	}
+	bits, _ := AtomicUpdate(word, func(bits BitSet64) (BitSet64, error) {
+		return field.AddSaturating(bits, delta), nil
+	})
+	return field.Get(bits), nil
+}
+
+// ------------- Сервисные методы --------------------------------
+
+// checkWordEnd проверяет, что поле заканчивается в пределах 64-битного слова
+func checkWordEnd(end BitPosition, label *fieldLabel) error {
+	if end >= 64 {
+		return label.annotate(newEndOutOfRangeError(end))
+	}
+	return nil
+}
+
+// checkWordPosition проверяет, что флаг лежит в пределах 64-битного слова
+func checkWordPosition(field BoolBitField) error {
+	if field.Position >= 64 {
+		return field.label.annotate(newPositionOutOfRangeError(field.Position))
+	}
+	return nil
+}
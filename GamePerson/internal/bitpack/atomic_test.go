@@ -0,0 +1,183 @@
+package bitpack
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+)
+
+// ============ Тесты атомарных обновлений ============
+
+func TestAtomicSetFields(t *testing.T) {
+	var word atomic.Uint64
+	word.Store(0xFFFF_0000)
+
+	if err := AtomicSetUIntField(&word, VersionField, 9); err != nil {
+		t.Fatalf("AtomicSetUIntField: %v", err)
+	}
+	if err := AtomicSetUIntField(&word, VersionField, 16); !errors.Is(err, ErrValueOverflow) {
+		t.Errorf("AtomicSetUIntField(16) err = %v, want overflow", err)
+	}
+	delta := MustNewIntBitField(4, 6, -4, 3)
+	if err := AtomicSetIntField(&word, delta, -3); err != nil {
+		t.Fatalf("AtomicSetIntField: %v", err)
+	}
+	if err := AtomicSetBoolField(&word, EncryptedFlag, true); err != nil {
+		t.Fatalf("AtomicSetBoolField: %v", err)
+	}
+
+	bits := AtomicLoad(&word)
+	if VersionField.Get(bits) != 9 || delta.Get(bits) != -3 || !EncryptedFlag.Get(bits) {
+		t.Errorf("unexpected word %#x", uint64(bits))
+	}
+	if uint64(bits)&0xFFFF_0000 != 0xFFFF_0000 {
+		t.Errorf("neighbour bits changed: %#x", uint64(bits))
+	}
+
+	AtomicSetBoolFieldUnchecked(&word, EncryptedFlag, false)
+	if flag, err := AtomicToggleBoolField(&word, EncryptedFlag); err != nil || !flag {
+		t.Errorf("toggle = %v, %v; want new value true", flag, err)
+	}
+}
+
+// Поля многословного буфера не лежат в атомарном слове: checked-функции
+// отвергают их, а не пишут молча мимо слова
+func TestAtomicRejectsFieldsOutsideWord(t *testing.T) {
+	layout := NewLayout("wide", 128)
+	layout.AddUInt("low", math.MaxUint64) // биты 0-63
+	flag := layout.AddBool("flag")        // бит 64
+	high := layout.AddUInt("high", 15)    // биты 65-68
+	delta := layout.AddInt("delta", -4, 3)
+	if err := layout.Err(); err != nil {
+		t.Fatalf("layout: %v", err)
+	}
+
+	var word atomic.Uint64
+	word.Store(7)
+	checks := map[string]error{
+		"SetUInt": AtomicSetUIntField(&word, high, 1),
+		"SetInt":  AtomicSetIntField(&word, delta, 1),
+		"SetBool": AtomicSetBoolField(&word, flag, true),
+	}
+	_, checks["Toggle"] = AtomicToggleBoolField(&word, flag)
+	_, checks["AddUInt"] = AtomicAddUIntField(&word, high, 1)
+	_, checks["SubUInt"] = AtomicSubUIntField(&word, high, 1)
+	_, checks["AddInt"] = AtomicAddIntField(&word, delta, 1)
+
+	for name, err := range checks {
+		if !errors.Is(err, ErrEndOutOfRange) && !errors.Is(err, ErrPositionOutOfRange) {
+			t.Errorf("%s: err = %v, want out of range", name, err)
+		}
+	}
+	if err := AtomicSetBoolField(&word, flag, true); !strings.Contains(fmt.Sprint(err), "wide.flag") {
+		t.Errorf("error must name the field: %v", err)
+	}
+	if word.Load() != 7 {
+		t.Errorf("word changed: %d", word.Load())
+	}
+}
+
+func TestAtomicUpdateErrorKeepsWord(t *testing.T) {
+	var word atomic.Uint64
+	word.Store(42)
+	wantErr := errors.New("rejected")
+
+	bits, err := AtomicUpdate(&word, func(BitSet64) (BitSet64, error) { return 0, wantErr })
+	if !errors.Is(err, wantErr) || bits != 42 || word.Load() != 42 {
+		t.Errorf("AtomicUpdate() = %d, %v; word = %d", bits, err, word.Load())
+	}
+}
+
+func TestAtomicAddSaturates(t *testing.T) {
+	var word atomic.Uint64
+	mana := MustNewUIntBitField(0, 9, 1000)
+	level := MustNewBiasedUIntBitField(10, 13, 1, 10)
+	delta := MustNewIntBitField(14, 21, -100, 100)
+	AtomicSetUIntFieldUnchecked(&word, level, 1)
+
+	if got, err := AtomicAddUIntField(&word, mana, 990); err != nil || got != 990 {
+		t.Errorf("add = %d, want 990", got)
+	}
+	if got, err := AtomicAddUIntField(&word, mana, 20); err != nil || got != 1000 {
+		t.Errorf("add over max = %d, want 1000", got)
+	}
+	if got, err := AtomicAddUIntField(&word, mana, math.MaxUint64); err != nil || got != 1000 {
+		t.Errorf("add MaxUint64 = %d, want 1000", got)
+	}
+	if got, err := AtomicSubUIntField(&word, mana, 2000); err != nil || got != 0 {
+		t.Errorf("sub below zero = %d, want 0", got)
+	}
+	if got, err := AtomicSubUIntField(&word, level, 5); err != nil || got != 1 {
+		t.Errorf("sub below biased min = %d, want 1", got)
+	}
+	if got, err := AtomicAddIntField(&word, delta, -150); err != nil || got != -100 {
+		t.Errorf("add int below min = %d, want -100", got)
+	}
+	if got, err := AtomicAddIntField(&word, delta, math.MaxInt64); err != nil || got != 100 {
+		t.Errorf("add MaxInt64 = %d, want 100", got)
+	}
+	if got, err := AtomicAddIntField(&word, delta, math.MinInt64); err != nil || got != -100 {
+		t.Errorf("add MinInt64 = %d, want -100", got)
+	}
+}
+
+// TestAtomicConcurrentWriters — разные горутины меняют разные поля одного слова.
+// Запускать с -race: go test -race ./internal/bitpack
+func TestAtomicConcurrentWriters(t *testing.T) {
+	const writers, iterations = 16, 500
+
+	var word atomic.Uint64
+	health := MustNewUIntBitField(0, 19, 1_000_000)
+	mana := MustNewUIntBitField(20, 39, 1_000_000)
+	balance := MustNewIntBitField(40, 59, -500_000, 500_000)
+	flag := MustNewBoolBitField(63)
+
+	var wg sync.WaitGroup
+	for w := range writers {
+		wg.Go(func() {
+			for range iterations {
+				AtomicAddUIntField(&word, health, 3)
+			}
+		})
+		wg.Go(func() {
+			for range iterations {
+				AtomicAddUIntField(&word, mana, 2)
+				AtomicSubUIntField(&word, mana, 1)
+			}
+		})
+		wg.Go(func() {
+			for i := range iterations {
+				if (w+i)%2 == 0 {
+					AtomicAddIntField(&word, balance, 7)
+				} else {
+					AtomicAddIntField(&word, balance, -5)
+				}
+			}
+		})
+		wg.Go(func() {
+			for range iterations {
+				AtomicToggleBoolField(&word, flag)
+			}
+		})
+	}
+	wg.Wait()
+
+	bits := AtomicLoad(&word)
+	if got, want := health.Get(bits), uint64(writers*iterations*3); got != want {
+		t.Errorf("health = %d, want %d (lost updates)", got, want)
+	}
+	if got, want := mana.Get(bits), uint64(writers*iterations); got != want {
+		t.Errorf("mana = %d, want %d (lost updates)", got, want)
+	}
+	if got, want := balance.Get(bits), int64(writers*iterations/2*(7-5)); got != want {
+		t.Errorf("balance = %d, want %d (lost updates)", got, want)
+	}
+	// Чётное общее число переключений возвращает флаг в false
+	if flag.Get(bits) {
+		t.Error("flag toggled odd number of times")
+	}
+}
@@ -14,6 +14,7 @@ type IntBitField struct {
 	Min   int64       // Минимальное допустимое значение
 	Max   int64       // Максимальное допустимое значение
 	mask  uint64      // Кэшированная маска для извлечения битов
+	label *fieldLabel // Имя поля и схемы для ошибок (nil — безымянное)
 }
 
 // NewIntBitField Конструктор создаёт битовое поле для знаковых целых с валидацией диапазона
@@ -24,7 +25,12 @@ func NewIntBitField(start, end BitPosition, min, max int64) (IntBitField, error)
 	if end >= 64 {
 		return IntBitField{}, newEndOutOfRangeError(end)
 	}
+	return newIntBitField(start, end, min, max)
+}
 
+// newIntBitField строит поле без ограничения end < 64 (для многословных буферов).
+// Вызывающий код гарантирует start <= end и ширину не более 64 бит.
+func newIntBitField(start, end BitPosition, min, max int64) (IntBitField, error) {
 	width := end - start + 1
 	allowedMin, allowedMax := intRangeForWidth(width)
 
@@ -71,7 +77,11 @@ func NewIntBitFieldAuto(start, end BitPosition) (IntBitField, error) {
 func (bf IntBitField) Get(bitSet BitSet64) int64 {
 	// Извлекаем биты как беззнаковое число
 	raw := (uint64(bitSet) >> bf.Start) & bf.mask
+	return bf.signExtend(raw)
+}
 
+// signExtend восстанавливает знак значения из width младших битов raw
+func (bf IntBitField) signExtend(raw uint64) int64 {
 	// Знаковое расширение (sign extension) через арифметический сдвиг
 	width := bf.Width()
 	if width == 64 {
@@ -87,7 +97,7 @@ func (bf IntBitField) Get(bitSet BitSet64) int64 {
 // Update записывает знаковое значение в bitSet с валидацией диапазона
 func (bf IntBitField) Update(bitSet BitSet64, value int64) (BitSet64, error) {
 	if value < bf.Min || value > bf.Max {
-		return bitSet, newValueOutOfRangeError(value, value, bf.Min, bf.Max, bf.Width())
+		return bitSet, bf.label.annotate(newValueOutOfRangeError(value, value, bf.Min, bf.Max, bf.Width()))
 	}
 	return bf.UpdateUnchecked(bitSet, value), nil
 
@@ -120,6 +130,22 @@ func (bf IntBitField) Width() uint8 {
 	return bf.End - bf.Start + 1
 }
 
+// Named возвращает копию поля с именем: ошибки, полученные через него, содержат schema.name
+func (bf IntBitField) Named(schema, name string) IntBitField {
+	bf.label = newFieldLabel(schema, name)
+	return bf
+}
+
+// Name — имя поля (пусто для безымянного)
+func (bf IntBitField) Name() string {
+	return bf.label.fieldName()
+}
+
+// Schema — имя схемы, которой принадлежит поле
+func (bf IntBitField) Schema() string {
+	return bf.label.schemaName()
+}
+
 // Строковое представление для отладки
 func (bf IntBitField) String() string {
 	return fmt.Sprintf("IntBitField[%d:%d] range=[%d,%d]", bf.Start, bf.End, bf.Min, bf.Max)
@@ -131,24 +157,41 @@ func (bf IntBitField) String() string {
 type UIntBitField struct {
 	Start BitPosition // Начальная позиция бита в структуре (включительно)
 	End   BitPosition // Конечная позиция бита в структуре (включительно)
+	Min   uint64      // Минимальное значение (смещение): в битах хранится value-Min
 	Max   uint64      // Максимальное значение для хранения в структуре битов
 	mask  uint64      // Кэшированная маска для производительности
+	label *fieldLabel // Имя поля и схемы для ошибок (nil — безымянное)
 }
 
 // Конструктор NewUIntBitField создаёт битовое поле с проверкой на ошибки логики хранения
 
 func NewUIntBitField(start, end BitPosition, max uint64) (UIntBitField, error) {
+	return NewBiasedUIntBitField(start, end, 0, max)
+}
+
+// NewBiasedUIntBitField создаёт поле для значений [min, max], хранящее value-min.
+// Диапазон 1000..1063 помещается в 6 бит, значения меньше min отклоняются.
+func NewBiasedUIntBitField(start, end BitPosition, min, max uint64) (UIntBitField, error) {
 	if start > end {
 		return UIntBitField{}, newStartAfterEndError(start, end)
 	}
 	if end >= 64 {
 		return UIntBitField{}, newEndOutOfRangeError(end)
 	}
+	return newUIntBitField(start, end, min, max)
+}
+
+// newUIntBitField строит поле без ограничения end < 64 (для многословных буферов).
+// Вызывающий код гарантирует start <= end и ширину не более 64 бит.
+func newUIntBitField(start, end BitPosition, min, max uint64) (UIntBitField, error) {
+	if min > max {
+		return UIntBitField{}, newValueRangeInvertedError(int64(min), int64(max))
+	}
 
 	width := end - start + 1
 	allowedMax := maxAllowedForWidth(width)
-	if max > allowedMax {
-		return UIntBitField{}, newValueOverflowError(max, allowedMax, width)
+	if max-min > allowedMax {
+		return UIntBitField{}, newValueOverflowError(max-min, allowedMax, width)
 	}
 
 	// Кэшируем маску при создании — поля иммутабельны
@@ -157,6 +200,7 @@ func NewUIntBitField(start, end BitPosition, max uint64) (UIntBitField, error) {
 	return UIntBitField{
 		Start: start,
 		End:   end,
+		Min:   min,
 		Max:   max,
 		mask:  mask,
 	}, nil
@@ -174,24 +218,39 @@ func MustNewUIntBitField(start, end BitPosition, max uint64) UIntBitField {
 	return bf
 }
 
+// MustNewBiasedUIntBitField создаёт поле со смещением или паникует при ошибке конфигурации
+// Используется ТОЛЬКО для статических конфигураций
+
+func MustNewBiasedUIntBitField(start, end BitPosition, min, max uint64) UIntBitField {
+	bf, err := NewBiasedUIntBitField(start, end, min, max)
+	if err != nil {
+		panic(fmt.Sprintf("FATAL: invalid static bit field configuration [start=%d, end=%d, min=%d, max=%d]: %v",
+			start, end, min, max, err))
+	}
+	return bf
+}
+
 // Get - извлекает из указанного bitSet значение целого типа согласно конфигурации поля
 
 func (bf UIntBitField) Get(bitSet BitSet64) uint64 {
-	return (uint64(bitSet) >> bf.Start) & bf.mask
+	return ((uint64(bitSet) >> bf.Start) & bf.mask) + bf.Min
 }
 
 // Update - Возвращает новое значение 64 битной карты, после изменения значения
 
 func (bf UIntBitField) Update(bitSet BitSet64, value uint64) (BitSet64, error) {
+	if value < bf.Min {
+		return bitSet, bf.label.annotate(newValueUnderflowError(value, bf.Min, bf.Width()))
+	}
 	if value > bf.Max {
-		return bitSet, newValueOverflowError(value, bf.Max, bf.Width())
+		return bitSet, bf.label.annotate(newValueOverflowError(value, bf.Max, bf.Width()))
 	}
 	return bf.UpdateUnchecked(bitSet, value), nil
 }
 
 func (bf UIntBitField) UpdateUnchecked(bitSet BitSet64, value uint64) BitSet64 {
 	fieldMask := bf.mask << bf.Start
-	return BitSet64((uint64(bitSet) & ^fieldMask) | ((value & bf.mask) << bf.Start))
+	return BitSet64((uint64(bitSet) & ^fieldMask) | (((value - bf.Min) & bf.mask) << bf.Start))
 }
 
 // ------------- Сервисные методы --------------------------------
@@ -200,8 +259,27 @@ func (bf UIntBitField) Width() uint8 {
 	return bf.End - bf.Start + 1
 }
 
+// Named возвращает копию поля с именем: ошибки, полученные через него, содержат schema.name
+func (bf UIntBitField) Named(schema, name string) UIntBitField {
+	bf.label = newFieldLabel(schema, name)
+	return bf
+}
+
+// Name — имя поля (пусто для безымянного)
+func (bf UIntBitField) Name() string {
+	return bf.label.fieldName()
+}
+
+// Schema — имя схемы, которой принадлежит поле
+func (bf UIntBitField) Schema() string {
+	return bf.label.schemaName()
+}
+
 // Строковое представление для отладки
 func (bf UIntBitField) String() string {
+	if bf.Min != 0 {
+		return fmt.Sprintf("UIntBitField[%d:%d] range=[%d,%d]", bf.Start, bf.End, bf.Min, bf.Max)
+	}
 	return fmt.Sprintf("UIntBitField[%d:%d] max=%d", bf.Start, bf.End, bf.Max)
 }
 
@@ -211,16 +289,22 @@ func (bf UIntBitField) String() string {
 type BoolBitField struct {
 	Position BitPosition // Начальная позиция бита в структуре (включительно)
 	bitMask  uint64      // кэшированная маска: 1 << Position
+	label    *fieldLabel // Имя поля и схемы для ошибок (nil — безымянное)
 }
 
 func NewBoolBitField(pos BitPosition) (BoolBitField, error) {
 	if pos >= 64 {
 		return BoolBitField{}, newPositionOutOfRangeError(pos)
 	}
+	return newBoolBitField(pos), nil
+}
+
+// newBoolBitField строит флаг без ограничения pos < 64 (для многословных буферов)
+func newBoolBitField(pos BitPosition) BoolBitField {
 	return BoolBitField{
 		Position: pos,
 		bitMask:  uint64(1) << pos,
-	}, nil
+	}
 }
 
 // MustNewBoolBitField создаёт булево битовое поле и паникует при ошибках
@@ -268,6 +352,22 @@ func (bf BoolBitField) Toggle(bitSet BitSet64) BitSet64 {
 	return BitSet64(uint64(bitSet) ^ bf.bitMask)
 }
 
+// Named возвращает копию поля с именем: ошибки, полученные через него, содержат schema.name
+func (bf BoolBitField) Named(schema, name string) BoolBitField {
+	bf.label = newFieldLabel(schema, name)
+	return bf
+}
+
+// Name — имя поля (пусто для безымянного)
+func (bf BoolBitField) Name() string {
+	return bf.label.fieldName()
+}
+
+// Schema — имя схемы, которой принадлежит поле
+func (bf BoolBitField) Schema() string {
+	return bf.label.schemaName()
+}
+
 // Строковое представление для отладки
 func (bf BoolBitField) String() string {
 	return fmt.Sprintf("\"BoolBitField[%d] ", bf.Position)
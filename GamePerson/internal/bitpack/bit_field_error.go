@@ -11,6 +11,16 @@ type Error struct {
 	Details errorDetails
 }
 
+// Field — имя поля, на котором произошла ошибка (пусто для безымянных полей)
+func (e *Error) Field() string {
+	return e.Details.FieldName
+}
+
+// Schema — имя схемы (Layout), которой принадлежит поле
+func (e *Error) Schema() string {
+	return e.Details.Schema
+}
+
 type ErrorKind int
 
 const (
@@ -22,6 +32,26 @@ const (
 	KindPositionOutOfRange
 	KindSliceEmpty
 	KindSliceTooLarge
+	KindFieldOverlap
+	KindLayoutOverflow
+	KindDuplicateField
+	KindFieldOutOfSlice
+	KindEnumValueUnknown
+	KindEnumNameUnknown
+	KindEnumDuplicate
+	KindEnumDefinition
+	KindFixedOutOfRange
+	KindFixedDefinition
+	KindViewTooLarge
+	KindIndexOutOfRange
+	KindArrayDefinition
+	KindUnknownField
+	KindFieldKindMismatch
+	KindInvalidTag
+	KindUnsupportedType
+	KindStreamTruncated
+	KindVarintOverflow
+	KindInvalidWidth
 )
 
 type errorDetails struct {
@@ -35,9 +65,47 @@ type errorDetails struct {
 	MaxValue    int64
 	SignedValue int64
 	SliceLength int
+	FieldName   string
+	Schema      string
+	OtherField  string
+	LayoutBits  int
+	EnumName    string
+	ValueCount  int
+	NameCount   int
+	FloatValue  float64
+	FloatMin    float64
+	FloatMax    float64
+	FloatStep   float64
+	Index       int
+	Length      int
+	FieldKind   FieldKind
+	Operation   string
+	Tag         string
+	TypeName    string
+	Reason      string
+	NeedBits    int
+	BitOffset   int
+	StreamBits  int
 }
 
+// Error добавляет к описанию ошибки имя поля (schema.field), если оно известно.
+// Ошибки Layout уже содержат имя поля в тексте.
 func (e *Error) Error() string {
+	msg := e.message()
+	if e.Details.FieldName == "" || e.isLayoutError() {
+		return msg
+	}
+	if e.Details.Schema != "" {
+		return fmt.Sprintf("field %s.%s: %s", e.Details.Schema, e.Details.FieldName, msg)
+	}
+	return fmt.Sprintf("field %s: %s", e.Details.FieldName, msg)
+}
+
+func (e *Error) isLayoutError() bool {
+	return e.Kind == KindFieldOverlap || e.Kind == KindLayoutOverflow || e.Kind == KindDuplicateField
+}
+
+func (e *Error) message() string {
 	switch e.Kind {
 	case KindStartAfterEnd:
 		return fmt.Sprintf("bit field range error: start position (%d) must be <= end position (%d)",
@@ -62,7 +130,56 @@ func (e *Error) Error() string {
 	case KindSliceEmpty:
 		return "packed slice is empty"
 	case KindSliceTooLarge:
-		return fmt.Sprintf("packed slice too large: %d bytes (max 8)", e.Details.SliceLength)
+		return fmt.Sprintf("packed slice too large: %d bytes (max %d)", e.Details.SliceLength, MaxPackedBytes)
+	case KindFieldOverlap:
+		return fmt.Sprintf("layout error: field %q [%d:%d] overlaps field %q",
+			e.Details.FieldName, e.Details.Start, e.Details.End, e.Details.OtherField)
+	case KindLayoutOverflow:
+		return fmt.Sprintf("layout error: field %q [%d:%d] does not fit into %d-bit layout",
+			e.Details.FieldName, e.Details.Start, e.Details.End, e.Details.LayoutBits)
+	case KindFieldOutOfSlice:
+		return fmt.Sprintf("bit field end position (%d) is outside of %d-byte packed slice",
+			e.Details.End, e.Details.SliceLength)
+	case KindEnumValueUnknown:
+		return fmt.Sprintf("enum value %d is not in the list of allowed values", e.Details.Value)
+	case KindEnumNameUnknown:
+		return fmt.Sprintf("enum name %q is not in the list of allowed names", e.Details.EnumName)
+	case KindEnumDuplicate:
+		return fmt.Sprintf("enum definition error: value %d or name %q is empty or duplicated",
+			e.Details.Value, e.Details.EnumName)
+	case KindEnumDefinition:
+		return fmt.Sprintf("enum definition error: %d values and %d names (want equal, non-zero)",
+			e.Details.ValueCount, e.Details.NameCount)
+	case KindFixedOutOfRange:
+		return fmt.Sprintf("fixed-point value %g is outside of range [%g, %g]",
+			e.Details.FloatValue, e.Details.FloatMin, e.Details.FloatMax)
+	case KindFixedDefinition:
+		return fmt.Sprintf("fixed-point definition error: range [%g, %g] with step %g (want finite min < max and range multiple of step)",
+			e.Details.FloatMin, e.Details.FloatMax, e.Details.FloatStep)
+	case KindViewTooLarge:
+		return fmt.Sprintf("packed slice too large for view: %d bytes (max 8)", e.Details.SliceLength)
+	case KindIndexOutOfRange:
+		return fmt.Sprintf("packed array index %d is out of range [0, %d)", e.Details.Index, e.Details.Length)
+	case KindArrayDefinition:
+		return fmt.Sprintf("packed array definition error: %d elements of %d bits from bit %d (want width 1..64, len > 0, within %d bits)",
+			e.Details.Length, e.Details.BitWidth, e.Details.Start, MaxPackedBits)
+	case KindDuplicateField:
+		return fmt.Sprintf("layout error: duplicate field name %q", e.Details.FieldName)
+	case KindUnknownField:
+		return "schema has no such field"
+	case KindFieldKindMismatch:
+		return fmt.Sprintf("%s field does not support %s", e.Details.FieldKind, e.Details.Operation)
+	case KindInvalidTag:
+		return fmt.Sprintf("invalid bitpack tag %q: %s", e.Details.Tag, e.Details.Reason)
+	case KindUnsupportedType:
+		return fmt.Sprintf("unsupported type %s: %s", e.Details.TypeName, e.Details.Reason)
+	case KindStreamTruncated:
+		return fmt.Sprintf("bit stream truncated: reading %d bits at bit offset %d of %d-bit stream",
+			e.Details.NeedBits, e.Details.BitOffset, e.Details.StreamBits)
+	case KindVarintOverflow:
+		return fmt.Sprintf("varint at bit offset %d overflows 64 bits", e.Details.BitOffset)
+	case KindInvalidWidth:
+		return fmt.Sprintf("bit width %d is out of range [1, 64]", e.Details.BitWidth)
 	default:
 		return "unknown bit field error"
 	}
@@ -87,8 +204,70 @@ var (
 	ErrPositionOutOfRange = &Error{Kind: KindPositionOutOfRange}
 	ErrSliceEmpty         = &Error{Kind: KindSliceEmpty}
 	ErrSliceTooLarge      = &Error{Kind: KindSliceTooLarge}
+	ErrFieldOverlap       = &Error{Kind: KindFieldOverlap}
+	ErrLayoutOverflow     = &Error{Kind: KindLayoutOverflow}
+	ErrDuplicateField     = &Error{Kind: KindDuplicateField}
+	ErrFieldOutOfSlice    = &Error{Kind: KindFieldOutOfSlice}
+	ErrEnumValueUnknown   = &Error{Kind: KindEnumValueUnknown}
+	ErrEnumNameUnknown    = &Error{Kind: KindEnumNameUnknown}
+	ErrEnumDuplicate      = &Error{Kind: KindEnumDuplicate}
+	ErrEnumDefinition     = &Error{Kind: KindEnumDefinition}
+	ErrFixedOutOfRange    = &Error{Kind: KindFixedOutOfRange}
+	ErrFixedDefinition    = &Error{Kind: KindFixedDefinition}
+	ErrViewTooLarge       = &Error{Kind: KindViewTooLarge}
+	ErrIndexOutOfRange    = &Error{Kind: KindIndexOutOfRange}
+	ErrArrayDefinition    = &Error{Kind: KindArrayDefinition}
+	ErrUnknownField       = &Error{Kind: KindUnknownField}
+	ErrFieldKindMismatch  = &Error{Kind: KindFieldKindMismatch}
+	ErrInvalidTag         = &Error{Kind: KindInvalidTag}
+	ErrUnsupportedType    = &Error{Kind: KindUnsupportedType}
+	ErrStreamTruncated    = &Error{Kind: KindStreamTruncated}
+	ErrVarintOverflow     = &Error{Kind: KindVarintOverflow}
+	ErrInvalidWidth       = &Error{Kind: KindInvalidWidth}
 )
 
+// ==================== Контекст ошибок: имя поля и схемы ====================
+
+// fieldLabel — имя поля и схемы, которое попадает в ошибки, возвращённые через поле.
+// Хранится по указателю: поле остаётся компактным, а путь без ошибок не меняется.
+type fieldLabel struct {
+	schema string
+	name   string
+}
+
+func newFieldLabel(schema, name string) *fieldLabel {
+	if name == "" {
+		return nil
+	}
+	return &fieldLabel{schema: schema, name: name}
+}
+
+func (l *fieldLabel) fieldName() string {
+	if l == nil {
+		return ""
+	}
+	return l.name
+}
+
+func (l *fieldLabel) schemaName() string {
+	if l == nil {
+		return ""
+	}
+	return l.schema
+}
+
+// annotate дописывает имя поля в *Error, если оно ещё не указано
+func (l *fieldLabel) annotate(err error) error {
+	if l == nil || err == nil {
+		return err
+	}
+	if e, ok := err.(*Error); ok && e.Details.FieldName == "" {
+		e.Details.FieldName = l.name
+		e.Details.Schema = l.schema
+	}
+	return err
+}
+
 // Вспомогательные конструкторы
 func newStartAfterEndError(start, end BitPosition) error {
 	return &Error{
@@ -113,6 +292,15 @@ func newValueOverflowError(value, allowedMax uint64, width uint8) error {
 	}
 }
 
+func newValueUnderflowError(value, min uint64, width uint8) error {
+	return &Error{
+		Kind: KindValueUnderflow,
+		Details: errorDetails{
+			SignedValue: int64(value), MinValue: int64(min), BitWidth: width,
+		},
+	}
+}
+
 func newPositionOutOfRangeError(pos BitPosition) error {
 	return &Error{
 		Kind:    KindPositionOutOfRange,
@@ -159,3 +347,143 @@ func newSliceTooLargeError(length int) error {
 		Details: errorDetails{SliceLength: length},
 	}
 }
+
+func newFieldOverlapError(name string, start, end BitPosition, other string) error {
+	return &Error{
+		Kind:    KindFieldOverlap,
+		Details: errorDetails{FieldName: name, Start: start, End: end, OtherField: other},
+	}
+}
+
+func newLayoutOverflowError(name string, start, end BitPosition, layoutBits int) error {
+	return &Error{
+		Kind:    KindLayoutOverflow,
+		Details: errorDetails{FieldName: name, Start: start, End: end, LayoutBits: layoutBits},
+	}
+}
+
+func newDuplicateFieldError(name string) error {
+	return &Error{
+		Kind:    KindDuplicateField,
+		Details: errorDetails{FieldName: name},
+	}
+}
+
+func newFieldOutOfSliceError(end BitPosition, length int) error {
+	return &Error{
+		Kind:    KindFieldOutOfSlice,
+		Details: errorDetails{End: end, SliceLength: length},
+	}
+}
+
+func newEnumValueUnknownError(value uint64) error {
+	return &Error{
+		Kind:    KindEnumValueUnknown,
+		Details: errorDetails{Value: value},
+	}
+}
+
+func newEnumNameUnknownError(name string) error {
+	return &Error{
+		Kind:    KindEnumNameUnknown,
+		Details: errorDetails{EnumName: name},
+	}
+}
+
+func newEnumDuplicateError(value uint64, name string) error {
+	return &Error{
+		Kind:    KindEnumDuplicate,
+		Details: errorDetails{Value: value, EnumName: name},
+	}
+}
+
+func newEnumDefinitionError(values, names int) error {
+	return &Error{
+		Kind:    KindEnumDefinition,
+		Details: errorDetails{ValueCount: values, NameCount: names},
+	}
+}
+
+func newFixedOutOfRangeError(value, min, max float64) error {
+	return &Error{
+		Kind:    KindFixedOutOfRange,
+		Details: errorDetails{FloatValue: value, FloatMin: min, FloatMax: max},
+	}
+}
+
+func newFixedDefinitionError(min, max, step float64) error {
+	return &Error{
+		Kind:    KindFixedDefinition,
+		Details: errorDetails{FloatMin: min, FloatMax: max, FloatStep: step},
+	}
+}
+
+func newViewTooLargeError(length int) error {
+	return &Error{
+		Kind:    KindViewTooLarge,
+		Details: errorDetails{SliceLength: length},
+	}
+}
+
+func newIndexOutOfRangeError(index, length int) error {
+	return &Error{
+		Kind:    KindIndexOutOfRange,
+		Details: errorDetails{Index: index, Length: length},
+	}
+}
+
+func newArrayDefinitionError(start BitPosition, width uint8, length int) error {
+	return &Error{
+		Kind:    KindArrayDefinition,
+		Details: errorDetails{Start: start, BitWidth: width, Length: length},
+	}
+}
+
+func newUnknownFieldError(schema, name string) error {
+	return &Error{
+		Kind:    KindUnknownField,
+		Details: errorDetails{FieldName: name, Schema: schema},
+	}
+}
+
+func newFieldKindMismatchError(kind FieldKind, operation string) error {
+	return &Error{
+		Kind:    KindFieldKindMismatch,
+		Details: errorDetails{FieldKind: kind, Operation: operation},
+	}
+}
+
+func newInvalidTagError(tag, reason string) error {
+	return &Error{
+		Kind:    KindInvalidTag,
+		Details: errorDetails{Tag: tag, Reason: reason},
+	}
+}
+
+func newUnsupportedTypeError(typeName, reason string) error {
+	return &Error{
+		Kind:    KindUnsupportedType,
+		Details: errorDetails{TypeName: typeName, Reason: reason},
+	}
+}
+
+func newStreamTruncatedError(need, offset, streamBits int) error {
+	return &Error{
+		Kind:    KindStreamTruncated,
+		Details: errorDetails{NeedBits: need, BitOffset: offset, StreamBits: streamBits},
+	}
+}
+
+func newVarintOverflowError(offset int) error {
+	return &Error{
+		Kind:    KindVarintOverflow,
+		Details: errorDetails{BitOffset: offset},
+	}
+}
+
+func newInvalidWidthError(width uint8) error {
+	return &Error{
+		Kind:    KindInvalidWidth,
+		Details: errorDetails{BitWidth: width},
+	}
+}
@@ -574,7 +574,7 @@ func TestNewIntBitField(t *testing.T) {
 			end:     3, // 4 bits → min=-8
 			min:     -9,
 			max:     7,
-			wantErr: KindValueOverflow,
+			wantErr: KindValueUnderflow,
 		},
 		{
 			name:    "max above allowed for width",
@@ -811,7 +811,7 @@ func TestIntBitFieldUpdate(t *testing.T) {
 			initial:     BitSet64(0),
 			value:       -51,
 			want:        0,
-			wantErrKind: KindValueOverflow,
+			wantErrKind: KindValueUnderflow,
 		},
 		{
 			name:        "value above max",
@@ -1165,6 +1165,103 @@ func TestUIntBitFieldBoundaryValues(t *testing.T) {
 	}
 }
 
+// ============ Тесты для UIntBitField со смещением ============
+
+func TestNewBiasedUIntBitField(t *testing.T) {
+	tests := []struct {
+		name     string
+		start    BitPosition
+		end      BitPosition
+		min, max uint64
+		wantErr  error
+	}{
+		{"1000..1063 in 6 bits", 0, 5, 1000, 1063, nil},
+		{"1000..1064 overflows 6 bits", 0, 5, 1000, 1064, ErrValueOverflow},
+		{"min equals max", 0, 0, 7, 7, nil},
+		{"inverted range", 0, 7, 10, 1, ErrValueRangeInverted},
+		{"end out of range", 60, 64, 0, 1, ErrEndOutOfRange},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := NewBiasedUIntBitField(tt.start, tt.end, tt.min, tt.max)
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("err = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestBiasedUIntBitFieldUpdate(t *testing.T) {
+	field := MustNewBiasedUIntBitField(4, 9, 1000, 1063)
+	neighbours := BitSet64(0b1111)
+
+	for _, v := range []uint64{1000, 1031, 1063} {
+		bits, err := field.Update(neighbours, v)
+		if err != nil {
+			t.Fatalf("Update(%d): %v", v, err)
+		}
+		if got := field.Get(bits); got != v {
+			t.Errorf("Get() = %d, want %d", got, v)
+		}
+		if raw := (uint64(bits) >> 4) & 0x3F; raw != v-1000 {
+			t.Errorf("stored raw = %d, want %d", raw, v-1000)
+		}
+		if bits&0b1111 != 0b1111 {
+			t.Errorf("neighbour bits changed: %b", bits)
+		}
+	}
+
+	if _, err := field.Update(0, 999); !errors.Is(err, ErrValueUnderflow) {
+		t.Errorf("Update(999) err = %v, want underflow", err)
+	}
+	if _, err := field.Update(0, 1064); !errors.Is(err, ErrValueOverflow) {
+		t.Errorf("Update(1064) err = %v, want overflow", err)
+	}
+
+	// Нулевые биты соответствуют Min
+	if got := field.Get(0); got != 1000 {
+		t.Errorf("Get(0) = %d, want Min 1000", got)
+	}
+}
+
+func TestBiasedUIntFieldSliceFunctions(t *testing.T) {
+	field := MustNewBiasedUIntBitField(3, 6, 1, 10)
+	wide := NewLayout("wide", 96)
+	wide.Seek(60)
+	level := wide.AddBiasedUInt("level", 1, 10)
+	if err := wide.Err(); err != nil {
+		t.Fatalf("layout error: %v", err)
+	}
+
+	var small Packed16
+	var large Packed96
+	for _, tc := range []struct {
+		packed []byte
+		field  UIntBitField
+	}{{small[:], field}, {large[:], level}} {
+		if err := SetUIntFieldAs(tc.packed, tc.field, uint8(0)); !errors.Is(err, ErrValueUnderflow) {
+			t.Errorf("SetUIntFieldAs(0) err = %v, want underflow", err)
+		}
+		if err := SetUIntFieldAs(tc.packed, tc.field, uint8(7)); err != nil {
+			t.Fatalf("SetUIntFieldAs(7): %v", err)
+		}
+		if got := GetUIntFieldAs[uint8](tc.packed, tc.field); got != 7 {
+			t.Errorf("GetUIntFieldAs() = %d, want 7", got)
+		}
+	}
+	if got := (uint64(small[0]) >> 3) & 0xF; got != 6 {
+		t.Errorf("stored raw = %d, want 6", got)
+	}
+}
+
+func TestBiasedUIntBitFieldString(t *testing.T) {
+	field := MustNewBiasedUIntBitField(0, 3, 1, 10)
+	if got, want := field.String(), "UIntBitField[0:3] range=[1,10]"; got != want {
+		t.Errorf("String() = %q, want %q", got, want)
+	}
+}
+
 // ============ Дополнительные тесты для IntBitField ============
 
 func TestIntBitFieldUpdateUnchecked(t *testing.T) {
@@ -1231,7 +1328,7 @@ func TestIntBitFieldInvalidRanges(t *testing.T) {
 			end:     3, // 4 бита: -8..7
 			min:     -10,
 			max:     7,
-			wantErr: KindValueOverflow,
+			wantErr: KindValueUnderflow,
 		},
 		{
 			name:    "max above allowed range",
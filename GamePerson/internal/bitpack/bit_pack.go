@@ -13,11 +13,27 @@
 // # Требования для Unchecked версий
 //
 // Вызывающий код ОБЯЗАН гарантировать:
-//   - len(packed) > 0 и len(packed) <= 8
+//   - len(packed) > 0 и len(packed) <= MaxPackedBytes
+//   - поле целиком помещается в packed
 //   - value находится в допустимом диапазоне для поля
 //
+// # Буферы больше 64 бит
+//
+// Функции над срезом работают с буферами до MaxPackedBytes байт (Packed96, Packed128 ...).
+// Поля могут располагаться в любом месте буфера, в том числе пересекать
+// границу 64-битных слов. Для срезов до 8 байт используется быстрый путь
+// через BitSet64, для больших — побайтовое чтение/запись только затронутых байтов.
+// Методы полей над BitSet64 (Get/Update) применимы только к полям в первых 64 битах.
+//
 // Нарушение этих требований приводит к undefined behavior.
 //
+// # Порядок байтов
+//
+// Функции без явного порядка работают в little-endian (бит n — в байте n/8).
+// Для big-endian буферов у всех видов полей есть варианты с порядком
+// (см. ByteOrder): BigEndian.SetUInt, BigEndian.GetFixed, SetEnumFieldOrder,
+// BigEndian.NewView, PackedArray.WithOrder, Schema.WithOrder ...
+//
 // # Пример использования
 //
 //   // Пользовательский ввод - используем checked
@@ -38,47 +54,68 @@ import (
 
 // ==================== Простые утилиты ====================
 
+// UnpackBytes читает буфер до 8 байт в порядке little-endian (см. ByteOrder)
 func UnpackBytes(data []byte) BitSet64 {
-	var result BitSet64
-	for i, b := range data {
-		result |= BitSet64(b) << (8 * i)
-	}
-	return result
+	return LittleEndian.Unpack(data)
 }
 
+// PackBytes записывает слово в буфер до 8 байт в порядке little-endian (см. ByteOrder)
 func PackBytes(data []byte, value BitSet64) {
-	for i := range data {
-		data[i] = byte(value >> (8 * i))
-	}
+	LittleEndian.Pack(data, value)
 }
 
 // ==================== Get ====================
 
 func GetUIntFieldAs[T UnsignedInteger](packed []byte, field UIntBitField) T {
-	return T(field.Get(UnpackBytes(packed)))
+	if len(packed) <= 8 {
+		return T(field.Get(UnpackBytes(packed)))
+	}
+	return T(readBits(packed, field.Start, field.Width()) + field.Min)
 }
 
 func GetIntFieldAs[T SignedInteger](packed []byte, field IntBitField) T {
-	return T(field.Get(UnpackBytes(packed)))
+	if len(packed) <= 8 {
+		return T(field.Get(UnpackBytes(packed)))
+	}
+	return T(field.signExtend(readBits(packed, field.Start, field.Width())))
 }
 
 func GetBoolField(packed []byte, field BoolBitField) bool {
-	return field.Get(UnpackBytes(packed))
+	if len(packed) <= 8 {
+		return field.Get(UnpackBytes(packed))
+	}
+	return readBits(packed, field.Position, 1) != 0
 }
 
 // ==================== Set Unchecked версии ====================
 
 func SetUIntFieldUncheckedAs[T UnsignedInteger](packed []byte, field UIntBitField, value T) {
+	if len(packed) > 8 {
+		writeBits(packed, field.Start, field.Width(), uint64(value)-field.Min)
+		return
+	}
 	bits := field.UpdateUnchecked(UnpackBytes(packed), uint64(value))
 	PackBytes(packed, bits)
 }
 
 func SetIntFieldUncheckedAs[T SignedInteger](packed []byte, field IntBitField, value T) {
+	if len(packed) > 8 {
+		writeBits(packed, field.Start, field.Width(), uint64(value))
+		return
+	}
 	bits := field.UpdateUnchecked(UnpackBytes(packed), int64(value))
 	PackBytes(packed, bits)
 }
 
 func SetBoolFieldUnchecked(packed []byte, field BoolBitField, value bool) {
+	if len(packed) > 8 {
+		var bit uint64
+		if value {
+			bit = 1
+		}
+		writeBits(packed, field.Position, 1, bit)
+		return
+	}
 	bits := UnpackBytes(packed) // Сначала читаем текущее состояние
 	if value {
 		bits = field.Set(bits)
@@ -91,49 +128,123 @@ func SetBoolFieldUnchecked(packed []byte, field BoolBitField, value bool) {
 // ==================== Set Checked версии ====================
 
 func SetUIntFieldAs[T UnsignedInteger](packed []byte, field UIntBitField, value T) error {
-	if len(packed) == 0 {
-		return newSliceEmptyError()
+	if err := field.checkSet(packed, uint64(value)); err != nil {
+		return err
 	}
-	if len(packed) > 8 {
-		return newSliceTooLargeError(len(packed))
+	SetUIntFieldUncheckedAs(packed, field, value)
+	return nil
+}
+
+func SetIntFieldAs[T SignedInteger](packed []byte, field IntBitField, value T) error {
+	if err := field.checkSet(packed, int64(value)); err != nil {
+		return err
 	}
+	SetIntFieldUncheckedAs(packed, field, value)
+	return nil
+}
 
-	bits, err := field.Update(UnpackBytes(packed), uint64(value))
-	if err != nil {
+func SetBoolField(packed []byte, field BoolBitField, value bool) error {
+	if err := field.checkSet(packed); err != nil {
 		return err
 	}
-	PackBytes(packed, bits)
+
+	SetBoolFieldUnchecked(packed, field, value)
 	return nil
 }
 
-func SetIntFieldAs[T SignedInteger](packed []byte, field IntBitField, value T) error {
-	if len(packed) == 0 {
-		return newSliceEmptyError()
+// checkSet — проверки checked записи в срез: размер буфера и диапазон значения
+func (bf UIntBitField) checkSet(packed []byte, value uint64) error {
+	if err := validatePacked(packed, bf.End); err != nil {
+		return bf.label.annotate(err)
 	}
-	if len(packed) > 8 {
-		return newSliceTooLargeError(len(packed))
+	return bf.checkValue(value)
+}
+
+// checkValue — проверка диапазона значения без буфера (для Writer)
+func (bf UIntBitField) checkValue(value uint64) error {
+	if value < bf.Min {
+		return bf.label.annotate(newValueUnderflowError(value, bf.Min, bf.Width()))
+	}
+	if value > bf.Max {
+		return bf.label.annotate(newValueOverflowError(value, bf.Max, bf.Width()))
+	}
+	return nil
+}
+
+func (bf IntBitField) checkSet(packed []byte, value int64) error {
+	if err := validatePacked(packed, bf.End); err != nil {
+		return bf.label.annotate(err)
 	}
+	return bf.checkValue(value)
+}
 
-	bits, err := field.Update(UnpackBytes(packed), int64(value))
-	if err != nil {
-		return err
+func (bf IntBitField) checkValue(value int64) error {
+	if value < bf.Min || value > bf.Max {
+		return bf.label.annotate(newValueOutOfRangeError(value, value, bf.Min, bf.Max, bf.Width()))
 	}
-	PackBytes(packed, bits)
 	return nil
 }
 
-func SetBoolField(packed []byte, field BoolBitField, value bool) error {
+func (bf BoolBitField) checkSet(packed []byte) error {
+	return bf.label.annotate(validatePacked(packed, bf.Position))
+}
+
+// validatePacked проверяет размер среза и то, что последний бит поля в нём помещается
+func validatePacked(packed []byte, end BitPosition) error {
 	if len(packed) == 0 {
 		return newSliceEmptyError()
 	}
-	if len(packed) > 8 {
+	if len(packed) > MaxPackedBytes {
 		return newSliceTooLargeError(len(packed))
 	}
-
-	SetBoolFieldUnchecked(packed, field, value)
+	if int(end) >= 8*len(packed) {
+		return newFieldOutOfSliceError(end, len(packed))
+	}
 	return nil
 }
 
+// ==================== Многословные буферы ====================
+
+// readBits читает width (1..64) битов начиная с позиции start из буфера любой длины.
+// Затрагиваются только байты, в которых лежит поле (не более 9).
+func readBits(packed []byte, start BitPosition, width uint8) uint64 {
+	first := int(start) / 8
+	shift := int(start) % 8
+	count := (shift + int(width) + 7) / 8
+
+	var result uint64
+	for i := 0; i < count && first+i < len(packed); i++ {
+		b := uint64(packed[first+i])
+		if pos := i*8 - shift; pos < 0 {
+			result |= b >> -pos
+		} else {
+			result |= b << pos
+		}
+	}
+	return result & computeMask(width)
+}
+
+// writeBits записывает width (1..64) младших битов value начиная с позиции start,
+// сохраняя соседние биты в крайних байтах
+func writeBits(packed []byte, start BitPosition, width uint8, value uint64) {
+	mask := computeMask(width)
+	value &= mask
+
+	first := int(start) / 8
+	shift := int(start) % 8
+	count := (shift + int(width) + 7) / 8
+
+	for i := 0; i < count && first+i < len(packed); i++ {
+		var byteMask, byteValue byte
+		if pos := i*8 - shift; pos < 0 {
+			byteMask, byteValue = byte(mask<<-pos), byte(value<<-pos)
+		} else {
+			byteMask, byteValue = byte(mask>>pos), byte(value>>pos)
+		}
+		packed[first+i] = packed[first+i]&^byteMask | byteValue
+	}
+}
+
 // ==================== Обратная совместимость (deprecated) ====================
 // Оставляем старые функции для плавной миграции, но помечаем как deprecated
 
@@ -1,7 +1,9 @@
 package bitpack
 
 import (
+	"errors"
 	"math"
+	"math/rand"
 	"testing"
 )
 
@@ -779,3 +781,218 @@ func TestPackedTypesUsage(t *testing.T) {
 		t.Errorf("Packed64 usage: got 0x%X, want 0x12345678", got)
 	}
 }
+
+// ============ Многословные буферы (> 64 бит) ============
+
+// refGetBit / refSetBit — эталонный побитовый доступ для сравнения с readBits/writeBits
+func refGetBit(packed []byte, pos int) uint64 {
+	return uint64(packed[pos/8]>>(pos%8)) & 1
+}
+
+func refSetBit(packed []byte, pos int, bit uint64) {
+	if bit != 0 {
+		packed[pos/8] |= 1 << (pos % 8)
+	} else {
+		packed[pos/8] &^= 1 << (pos % 8)
+	}
+}
+
+func TestReadWriteBitsAgainstReference(t *testing.T) {
+	rng := rand.New(rand.NewSource(42))
+
+	for i := 0; i < 5000; i++ {
+		size := 1 + rng.Intn(MaxPackedBytes)
+		width := uint8(1 + rng.Intn(64))
+		if int(width) > 8*size {
+			width = uint8(8 * size)
+		}
+		start := BitPosition(rng.Intn(8*size - int(width) + 1))
+		value := rng.Uint64() & computeMask(width)
+
+		got := make([]byte, size)
+		rng.Read(got)
+		want := append([]byte(nil), got...)
+
+		writeBits(got, start, width, value)
+		for b := 0; b < int(width); b++ {
+			refSetBit(want, int(start)+b, (value>>b)&1)
+		}
+
+		if string(got) != string(want) {
+			t.Fatalf("writeBits(size=%d, start=%d, width=%d) = %x, want %x", size, start, width, got, want)
+		}
+		if read := readBits(got, start, width); read != value {
+			t.Fatalf("readBits(size=%d, start=%d, width=%d) = 0x%X, want 0x%X", size, start, width, read, value)
+		}
+	}
+}
+
+func TestSlicePathMatchesBitSet64Path(t *testing.T) {
+	rng := rand.New(rand.NewSource(7))
+
+	for i := 0; i < 1000; i++ {
+		width := uint8(1 + rng.Intn(64))
+		start := BitPosition(rng.Intn(64 - int(width) + 1))
+		field := MustNewUIntBitField(start, start+width-1, computeMask(width))
+
+		var packed Packed64
+		rng.Read(packed[:])
+		if got, want := readBits(packed[:], start, width), field.Get(UnpackBytes(packed[:])); got != want {
+			t.Fatalf("field %v: readBits = 0x%X, Get = 0x%X", field, got, want)
+		}
+	}
+}
+
+func TestFullWidthFieldAtUnalignedOffset(t *testing.T) {
+	// 64-битное поле со сдвигом 3 занимает 9 байт
+	l := NewLayout("wide", 96)
+	l.Reserve("pad", 3)
+	field := l.AddUInt("big", math.MaxUint64)
+	signed := l.AddInt("signed", math.MinInt16, math.MaxInt16)
+	l.MustBuild()
+
+	var packed Packed96
+	for i := range packed {
+		packed[i] = 0xFF
+	}
+
+	SetUIntFieldUncheckedAs[uint64](packed[:], field, 0x0123456789ABCDEF)
+	SetIntFieldUncheckedAs[int16](packed[:], signed, -2)
+
+	if got := GetUIntFieldAs[uint64](packed[:], field); got != 0x0123456789ABCDEF {
+		t.Errorf("big = 0x%X, want 0x0123456789ABCDEF", got)
+	}
+	if got := GetIntFieldAs[int16](packed[:], signed); got != -2 {
+		t.Errorf("signed = %d, want -2", got)
+	}
+	if packed[0]&0x07 != 0x07 {
+		t.Errorf("padding bits 0-2 were modified: 0x%02X", packed[0])
+	}
+	if packed[11] != 0xFF {
+		t.Errorf("trailing bits were modified: 0x%02X", packed[11])
+	}
+}
+
+func TestCheckedSetMultiWordErrors(t *testing.T) {
+	l := NewLayout("wide", 128)
+	l.Seek(100)
+	field := l.AddUInt("far", 1000) // 100-109
+	flag := l.AddBool("flag")       // 110
+	l.MustBuild()
+
+	var packed Packed128
+	if err := SetUIntFieldAs[uint32](packed[:], field, 1001); !errors.Is(err, ErrValueOverflow) {
+		t.Errorf("overflow: err = %v", err)
+	}
+	if err := SetUIntFieldAs[uint32](packed[:8], field, 1); !errors.Is(err, ErrFieldOutOfSlice) {
+		t.Errorf("short slice: err = %v", err)
+	}
+	if err := SetBoolField(packed[:12], flag, true); !errors.Is(err, ErrFieldOutOfSlice) {
+		t.Errorf("short slice for bool: err = %v", err)
+	}
+	if err := SetUIntFieldAs[uint32](make([]byte, MaxPackedBytes+1), field, 1); !errors.Is(err, ErrSliceTooLarge) {
+		t.Errorf("too large: err = %v", err)
+	}
+	if packed != (Packed128{}) {
+		t.Errorf("failed sets must not modify buffer: %x", packed)
+	}
+}
+
+// ============ Бенчмарки: поштучный доступ против View ============
+
+// Раскладка, повторяющая схему персонажа: 11 полей в 48 битах
+var (
+	benchLayout     = NewLayout("bench", 48)
+	benchNameSize   = benchLayout.AddUInt("nameSize", 42)
+	benchRespect    = benchLayout.AddUInt("respect", 10)
+	benchStrength   = benchLayout.AddUInt("strength", 10)
+	benchExperience = benchLayout.AddUInt("experience", 10)
+	benchLevel      = benchLayout.AddBiasedUInt("level", 1, 10)
+	benchType       = benchLayout.AddUInt("type", 3)
+	benchHouse      = benchLayout.AddBool("house")
+	benchWeapon     = benchLayout.AddBool("weapon")
+	benchFamily     = benchLayout.AddBool("family")
+	benchMana       = benchLayout.AddUInt("mana", 1000)
+	benchHealth     = benchLayout.AddUInt("health", 1000)
+)
+
+func init() {
+	benchLayout.MustBuild()
+}
+
+// benchSink не даёт компилятору выбросить результат чтения
+var benchSink uint64
+
+// BenchmarkCreatureBuild — заполнение всех полей раскладки (FromDTO целиком — в пакете person)
+func BenchmarkCreatureBuild(b *testing.B) {
+	b.Run("Slice", func(b *testing.B) {
+		var packed Packed48
+		for b.Loop() {
+			_ = SetUIntFieldAs(packed[:], benchNameSize, uint32(12))
+			_ = SetUIntFieldAs(packed[:], benchRespect, uint32(3))
+			_ = SetUIntFieldAs(packed[:], benchStrength, uint32(7))
+			_ = SetUIntFieldAs(packed[:], benchExperience, uint32(5))
+			_ = SetUIntFieldAs(packed[:], benchLevel, uint32(4))
+			_ = SetUIntFieldAs(packed[:], benchType, uint32(2))
+			_ = SetBoolField(packed[:], benchHouse, true)
+			_ = SetBoolField(packed[:], benchWeapon, false)
+			_ = SetBoolField(packed[:], benchFamily, true)
+			_ = SetUIntFieldAs(packed[:], benchMana, uint32(250))
+			_ = SetUIntFieldAs(packed[:], benchHealth, uint32(900))
+		}
+	})
+
+	b.Run("View", func(b *testing.B) {
+		var packed Packed48
+		for b.Loop() {
+			view := MustNewView(packed[:])
+			_ = view.SetUInt(benchNameSize, 12)
+			_ = view.SetUInt(benchRespect, 3)
+			_ = view.SetUInt(benchStrength, 7)
+			_ = view.SetUInt(benchExperience, 5)
+			_ = view.SetUInt(benchLevel, 4)
+			_ = view.SetUInt(benchType, 2)
+			_ = view.SetBool(benchHouse, true)
+			_ = view.SetBool(benchWeapon, false)
+			_ = view.SetBool(benchFamily, true)
+			_ = view.SetUInt(benchMana, 250)
+			_ = view.SetUInt(benchHealth, 900)
+			_ = view.Commit()
+		}
+	})
+}
+
+// BenchmarkCreatureRead — чтение всех полей раскладки (ToDTO целиком — в пакете person)
+func BenchmarkCreatureRead(b *testing.B) {
+	packed := Packed48{0x8C, 0x3D, 0x5A, 0xA1, 0x7F, 0x03}
+
+	b.Run("Slice", func(b *testing.B) {
+		for b.Loop() {
+			sum := GetUIntFieldAs[uint64](packed[:], benchNameSize) +
+				GetUIntFieldAs[uint64](packed[:], benchRespect) +
+				GetUIntFieldAs[uint64](packed[:], benchStrength) +
+				GetUIntFieldAs[uint64](packed[:], benchExperience) +
+				GetUIntFieldAs[uint64](packed[:], benchLevel) +
+				GetUIntFieldAs[uint64](packed[:], benchType) +
+				GetUIntFieldAs[uint64](packed[:], benchMana) +
+				GetUIntFieldAs[uint64](packed[:], benchHealth)
+			if GetBoolField(packed[:], benchHouse) && GetBoolField(packed[:], benchWeapon) && GetBoolField(packed[:], benchFamily) {
+				sum++
+			}
+			benchSink = sum
+		}
+	})
+
+	b.Run("View", func(b *testing.B) {
+		for b.Loop() {
+			view := MustNewView(packed[:])
+			sum := view.UInt(benchNameSize) + view.UInt(benchRespect) + view.UInt(benchStrength) +
+				view.UInt(benchExperience) + view.UInt(benchLevel) + view.UInt(benchType) +
+				view.UInt(benchMana) + view.UInt(benchHealth)
+			if view.Bool(benchHouse) && view.Bool(benchWeapon) && view.Bool(benchFamily) {
+				sum++
+			}
+			benchSink = sum
+		}
+	})
+}
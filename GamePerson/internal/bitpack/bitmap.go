@@ -0,0 +1,304 @@
+package bitpack
+
+import (
+	"fmt"
+	"strings"
+)
+
+// =================  Карта битов и покрытие ================================
+// ======== Отчёт о занятых, свободных и пересекающихся битах ===============
+//
+// Coverage строится по Schema или по списку диапазонов (для схем с ручными
+// позициями) и перечисляет пропуски и пересечения:
+//
+//	cov := personbitpack.Schema().Coverage()
+//	if !cov.OK() { ... }               // пересечения или выход за размер
+//	fmt.Println(cov.Utilization())     // доля битов, занятых полями
+//
+// BitMap и Markdown печатают карту битов схемы, чтобы документация
+// строилась из кода (см. cmd/bitmap), а не рисовалась вручную.
+
+// BitRange — именованный диапазон битов [Start, End]
+type BitRange struct {
+	Name     string
+	Start    BitPosition
+	End      BitPosition
+	Reserved bool // Биты зарезервированы (Layout.Reserve)
+}
+
+// Width — количество битов диапазона
+func (r BitRange) Width() int {
+	return int(r.End) - int(r.Start) + 1
+}
+
+// Overlap — общие биты двух пересекающихся диапазонов
+type Overlap struct {
+	First  string
+	Second string
+	Start  BitPosition
+	End    BitPosition
+}
+
+type Coverage struct {
+	Bits        int        // Размер схемы в битах
+	Used        int        // Биты, занятые полями (пересечения считаются один раз)
+	Reserved    int        // Зарезервированные биты
+	Gaps        []BitRange // Свободные диапазоны (без имени)
+	Overlaps    []Overlap  // Пересечения диапазонов
+	OutOfBounds []BitRange // Диапазоны, выходящие за размер схемы
+}
+
+// CheckCoverage строит отчёт о покрытии bits битов диапазонами ranges.
+// Для схем с ручными позициями заменяет самописный тест на пересечения.
+func CheckCoverage(bits int, ranges []BitRange) Coverage {
+	c := Coverage{Bits: bits}
+	owners := make([]int, bits) // 0 — свободен, 1 — поле, 2 — резерв
+	for i, r := range ranges {
+		if int(r.End) >= bits || r.Start > r.End {
+			c.OutOfBounds = append(c.OutOfBounds, r)
+		}
+		for bit := int(r.Start); bit <= min(int(r.End), bits-1); bit++ {
+			switch {
+			case owners[bit] == 0 && r.Reserved:
+				owners[bit] = 2
+			case owners[bit] != 1 && !r.Reserved:
+				owners[bit] = 1
+			}
+		}
+		for _, other := range ranges[i+1:] {
+			if r.Start <= other.End && other.Start <= r.End {
+				c.Overlaps = append(c.Overlaps, Overlap{
+					First: r.Name, Second: other.Name,
+					Start: max(r.Start, other.Start), End: min(r.End, other.End),
+				})
+			}
+		}
+	}
+
+	for bit := 0; bit < bits; bit++ {
+		switch owners[bit] {
+		case 1:
+			c.Used++
+		case 2:
+			c.Reserved++
+		default:
+			if n := len(c.Gaps); n > 0 && int(c.Gaps[n-1].End) == bit-1 {
+				c.Gaps[n-1].End = BitPosition(bit)
+			} else {
+				c.Gaps = append(c.Gaps, BitRange{Start: BitPosition(bit), End: BitPosition(bit)})
+			}
+		}
+	}
+	return c
+}
+
+// Coverage строит отчёт о покрытии битов полями схемы
+func (s *Schema) Coverage() Coverage {
+	ranges := make([]BitRange, len(s.fields))
+	for i, f := range s.fields {
+		ranges[i] = f.Range()
+	}
+	return CheckCoverage(s.bits, ranges)
+}
+
+// Range — диапазон битов поля
+func (d FieldDescriptor) Range() BitRange {
+	return BitRange{Name: d.Name, Start: d.Start, End: d.End, Reserved: d.Reserved()}
+}
+
+// OK сообщает, что диапазоны не пересекаются и помещаются в схему
+func (c Coverage) OK() bool {
+	return len(c.Overlaps) == 0 && len(c.OutOfBounds) == 0
+}
+
+// Free — количество свободных битов
+func (c Coverage) Free() int {
+	return c.Bits - c.Used - c.Reserved
+}
+
+// Utilization — доля битов, занятых полями, в процентах
+func (c Coverage) Utilization() float64 {
+	if c.Bits == 0 {
+		return 0
+	}
+	return 100 * float64(c.Used) / float64(c.Bits)
+}
+
+// Summary — итог покрытия в одну строку
+func (c Coverage) Summary() string {
+	return fmt.Sprintf("занято %d из %d бит (%.1f%%), резерв %d, свободно %d",
+		c.Used, c.Bits, c.Utilization(), c.Reserved, c.Free())
+}
+
+// Строковое представление: итог и перечень пропусков и проблем
+func (c Coverage) String() string {
+	var b strings.Builder
+	b.WriteString(c.Summary())
+	for _, g := range c.Gaps {
+		fmt.Fprintf(&b, "\nсвободно: %s", bitsLabel(g.Start, g.End))
+	}
+	for _, o := range c.Overlaps {
+		fmt.Fprintf(&b, "\nпересечение: %s и %s, %s", o.First, o.Second, bitsLabel(o.Start, o.End))
+	}
+	for _, r := range c.OutOfBounds {
+		fmt.Fprintf(&b, "\nвне схемы: %s [%d:%d]", r.Name, r.Start, r.End)
+	}
+	return b.String()
+}
+
+// ==================== Карта битов ====================
+
+const (
+	bitMapRowBits  = 32  // битов в строке полосы
+	bitMapFree     = '.' // свободный бит
+	bitMapReserved = '#' // зарезервированный бит
+)
+
+// bitMapSymbols — метки полей на полосе битов в порядке позиций
+const bitMapSymbols = "abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ0123456789"
+
+// BitMap печатает карту битов: полосу по 32 бита с метками полей
+// и таблицу полей со свободными и зарезервированными диапазонами
+func (s *Schema) BitMap() string {
+	cov := s.Coverage()
+	strip := make([]byte, s.bits)
+	for i := range strip {
+		strip[i] = bitMapFree
+	}
+	symbols := make([]byte, len(s.fields))
+	next := 0
+	for i, f := range s.fields {
+		switch {
+		case f.Reserved():
+			symbols[i] = bitMapReserved
+		case next < len(bitMapSymbols):
+			symbols[i] = bitMapSymbols[next]
+			next++
+		default:
+			symbols[i] = '*'
+		}
+		for bit := int(f.Start); bit <= int(f.End); bit++ {
+			strip[bit] = symbols[i]
+		}
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "Схема %s: %s\n\n", s.name, cov.Summary())
+	digits := len(fmt.Sprint(s.bits - 1))
+	for row := 0; row < s.bits; row += bitMapRowBits {
+		last := min(row+bitMapRowBits, s.bits) - 1
+		fmt.Fprintf(&b, "%*d-%-*d ", digits, row, digits, last)
+		for bit := row; bit <= last; bit++ {
+			if bit > row && bit%8 == 0 {
+				b.WriteByte(' ')
+			}
+			b.WriteByte(strip[bit])
+		}
+		b.WriteByte('\n')
+	}
+	b.WriteByte('\n')
+
+	for _, row := range s.bitMapRows(cov) {
+		symbol := byte(bitMapFree)
+		if row.field >= 0 {
+			symbol = symbols[row.field]
+		}
+		line := fmt.Sprintf("%c  %-11s %-14s %-8s %-18s %s",
+			symbol, bitsLabel(row.start, row.end), row.name, row.kind, row.values, pluralBits(int(row.end-row.start)+1))
+		b.WriteString(strings.TrimRight(line, " "))
+		b.WriteByte('\n')
+	}
+	return b.String()
+}
+
+// Markdown печатает карту битов таблицей Markdown
+func (s *Schema) Markdown() string {
+	cov := s.Coverage()
+	var b strings.Builder
+	fmt.Fprintf(&b, "**%s**: %s\n\n", s.name, cov.Summary())
+	b.WriteString("| Биты | Поле | Вид | Значения | Ширина |\n")
+	b.WriteString("|------|------|-----|----------|--------|\n")
+	for _, row := range s.bitMapRows(cov) {
+		name := "`" + row.name + "`"
+		switch {
+		case row.field < 0:
+			name = "**свободно**"
+		case s.fields[row.field].Reserved():
+			name = "*резерв* `" + row.name + "`"
+		}
+		span := fmt.Sprintf("%d-%d", row.start, row.end)
+		if row.start == row.end {
+			span = fmt.Sprint(row.start)
+		}
+		fmt.Fprintf(&b, "| %s | %s | %s | %s | %s |\n",
+			span, name, orDash(row.kind), orDash(row.values), pluralBits(int(row.end-row.start)+1))
+	}
+	return b.String()
+}
+
+// bitMapRow — строка таблицы карты: поле схемы (field >= 0) или свободный диапазон
+type bitMapRow struct {
+	field      int
+	start, end BitPosition
+	name       string
+	kind       string
+	values     string
+}
+
+// bitMapRows объединяет поля и свободные диапазоны в порядке позиций
+func (s *Schema) bitMapRows(cov Coverage) []bitMapRow {
+	rows := make([]bitMapRow, 0, len(s.fields)+len(cov.Gaps))
+	gaps := cov.Gaps
+	for i, f := range s.fields {
+		for len(gaps) > 0 && gaps[0].Start < f.Start {
+			rows = append(rows, bitMapRow{field: -1, start: gaps[0].Start, end: gaps[0].End, name: "свободно"})
+			gaps = gaps[1:]
+		}
+		rows = append(rows, bitMapRow{field: i, start: f.Start, end: f.End, name: f.Name, kind: f.Kind.String(), values: f.values()})
+	}
+	for _, g := range gaps {
+		rows = append(rows, bitMapRow{field: -1, start: g.Start, end: g.End, name: "свободно"})
+	}
+	return rows
+}
+
+// values — диапазон значений поля для карты битов
+func (d FieldDescriptor) values() string {
+	switch d.Kind {
+	case FieldKindUInt, FieldKindInt:
+		if d.Optional {
+			return fmt.Sprintf("[%d, %d] или нет", d.Min, d.Max)
+		}
+		return fmt.Sprintf("[%d, %d]", d.Min, d.Max)
+	case FieldKindFixed:
+		return fmt.Sprintf("[%g, %g] шаг %g", d.FixedMin, d.FixedMax, d.Step)
+	case FieldKindArray:
+		return fmt.Sprintf("%d × [%d, %d]", d.Len, d.Min, d.Max)
+	default:
+		return ""
+	}
+}
+
+// bitsLabel — "бит 7" или "биты 0-5"
+func bitsLabel(start, end BitPosition) string {
+	if start == end {
+		return fmt.Sprintf("бит %d", start)
+	}
+	return fmt.Sprintf("биты %d-%d", start, end)
+}
+
+func orDash(s string) string {
+	if s == "" {
+		return "—"
+	}
+	return s
+}
+
+// pluralBits — "1 бит", "2 бита", "5 бит", "22 бита"
+func pluralBits(n int) string {
+	word := "бит"
+	if n%10 >= 2 && n%10 <= 4 && (n%100 < 12 || n%100 > 14) {
+		word = "бита"
+	}
+	return fmt.Sprintf("%d %s", n, word)
+}
@@ -0,0 +1,99 @@
+package bitpack
+
+import (
+	"strings"
+	"testing"
+)
+
+// ============ Тесты для карты битов и покрытия ============
+
+func TestCheckCoverage(t *testing.T) {
+	cov := CheckCoverage(16, []BitRange{
+		{Name: "a", Start: 0, End: 3},
+		{Name: "b", Start: 3, End: 5},
+		{Name: "r", Start: 8, End: 9, Reserved: true},
+		{Name: "c", Start: 14, End: 17},
+	})
+
+	if cov.OK() {
+		t.Error("OK() = true, want false (overlap and out of bounds)")
+	}
+	if cov.Used != 8 || cov.Reserved != 2 || cov.Free() != 6 {
+		t.Errorf("Used/Reserved/Free = %d/%d/%d, want 8/2/6", cov.Used, cov.Reserved, cov.Free())
+	}
+	if want := []BitRange{{Start: 6, End: 7}, {Start: 10, End: 13}}; len(cov.Gaps) != 2 ||
+		cov.Gaps[0] != want[0] || cov.Gaps[1] != want[1] {
+		t.Errorf("Gaps = %v, want %v", cov.Gaps, want)
+	}
+	if want := (Overlap{First: "a", Second: "b", Start: 3, End: 3}); len(cov.Overlaps) != 1 || cov.Overlaps[0] != want {
+		t.Errorf("Overlaps = %v, want [%v]", cov.Overlaps, want)
+	}
+	if len(cov.OutOfBounds) != 1 || cov.OutOfBounds[0].Name != "c" {
+		t.Errorf("OutOfBounds = %v, want [c]", cov.OutOfBounds)
+	}
+	if got := cov.Utilization(); got != 50 {
+		t.Errorf("Utilization() = %g, want 50", got)
+	}
+	for _, want := range []string{"свободно: биты 6-7", "пересечение: a и b, бит 3", "вне схемы: c [14:17]"} {
+		if !strings.Contains(cov.String(), want) {
+			t.Errorf("String() = %q, want %q", cov.String(), want)
+		}
+	}
+}
+
+func newBitMapSchema(t *testing.T) *Schema {
+	t.Helper()
+	layout := NewLayout("probe", 40)
+	layout.AddUInt("count", 42)
+	layout.AddBool("on")
+	layout.Reserve("future", 3)
+	layout.Seek(16)
+	layout.AddInt("delta", -100, 100)
+	schema, err := layout.Schema()
+	if err != nil {
+		t.Fatalf("Schema(): %v", err)
+	}
+	return schema
+}
+
+func TestSchemaBitMap(t *testing.T) {
+	want := `Схема probe: занято 15 из 40 бит (37.5%), резерв 3, свободно 22
+
+ 0-31 aaaaaab# ##...... cccccccc ........
+32-39 ........
+
+a  биты 0-5    count          uint     [0, 42]            6 бит
+b  бит 6       on             bool                        1 бит
+#  биты 7-9    future         reserved                    3 бита
+.  биты 10-15  свободно                                   6 бит
+c  биты 16-23  delta          int      [-100, 100]        8 бит
+.  биты 24-39  свободно                                   16 бит
+`
+	if got := newBitMapSchema(t).BitMap(); got != want {
+		t.Errorf("BitMap() =\n%s\nwant\n%s", got, want)
+	}
+}
+
+func TestSchemaMarkdown(t *testing.T) {
+	want := "**probe**: занято 15 из 40 бит (37.5%), резерв 3, свободно 22\n\n" +
+		"| Биты | Поле | Вид | Значения | Ширина |\n" +
+		"|------|------|-----|----------|--------|\n" +
+		"| 0-5 | `count` | uint | [0, 42] | 6 бит |\n" +
+		"| 6 | `on` | bool | — | 1 бит |\n" +
+		"| 7-9 | *резерв* `future` | reserved | — | 3 бита |\n" +
+		"| 10-15 | **свободно** | — | — | 6 бит |\n" +
+		"| 16-23 | `delta` | int | [-100, 100] | 8 бит |\n" +
+		"| 24-39 | **свободно** | — | — | 16 бит |\n"
+	if got := newBitMapSchema(t).Markdown(); got != want {
+		t.Errorf("Markdown() =\n%s\nwant\n%s", got, want)
+	}
+}
+
+func TestPluralBits(t *testing.T) {
+	tests := map[int]string{1: "1 бит", 2: "2 бита", 4: "4 бита", 5: "5 бит", 11: "11 бит", 12: "12 бит", 21: "21 бит", 22: "22 бита"}
+	for n, want := range tests {
+		if got := pluralBits(n); got != want {
+			t.Errorf("pluralBits(%d) = %q, want %q", n, got, want)
+		}
+	}
+}
@@ -0,0 +1,260 @@
+package bitpack
+
+import "encoding/binary"
+
+// =================  Порядок байтов ========================================
+// ============ Little-endian и big-endian раскладка буфера =================
+//
+// Номер бита поля не зависит от порядка байтов: бит n — это n-й младший
+// бит числа, записанного в буфер. Порядок определяет только, в каком байте
+// буфера лежит этот бит:
+//
+//	LittleEndian: бит n — в байте n/8 (младший байт первым)
+//	BigEndian:    бит n — в байте len-1-n/8 (старший байт первым)
+//
+// Буфер BigEndian — это буфер LittleEndian с обратным порядком байтов,
+// поэтому одни и те же значения полей в двух порядках дают зеркальные байты.
+// Функции пакета без явного порядка работают с LittleEndian. Для другого
+// порядка у каждого вида полей есть checked и unchecked варианты:
+//
+//   - UInt, Int, Bool, Fixed, optional и nullable поля, арифметика
+//     и Marshal/Unmarshal — методы ByteOrder (BigEndian.GetFixed ...)
+//   - EnumBitField — функции GetEnumFieldOrder, SetEnumFieldOrder ...
+//     с порядком первым аргументом (у методов нет параметров типа)
+//   - View, PackedArray и Schema — копия с порядком: o.NewView,
+//     PackedArray.WithOrder, Schema.WithOrder
+//
+// Writer.WriteSchema и Reader.ReadSchema используют порядок схемы, а в
+// поток пишут значения полей, поэтому поток от порядка не зависит.
+//
+//	bitpack.BigEndian.SetUInt(packed[:], healthField, 500)
+//	hp, _ := bitpack.BigEndian.GetUInt(packed[:], healthField)
+//	err := bitpack.BigEndian.SetFixed(packed[:], speedField, 1.5)
+//	err = schema.WithOrder(bitpack.BigEndian).Validate(packed[:])
+//
+// Для слов 2, 4 и 8 байт Unpack/Pack используют encoding/binary.
+
+// ByteOrder — порядок байтов буфера
+type ByteOrder struct {
+	big bool
+}
+
+var (
+	LittleEndian = ByteOrder{}          // младший байт первым (по умолчанию)
+	BigEndian    = ByteOrder{big: true} // старший байт первым (сетевой порядок)
+)
+
+// Строковое представление для отладки
+func (o ByteOrder) String() string {
+	if o.big {
+		return "BigEndian"
+	}
+	return "LittleEndian"
+}
+
+// Unpack читает буфер до 8 байт как одно слово
+func (o ByteOrder) Unpack(data []byte) BitSet64 {
+	switch {
+	case len(data) == 8 && o.big:
+		return BitSet64(binary.BigEndian.Uint64(data))
+	case len(data) == 8:
+		return BitSet64(binary.LittleEndian.Uint64(data))
+	case len(data) == 4 && o.big:
+		return BitSet64(binary.BigEndian.Uint32(data))
+	case len(data) == 4:
+		return BitSet64(binary.LittleEndian.Uint32(data))
+	case len(data) == 2 && o.big:
+		return BitSet64(binary.BigEndian.Uint16(data))
+	case len(data) == 2:
+		return BitSet64(binary.LittleEndian.Uint16(data))
+	}
+
+	var result BitSet64
+	for i, b := range data {
+		result |= BitSet64(b) << (8 * o.shift(len(data), i))
+	}
+	return result
+}
+
+// Pack записывает слово в буфер до 8 байт
+func (o ByteOrder) Pack(data []byte, value BitSet64) {
+	switch {
+	case len(data) == 8 && o.big:
+		binary.BigEndian.PutUint64(data, uint64(value))
+	case len(data) == 8:
+		binary.LittleEndian.PutUint64(data, uint64(value))
+	case len(data) == 4 && o.big:
+		binary.BigEndian.PutUint32(data, uint32(value))
+	case len(data) == 4:
+		binary.LittleEndian.PutUint32(data, uint32(value))
+	case len(data) == 2 && o.big:
+		binary.BigEndian.PutUint16(data, uint16(value))
+	case len(data) == 2:
+		binary.LittleEndian.PutUint16(data, uint16(value))
+	default:
+		for i := range data {
+			data[i] = byte(value >> (8 * o.shift(len(data), i)))
+		}
+	}
+}
+
+// ==================== Get ====================
+
+func (o ByteOrder) GetUInt(packed []byte, field UIntBitField) (uint64, error) {
+	if err := validatePacked(packed, field.End); err != nil {
+		return 0, field.label.annotate(err)
+	}
+	return o.GetUIntUnchecked(packed, field), nil
+}
+
+func (o ByteOrder) GetInt(packed []byte, field IntBitField) (int64, error) {
+	if err := validatePacked(packed, field.End); err != nil {
+		return 0, field.label.annotate(err)
+	}
+	return o.GetIntUnchecked(packed, field), nil
+}
+
+func (o ByteOrder) GetBool(packed []byte, field BoolBitField) (bool, error) {
+	if err := validatePacked(packed, field.Position); err != nil {
+		return false, field.label.annotate(err)
+	}
+	return o.GetBoolUnchecked(packed, field), nil
+}
+
+func (o ByteOrder) GetUIntUnchecked(packed []byte, field UIntBitField) uint64 {
+	if len(packed) <= 8 {
+		return field.Get(o.Unpack(packed))
+	}
+	return o.readBits(packed, field.Start, field.Width()) + field.Min
+}
+
+func (o ByteOrder) GetIntUnchecked(packed []byte, field IntBitField) int64 {
+	if len(packed) <= 8 {
+		return field.Get(o.Unpack(packed))
+	}
+	return field.signExtend(o.readBits(packed, field.Start, field.Width()))
+}
+
+func (o ByteOrder) GetBoolUnchecked(packed []byte, field BoolBitField) bool {
+	if len(packed) <= 8 {
+		return field.Get(o.Unpack(packed))
+	}
+	return o.readBits(packed, field.Position, 1) != 0
+}
+
+// ==================== Set ====================
+
+func (o ByteOrder) SetUInt(packed []byte, field UIntBitField, value uint64) error {
+	if err := field.checkSet(packed, value); err != nil {
+		return err
+	}
+	o.SetUIntUnchecked(packed, field, value)
+	return nil
+}
+
+func (o ByteOrder) SetInt(packed []byte, field IntBitField, value int64) error {
+	if err := field.checkSet(packed, value); err != nil {
+		return err
+	}
+	o.SetIntUnchecked(packed, field, value)
+	return nil
+}
+
+func (o ByteOrder) SetBool(packed []byte, field BoolBitField, value bool) error {
+	if err := field.checkSet(packed); err != nil {
+		return err
+	}
+	o.SetBoolUnchecked(packed, field, value)
+	return nil
+}
+
+func (o ByteOrder) SetUIntUnchecked(packed []byte, field UIntBitField, value uint64) {
+	if len(packed) > 8 {
+		o.writeBits(packed, field.Start, field.Width(), value-field.Min)
+		return
+	}
+	o.Pack(packed, field.UpdateUnchecked(o.Unpack(packed), value))
+}
+
+func (o ByteOrder) SetIntUnchecked(packed []byte, field IntBitField, value int64) {
+	if len(packed) > 8 {
+		o.writeBits(packed, field.Start, field.Width(), uint64(value))
+		return
+	}
+	o.Pack(packed, field.UpdateUnchecked(o.Unpack(packed), value))
+}
+
+func (o ByteOrder) SetBoolUnchecked(packed []byte, field BoolBitField, value bool) {
+	if len(packed) > 8 {
+		var bit uint64
+		if value {
+			bit = 1
+		}
+		o.writeBits(packed, field.Position, 1, bit)
+		return
+	}
+	bits := o.Unpack(packed)
+	if value {
+		bits = field.Set(bits)
+	} else {
+		bits = field.Clear(bits)
+	}
+	o.Pack(packed, bits)
+}
+
+// ------------- Сервисные методы --------------------------------
+
+// shift — номер байта слова (0 — младший), хранящегося в data[i]
+func (o ByteOrder) shift(n, i int) int {
+	if o.big {
+		return n - 1 - i
+	}
+	return i
+}
+
+// readBits — readBits для буферов больше 8 байт с учётом порядка.
+// В BigEndian байты поля читаются с конца буфера.
+func (o ByteOrder) readBits(packed []byte, start BitPosition, width uint8) uint64 {
+	if !o.big {
+		return readBits(packed, start, width)
+	}
+	first := int(start) / 8
+	shift := int(start) % 8
+	count := (shift + int(width) + 7) / 8
+
+	var result uint64
+	for i := 0; i < count && first+i < len(packed); i++ {
+		b := uint64(packed[len(packed)-1-first-i])
+		if pos := i*8 - shift; pos < 0 {
+			result |= b >> -pos
+		} else {
+			result |= b << pos
+		}
+	}
+	return result & computeMask(width)
+}
+
+// writeBits — writeBits для буферов больше 8 байт с учётом порядка
+func (o ByteOrder) writeBits(packed []byte, start BitPosition, width uint8, value uint64) {
+	if !o.big {
+		writeBits(packed, start, width, value)
+		return
+	}
+	mask := computeMask(width)
+	value &= mask
+
+	first := int(start) / 8
+	shift := int(start) % 8
+	count := (shift + int(width) + 7) / 8
+
+	for i := 0; i < count && first+i < len(packed); i++ {
+		var byteMask, byteValue byte
+		if pos := i*8 - shift; pos < 0 {
+			byteMask, byteValue = byte(mask<<-pos), byte(value<<-pos)
+		} else {
+			byteMask, byteValue = byte(mask>>pos), byte(value>>pos)
+		}
+		idx := len(packed) - 1 - first - i
+		packed[idx] = packed[idx]&^byteMask | byteValue
+	}
+}
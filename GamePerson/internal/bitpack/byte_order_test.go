@@ -0,0 +1,454 @@
+package bitpack
+
+import (
+	"errors"
+	"math/rand"
+	"slices"
+	"testing"
+)
+
+// ============ Тесты порядка байтов ============
+
+func TestByteOrderPackUnpack(t *testing.T) {
+	rng := rand.New(rand.NewSource(16))
+	for size := 1; size <= 8; size++ {
+		for i := 0; i < 50; i++ {
+			value := BitSet64(rng.Uint64()) & BitSet64(computeMask(uint8(size*8)))
+
+			le := make([]byte, size)
+			be := make([]byte, size)
+			LittleEndian.Pack(le, value)
+			BigEndian.Pack(be, value)
+
+			for j := range le {
+				if le[j] != byte(value>>(8*j)) {
+					t.Fatalf("size %d: LittleEndian byte %d = %#x, want %#x", size, j, le[j], byte(value>>(8*j)))
+				}
+			}
+			if !slices.Equal(be, reversed(le)) {
+				t.Fatalf("size %d: BigEndian % x, want reversed % x", size, be, le)
+			}
+			if got := LittleEndian.Unpack(le); got != value {
+				t.Fatalf("size %d: LittleEndian.Unpack = %#x, want %#x", size, got, value)
+			}
+			if got := BigEndian.Unpack(be); got != value {
+				t.Fatalf("size %d: BigEndian.Unpack = %#x, want %#x", size, got, value)
+			}
+		}
+	}
+}
+
+func TestPackBytesIsLittleEndian(t *testing.T) {
+	data := make([]byte, 3)
+	PackBytes(data, 0x0A0B0C)
+	if want := []byte{0x0C, 0x0B, 0x0A}; !slices.Equal(data, want) {
+		t.Errorf("PackBytes = % x, want % x", data, want)
+	}
+	if got := UnpackBytes(data); got != 0x0A0B0C {
+		t.Errorf("UnpackBytes = %#x, want 0x0A0B0C", got)
+	}
+}
+
+// TestByteOrderFieldSemantics записывает одни и те же значения в обоих порядках
+// и проверяет, что поля читаются одинаково, а байты зеркальны
+func TestByteOrderFieldSemantics(t *testing.T) {
+	for size := 1; size <= MaxPackedBytes; size++ {
+		bits := size * 8
+		// Поля пересекают границы байтов и, для больших буферов, 64-битных слов
+		uintWidth := min(13, bits/2)
+		intEnd := BitPosition(min(uintWidth+59, bits-2))
+		lo, hi := intRangeForWidth(intEnd - BitPosition(uintWidth) + 1)
+		uintField, _ := newUIntBitField(0, BitPosition(uintWidth-1), 3, 3+computeMask(uint8(uintWidth)))
+		intField, _ := newIntBitField(BitPosition(uintWidth), intEnd, lo, hi)
+		boolField := newBoolBitField(BitPosition(bits - 1))
+
+		rng := rand.New(rand.NewSource(int64(size)))
+		for i := 0; i < 20; i++ {
+			u := uintField.Min + rng.Uint64()%(uintField.Max-uintField.Min+1)
+			n := intField.Min + rng.Int63n(intField.Max-intField.Min)
+			flag := rng.Intn(2) == 1
+
+			le := make([]byte, size)
+			be := make([]byte, size)
+			ref := make([]byte, size)
+			for _, buf := range []struct {
+				order ByteOrder
+				data  []byte
+			}{{LittleEndian, le}, {BigEndian, be}} {
+				if err := buf.order.SetInt(buf.data, intField, n); err != nil {
+					t.Fatalf("size %d %v: SetInt: %v", size, buf.order, err)
+				}
+				if err := buf.order.SetUInt(buf.data, uintField, u); err != nil {
+					t.Fatalf("size %d %v: SetUInt: %v", size, buf.order, err)
+				}
+				if err := buf.order.SetBool(buf.data, boolField, flag); err != nil {
+					t.Fatalf("size %d %v: SetBool: %v", size, buf.order, err)
+				}
+			}
+			SetIntFieldUncheckedAs(ref, intField, n)
+			SetUIntFieldUncheckedAs(ref, uintField, u)
+			SetBoolFieldUnchecked(ref, boolField, flag)
+
+			if !slices.Equal(le, ref) {
+				t.Fatalf("size %d: LittleEndian % x differs from SetXXXField % x", size, le, ref)
+			}
+			if !slices.Equal(be, reversed(le)) {
+				t.Fatalf("size %d: BigEndian % x, want reversed % x", size, be, le)
+			}
+
+			for _, buf := range []struct {
+				order ByteOrder
+				data  []byte
+			}{{LittleEndian, le}, {BigEndian, be}} {
+				gotU, err := buf.order.GetUInt(buf.data, uintField)
+				if err != nil || gotU != u {
+					t.Fatalf("size %d %v: GetUInt = %d, %v, want %d", size, buf.order, gotU, err, u)
+				}
+				if gotN := buf.order.GetIntUnchecked(buf.data, intField); gotN != n {
+					t.Fatalf("size %d %v: GetInt = %d, want %d", size, buf.order, gotN, n)
+				}
+				if gotB, _ := buf.order.GetBool(buf.data, boolField); gotB != flag {
+					t.Fatalf("size %d %v: GetBool = %v, want %v", size, buf.order, gotB, flag)
+				}
+			}
+		}
+	}
+}
+
+func TestByteOrderCheckedErrors(t *testing.T) {
+	tests := []struct {
+		name string
+		set  func(o ByteOrder, packed []byte) error
+		size int
+		want error
+	}{
+		{"overflow", func(o ByteOrder, p []byte) error { return o.SetUInt(p, SequenceField, 70000) }, 4, ErrValueOverflow},
+		{"int range", func(o ByteOrder, p []byte) error {
+			return o.SetInt(p, MustNewIntBitField(0, 7, -10, 10), 11)
+		}, 4, ErrValueOverflow},
+		{"field out of slice", func(o ByteOrder, p []byte) error { return o.SetBool(p, LastFlag, true) }, 2, ErrFieldOutOfSlice},
+		{"empty", func(o ByteOrder, p []byte) error { return o.SetUInt(p, VersionField, 1) }, 0, ErrSliceEmpty},
+	}
+
+	for _, tt := range tests {
+		for _, order := range []ByteOrder{LittleEndian, BigEndian} {
+			t.Run(tt.name+"/"+order.String(), func(t *testing.T) {
+				packed := make([]byte, tt.size)
+				for i := range packed {
+					packed[i] = 0xA5
+				}
+				before := slices.Clone(packed)
+
+				err := tt.set(order, packed)
+				if !errors.Is(err, tt.want) {
+					t.Errorf("err = %v, want %v", err, tt.want)
+				}
+				if !slices.Equal(packed, before) {
+					t.Errorf("packed changed on error: % x", packed)
+				}
+			})
+		}
+	}
+}
+
+func TestByteOrderView(t *testing.T) {
+	le := Packed32{}
+	be := Packed32{}
+	for _, buf := range []struct {
+		order ByteOrder
+		data  []byte
+	}{{LittleEndian, le[:]}, {BigEndian, be[:]}} {
+		view, err := buf.order.NewView(buf.data)
+		if err != nil {
+			t.Fatalf("%v: NewView: %v", buf.order, err)
+		}
+		view.SetUIntUnchecked(SequenceField, 0xBEEF)
+		view.SetUIntUnchecked(VersionField, 9)
+		view.SetBoolUnchecked(LastFlag, true)
+		if err := view.Commit(); err != nil {
+			t.Fatalf("%v: Commit: %v", buf.order, err)
+		}
+
+		view.SetUIntUnchecked(VersionField, 1)
+		view.Reset()
+		if got := view.UInt(VersionField); got != 9 {
+			t.Errorf("%v: Reset re-read Version = %d, want 9", buf.order, got)
+		}
+	}
+
+	if want := (Packed32{0x09, 0xEF, 0xBE, 0x80}); le != want {
+		t.Errorf("LittleEndian view = % x, want % x", le, want)
+	}
+	if want := (Packed32{0x80, 0xBE, 0xEF, 0x09}); be != want {
+		t.Errorf("BigEndian view = % x, want % x", be, want)
+	}
+}
+
+func reversed(b []byte) []byte {
+	r := slices.Clone(b)
+	slices.Reverse(r)
+	return r
+}
+
+// ============ Порядок байтов для остальных видов полей ============
+
+// orderLayout — поля всех видов в 96-битном буфере, часть пересекает границу 64 бит
+type orderLayout struct {
+	kind   UIntBitField
+	enum   EnumBitField[uint8]
+	health UIntBitField
+	delta  IntBitField
+	speed  FixedBitField
+	mana   OptionalUIntBitField
+	shield OptionalIntBitField
+	level  NullableUIntBitField
+	alive  BoolBitField
+	slots  PackedArray
+	schema *Schema
+}
+
+func newOrderLayout() orderLayout {
+	l := NewLayout("order", 96)
+	var o orderLayout
+	o.kind = l.AddUInt("kind", 5)
+	o.enum = MustNewEnumBitField(o.kind, []uint8{0, 2, 5}, []string{"none", "mage", "smith"})
+	o.health = l.AddBiasedUInt("health", 1, 1000)
+	o.delta = l.AddInt("delta", -500, 500)
+	o.speed = l.AddFixed("speed", 0, 10, 0.25, RoundNearest)
+	o.mana = l.AddOptionalUInt("mana", 300)
+	o.shield = l.AddOptionalInt("shield", -40, 40)
+	o.level = l.AddNullableUInt("level", 60)
+	o.alive = l.AddBool("alive")
+	o.slots = l.AddArray("slots", 4, 7)
+	l.Reserve("spare", 3)
+	o.schema = l.MustSchema()
+	return o
+}
+
+func TestByteOrderFieldKinds(t *testing.T) {
+	f := newOrderLayout()
+	le := make([]byte, 12)
+	be := make([]byte, 12)
+	ref := make([]byte, 12)
+
+	for _, buf := range []struct {
+		order ByteOrder
+		data  []byte
+	}{{LittleEndian, le}, {BigEndian, be}} {
+		o, p := buf.order, buf.data
+		for _, err := range []error{
+			SetEnumFieldOrder(o, p, f.enum, 5),
+			o.SetUInt(p, f.health, 700),
+			o.SetInt(p, f.delta, -321),
+			o.SetFixed(p, f.speed, 7.25),
+			o.SetOptionalUInt(p, f.mana, 250),
+			o.SetOptionalInt(p, f.shield, -17),
+			o.SetNullableUInt(p, f.level, 0),
+			o.SetBool(p, f.alive, true),
+			f.slots.WithOrder(o).Fill(p, 6),
+			f.slots.WithOrder(o).Set(p, 2, 3),
+		} {
+			if err != nil {
+				t.Fatalf("%v: set: %v", o, err)
+			}
+		}
+		if _, err := o.AddUInt(p, f.health, 200); err != nil {
+			t.Fatalf("%v: AddUInt: %v", o, err)
+		}
+		if got := o.SubIntSaturating(p, f.delta, 1000); got != -500 {
+			t.Fatalf("%v: SubIntSaturating = %d, want -500", o, got)
+		}
+		if _, err := o.AddNullableUInt(p, f.level, 42); err != nil {
+			t.Fatalf("%v: AddNullableUInt: %v", o, err)
+		}
+		if _, err := o.AddUInt(p, f.health, 1000); !errors.Is(err, ErrValueOverflow) {
+			t.Fatalf("%v: AddUInt overflow err = %v, want %v", o, err, ErrValueOverflow)
+		}
+	}
+
+	_ = SetEnumField(ref, f.enum, 5)
+	_ = SetUIntFieldAs(ref, f.health, uint64(900))
+	_ = SetIntFieldAs(ref, f.delta, int64(-500))
+	_ = SetFixedField(ref, f.speed, 7.25)
+	_ = SetOptionalUIntFieldAs(ref, f.mana, uint64(250))
+	_ = SetOptionalIntFieldAs(ref, f.shield, int64(-17))
+	_ = SetNullableUIntFieldAs(ref, f.level, uint64(42))
+	_ = SetBoolField(ref, f.alive, true)
+	_ = f.slots.Fill(ref, 6)
+	_ = f.slots.Set(ref, 2, 3)
+
+	if !slices.Equal(le, ref) {
+		t.Fatalf("LittleEndian % x differs from functions without order % x", le, ref)
+	}
+	if !slices.Equal(be, reversed(le)) {
+		t.Fatalf("BigEndian % x, want reversed % x", be, le)
+	}
+
+	for _, buf := range []struct {
+		order ByteOrder
+		data  []byte
+	}{{LittleEndian, le}, {BigEndian, be}} {
+		o, p := buf.order, buf.data
+		if got, err := GetEnumFieldOrder(o, p, f.enum); err != nil || got != 5 {
+			t.Errorf("%v: GetEnumFieldOrder = %d, %v, want 5", o, got, err)
+		}
+		if got, err := o.GetFixed(p, f.speed); err != nil || got != 7.25 {
+			t.Errorf("%v: GetFixed = %g, %v, want 7.25", o, got, err)
+		}
+		if got, ok, err := o.GetOptionalUInt(p, f.mana); err != nil || !ok || got != 250 {
+			t.Errorf("%v: GetOptionalUInt = %d, %v, %v, want 250", o, got, ok, err)
+		}
+		if got, ok := o.GetOptionalIntUnchecked(p, f.shield); !ok || got != -17 {
+			t.Errorf("%v: GetOptionalInt = %d, %v, want -17", o, got, ok)
+		}
+		if got, ok, err := o.GetNullableUInt(p, f.level); err != nil || !ok || got != 42 {
+			t.Errorf("%v: GetNullableUInt = %d, %v, %v, want 42", o, got, ok, err)
+		}
+		if got, err := f.slots.WithOrder(o).Count(p, 6); err != nil || got != 3 {
+			t.Errorf("%v: PackedArray.Count = %d, %v, want 3", o, got, err)
+		}
+
+		o.ClearOptionalUInt(p, f.mana)
+		o.ClearNullableUInt(p, f.level)
+		if _, ok := o.GetOptionalUIntUnchecked(p, f.mana); ok {
+			t.Errorf("%v: mana present after Clear", o)
+		}
+		if _, ok := o.GetNullableUIntUnchecked(p, f.level); ok {
+			t.Errorf("%v: level present after Clear", o)
+		}
+	}
+	if !slices.Equal(be, reversed(le)) {
+		t.Fatalf("after Clear BigEndian % x, want reversed % x", be, le)
+	}
+}
+
+func TestByteOrderFieldKindErrors(t *testing.T) {
+	f := newOrderLayout()
+	tests := []struct {
+		name string
+		set  func(o ByteOrder, packed []byte) error
+		want error
+	}{
+		{"fixed range", func(o ByteOrder, p []byte) error { return o.SetFixed(p, f.speed, 11) }, ErrFixedOutOfRange},
+		{"enum unknown", func(o ByteOrder, p []byte) error { return SetEnumFieldOrder(o, p, f.enum, 3) }, ErrEnumValueUnknown},
+		{"optional range", func(o ByteOrder, p []byte) error { return o.SetOptionalInt(p, f.shield, 41) }, ErrValueOverflow},
+		{"nullable range", func(o ByteOrder, p []byte) error { return o.SetNullableUInt(p, f.level, 61) }, ErrValueOverflow},
+		{"array value", func(o ByteOrder, p []byte) error { return f.slots.WithOrder(o).Set(p, 0, 8) }, ErrValueOverflow},
+		{"schema range", func(o ByteOrder, p []byte) error { return f.schema.WithOrder(o).Set(p, "health", 0) }, ErrValueUnderflow},
+		{"short buffer", func(o ByteOrder, p []byte) error { return o.SetFixed(p[:2], f.speed, 1) }, ErrFieldOutOfSlice},
+	}
+
+	for _, tt := range tests {
+		for _, order := range []ByteOrder{LittleEndian, BigEndian} {
+			t.Run(tt.name+"/"+order.String(), func(t *testing.T) {
+				packed := make([]byte, 12)
+				before := slices.Clone(packed)
+				if err := tt.set(order, packed); !errors.Is(err, tt.want) {
+					t.Errorf("err = %v, want %v", err, tt.want)
+				}
+				if !slices.Equal(packed, before) {
+					t.Errorf("packed changed on error: % x", packed)
+				}
+			})
+		}
+	}
+}
+
+func TestByteOrderSchema(t *testing.T) {
+	f := newOrderLayout()
+	beSchema := f.schema.WithOrder(BigEndian)
+	if f.schema.Order() != LittleEndian || beSchema.Order() != BigEndian {
+		t.Fatalf("Order = %v, %v, want LittleEndian, BigEndian", f.schema.Order(), beSchema.Order())
+	}
+
+	le := make([]byte, 12)
+	be := make([]byte, 12)
+	for _, s := range []struct {
+		schema *Schema
+		data   []byte
+	}{{f.schema, le}, {beSchema, be}} {
+		for _, err := range []error{
+			s.schema.Set(s.data, "kind", 2),
+			s.schema.Set(s.data, "health", 321),
+			s.schema.Set(s.data, "delta", -7),
+			s.schema.SetFixed(s.data, "speed", 2.5),
+			s.schema.Set(s.data, "mana", 100),
+			s.schema.Set(s.data, "level", 9),
+			s.schema.Set(s.data, "alive", 1),
+		} {
+			if err != nil {
+				t.Fatalf("%v: Set: %v", s.schema.Order(), err)
+			}
+		}
+	}
+	if !slices.Equal(be, reversed(le)) {
+		t.Fatalf("BigEndian % x, want reversed % x", be, le)
+	}
+
+	for name, want := range map[string]int64{"kind": 2, "health": 321, "delta": -7, "mana": 100, "level": 9, "alive": 1} {
+		if got, err := beSchema.Get(be, name); err != nil || got != want {
+			t.Errorf("BigEndian Get(%s) = %d, %v, want %d", name, got, err, want)
+		}
+	}
+	if got, err := beSchema.GetFixed(be, "speed"); err != nil || got != 2.5 {
+		t.Errorf("BigEndian GetFixed(speed) = %g, %v, want 2.5", got, err)
+	}
+	if err := beSchema.Validate(be); err != nil {
+		t.Errorf("BigEndian Validate: %v", err)
+	}
+	// Тот же буфер в чужом порядке даёт другие значения
+	if got, _ := f.schema.Get(be, "health"); got == 321 {
+		t.Errorf("LittleEndian schema read BigEndian health as 321")
+	}
+
+	changed := slices.Clone(be)
+	if err := beSchema.Clear(changed, "mana"); err != nil {
+		t.Fatalf("BigEndian Clear: %v", err)
+	}
+	if diff, err := beSchema.Diff(be, changed); err != nil || !slices.Equal(diff, []string{"mana", "manaPresent"}) {
+		t.Errorf("BigEndian Diff = %v, %v, want [mana manaPresent]", diff, err)
+	}
+
+	// Поток не зависит от порядка: буфер BigEndian читается в LittleEndian
+	w := NewWriter(16)
+	if err := w.WriteSchema(beSchema, be); err != nil {
+		t.Fatalf("WriteSchema: %v", err)
+	}
+	got := make([]byte, 12)
+	if err := NewReader(w.Bytes()).ReadSchema(f.schema, got); err != nil {
+		t.Fatalf("ReadSchema: %v", err)
+	}
+	if !slices.Equal(got, le) {
+		t.Errorf("ReadSchema = % x, want % x", got, le)
+	}
+}
+
+func TestByteOrderMarshal(t *testing.T) {
+	type stats struct {
+		Kind   uint8 `bitpack:"0:2,enum=0|2|5"`
+		Level  uint8 `bitpack:"3:6,min=1,max=10"`
+		Delta  int16 `bitpack:"7:18"`
+		Active bool  `bitpack:"19"`
+	}
+	in := stats{Kind: 5, Level: 7, Delta: -900, Active: true}
+
+	le := make([]byte, 3)
+	be := make([]byte, 3)
+	if err := Marshal(&in, le); err != nil {
+		t.Fatalf("Marshal: %v", err)
+	}
+	if err := BigEndian.Marshal(&in, be); err != nil {
+		t.Fatalf("BigEndian.Marshal: %v", err)
+	}
+	if !slices.Equal(be, reversed(le)) {
+		t.Fatalf("BigEndian % x, want reversed % x", be, le)
+	}
+
+	var out stats
+	if err := BigEndian.Unmarshal(be, &out); err != nil || out != in {
+		t.Errorf("BigEndian.Unmarshal = %+v, %v, want %+v", out, err, in)
+	}
+	if err := BigEndian.Marshal(&stats{Kind: 3, Level: 1}, be); !errors.Is(err, ErrEnumValueUnknown) {
+		t.Errorf("BigEndian.Marshal unknown enum err = %v, want %v", err, ErrEnumValueUnknown)
+	}
+}
@@ -0,0 +1,159 @@
+package bitpack
+
+import (
+	"fmt"
+	"slices"
+)
+
+// =================  EnumBitField ==========================================
+// ======== Хранение перечисления с явным набором допустимых значений =======
+//
+// EnumBitField хранит значение перечисления в беззнаковом поле и знает
+// полный список допустимых значений и их имён. Checked запись отклоняет
+// значения вне списка, Name/Parse дают строковое представление.
+//
+//	types := bitpack.MustNewEnumBitField(typeField,
+//	    []PersonType{Builder, Blacksmith, Warrior},
+//	    []string{"Builder", "Blacksmith", "Warrior"})
+
+type EnumBitField[T UnsignedInteger] struct {
+	Field  UIntBitField // Размещение значения в битах
+	values []T
+	names  []string
+}
+
+// NewEnumBitField создаёт перечисление поверх поля. Значения и имена
+// задаются параллельными срезами и должны быть уникальны.
+func NewEnumBitField[T UnsignedInteger](field UIntBitField, values []T, names []string) (EnumBitField[T], error) {
+	if len(values) == 0 || len(values) != len(names) {
+		return EnumBitField[T]{}, newEnumDefinitionError(len(values), len(names))
+	}
+
+	for i, v := range values {
+		if uint64(v) < field.Min {
+			return EnumBitField[T]{}, newValueUnderflowError(uint64(v), field.Min, field.Width())
+		}
+		if uint64(v) > field.Max {
+			return EnumBitField[T]{}, newValueOverflowError(uint64(v), field.Max, field.Width())
+		}
+		if names[i] == "" || slices.Index(values, v) != i || slices.Index(names, names[i]) != i {
+			return EnumBitField[T]{}, newEnumDuplicateError(uint64(v), names[i])
+		}
+	}
+
+	return EnumBitField[T]{
+		Field:  field,
+		values: slices.Clone(values),
+		names:  slices.Clone(names),
+	}, nil
+}
+
+// MustNewEnumBitField создаёт перечисление или паникует при ошибке конфигурации
+// Используется ТОЛЬКО для статических конфигураций
+
+func MustNewEnumBitField[T UnsignedInteger](field UIntBitField, values []T, names []string) EnumBitField[T] {
+	bf, err := NewEnumBitField(field, values, names)
+	if err != nil {
+		panic(fmt.Sprintf("FATAL: invalid static enum bit field configuration [%v, values=%v]: %v",
+			field, values, err))
+	}
+	return bf
+}
+
+// Get извлекает значение перечисления (без проверки принадлежности списку)
+func (bf EnumBitField[T]) Get(bitSet BitSet64) T {
+	return T(bf.Field.Get(bitSet))
+}
+
+// Update записывает значение, отклоняя значения вне списка допустимых
+func (bf EnumBitField[T]) Update(bitSet BitSet64, value T) (BitSet64, error) {
+	if !bf.Contains(value) {
+		return bitSet, bf.Field.label.annotate(newEnumValueUnknownError(uint64(value)))
+	}
+	return bf.UpdateUnchecked(bitSet, value), nil
+}
+
+func (bf EnumBitField[T]) UpdateUnchecked(bitSet BitSet64, value T) BitSet64 {
+	return bf.Field.UpdateUnchecked(bitSet, uint64(value))
+}
+
+// ------------- Значения и имена --------------------------------
+
+// Contains сообщает, входит ли значение в список допустимых
+func (bf EnumBitField[T]) Contains(value T) bool {
+	return slices.Contains(bf.values, value)
+}
+
+// Values возвращает допустимые значения в порядке объявления
+func (bf EnumBitField[T]) Values() []T {
+	return slices.Clone(bf.values)
+}
+
+// Name возвращает имя значения; ok == false для значений вне списка
+func (bf EnumBitField[T]) Name(value T) (string, bool) {
+	if i := slices.Index(bf.values, value); i >= 0 {
+		return bf.names[i], true
+	}
+	return "", false
+}
+
+// Parse возвращает значение по имени (с учётом регистра)
+func (bf EnumBitField[T]) Parse(name string) (T, error) {
+	if i := slices.Index(bf.names, name); i >= 0 {
+		return bf.values[i], nil
+	}
+	return 0, newEnumNameUnknownError(name)
+}
+
+// Строковое представление для отладки
+func (bf EnumBitField[T]) String() string {
+	return fmt.Sprintf("EnumBitField[%d:%d] values=%v", bf.Field.Start, bf.Field.End, bf.names)
+}
+
+// ==================== Функции над срезом ====================
+
+func GetEnumField[T UnsignedInteger](packed []byte, field EnumBitField[T]) T {
+	return GetUIntFieldAs[T](packed, field.Field)
+}
+
+func SetEnumField[T UnsignedInteger](packed []byte, field EnumBitField[T], value T) error {
+	if err := validatePacked(packed, field.Field.End); err != nil {
+		return field.Field.label.annotate(err)
+	}
+	if !field.Contains(value) {
+		return field.Field.label.annotate(newEnumValueUnknownError(uint64(value)))
+	}
+	SetUIntFieldUncheckedAs(packed, field.Field, value)
+	return nil
+}
+
+func SetEnumFieldUnchecked[T UnsignedInteger](packed []byte, field EnumBitField[T], value T) {
+	SetUIntFieldUncheckedAs(packed, field.Field, value)
+}
+
+// ==================== Заданный порядок байтов ====================
+// Методы не могут иметь параметров типа, поэтому порядок — первый аргумент.
+
+func GetEnumFieldOrder[T UnsignedInteger](o ByteOrder, packed []byte, field EnumBitField[T]) (T, error) {
+	value, err := o.GetUInt(packed, field.Field)
+	return T(value), err
+}
+
+func GetEnumFieldOrderUnchecked[T UnsignedInteger](o ByteOrder, packed []byte, field EnumBitField[T]) T {
+	return T(o.GetUIntUnchecked(packed, field.Field))
+}
+
+func SetEnumFieldOrder[T UnsignedInteger](o ByteOrder, packed []byte, field EnumBitField[T], value T) error {
+	if err := validatePacked(packed, field.Field.End); err != nil {
+		return field.Field.label.annotate(err)
+	}
+	if !field.Contains(value) {
+		return field.Field.label.annotate(newEnumValueUnknownError(uint64(value)))
+	}
+	o.SetUIntUnchecked(packed, field.Field, uint64(value))
+	return nil
+}
+
+func SetEnumFieldOrderUnchecked[T UnsignedInteger](o ByteOrder, packed []byte, field EnumBitField[T], value T) {
+	o.SetUIntUnchecked(packed, field.Field, uint64(value))
+}
@@ -0,0 +1,143 @@
+package bitpack
+
+import (
+	"errors"
+	"slices"
+	"testing"
+)
+
+// ============ Тесты для EnumBitField ============
+
+type testSpecies uint8
+
+const (
+	speciesGoblin testSpecies = 0
+	speciesTroll  testSpecies = 1
+	speciesDragon testSpecies = 5 // значения не обязаны идти подряд
+)
+
+func newTestSpecies(t *testing.T) EnumBitField[testSpecies] {
+	t.Helper()
+	bf, err := NewEnumBitField(MustNewUIntBitField(4, 6, 7),
+		[]testSpecies{speciesGoblin, speciesTroll, speciesDragon},
+		[]string{"Goblin", "Troll", "Dragon"})
+	if err != nil {
+		t.Fatalf("NewEnumBitField: %v", err)
+	}
+	return bf
+}
+
+func TestNewEnumBitFieldErrors(t *testing.T) {
+	field := MustNewUIntBitField(0, 1, 3)
+
+	tests := []struct {
+		name   string
+		values []uint8
+		names  []string
+		want   error
+	}{
+		{"empty", nil, nil, ErrEnumDefinition},
+		{"length mismatch", []uint8{0, 1}, []string{"A"}, ErrEnumDefinition},
+		{"value exceeds field", []uint8{0, 4}, []string{"A", "B"}, ErrValueOverflow},
+		{"duplicate value", []uint8{1, 1}, []string{"A", "B"}, ErrEnumDuplicate},
+		{"duplicate name", []uint8{0, 1}, []string{"A", "A"}, ErrEnumDuplicate},
+		{"empty name", []uint8{0, 1}, []string{"A", ""}, ErrEnumDuplicate},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := NewEnumBitField(field, tt.values, tt.names)
+			if !errors.Is(err, tt.want) {
+				t.Errorf("err = %v, want %v", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestMustNewEnumBitFieldPanics(t *testing.T) {
+	defer func() {
+		if r := recover(); r == nil {
+			t.Error("expected panic but got none")
+		}
+	}()
+	MustNewEnumBitField(MustNewUIntBitField(0, 0, 1), []uint8{0, 2}, []string{"A", "B"})
+}
+
+func TestEnumBitFieldUpdate(t *testing.T) {
+	bf := newTestSpecies(t)
+
+	bits, err := bf.Update(BitSet64(0b1000_1111), speciesDragon)
+	if err != nil {
+		t.Fatalf("Update: %v", err)
+	}
+	if got := bf.Get(bits); got != speciesDragon {
+		t.Errorf("Get() = %d, want %d", got, speciesDragon)
+	}
+	if bits&0b1000_1111 != 0b1000_1111 {
+		t.Errorf("neighbour bits changed: %08b", bits)
+	}
+
+	// 3 помещается в поле, но не входит в список
+	if _, err := bf.Update(bits, 3); !errors.Is(err, ErrEnumValueUnknown) {
+		t.Errorf("Update(3) err = %v, want unknown value", err)
+	}
+	if got := bf.UpdateUnchecked(0, 3); bf.Get(got) != 3 {
+		t.Error("UpdateUnchecked must store value without validation")
+	}
+}
+
+func TestEnumBitFieldNames(t *testing.T) {
+	bf := newTestSpecies(t)
+
+	if got := bf.Values(); !slices.Equal(got, []testSpecies{speciesGoblin, speciesTroll, speciesDragon}) {
+		t.Errorf("Values() = %v", got)
+	}
+
+	for _, v := range bf.Values() {
+		name, ok := bf.Name(v)
+		if !ok {
+			t.Fatalf("Name(%d) not found", v)
+		}
+		parsed, err := bf.Parse(name)
+		if err != nil || parsed != v {
+			t.Errorf("Parse(Name(%d)) = %d, %v", v, parsed, err)
+		}
+	}
+
+	if _, ok := bf.Name(2); ok {
+		t.Error("Name(2) must not be found")
+	}
+	if _, err := bf.Parse("goblin"); !errors.Is(err, ErrEnumNameUnknown) {
+		t.Errorf("Parse(goblin) err = %v, want unknown name", err)
+	}
+
+	// Values() возвращает копию
+	values := bf.Values()
+	values[0] = 7
+	if bf.Contains(7) {
+		t.Error("Values() must return a copy")
+	}
+}
+
+func TestEnumFieldSliceFunctions(t *testing.T) {
+	bf := newTestSpecies(t)
+	var packed Packed8
+
+	if err := SetEnumField(packed[:], bf, speciesTroll); err != nil {
+		t.Fatalf("SetEnumField: %v", err)
+	}
+	if got := GetEnumField(packed[:], bf); got != speciesTroll {
+		t.Errorf("GetEnumField() = %d, want %d", got, speciesTroll)
+	}
+	if err := SetEnumField(packed[:], bf, 4); !errors.Is(err, ErrEnumValueUnknown) {
+		t.Errorf("SetEnumField(4) err = %v, want unknown value", err)
+	}
+	if got := GetEnumField(packed[:], bf); got != speciesTroll {
+		t.Errorf("failed set changed value to %d", got)
+	}
+
+	SetEnumFieldUnchecked(packed[:], bf, speciesDragon)
+	if got := GetEnumField(packed[:], bf); got != speciesDragon {
+		t.Errorf("GetEnumField() = %d, want %d", got, speciesDragon)
+	}
+}
@@ -0,0 +1,246 @@
+package bitpack
+
+import (
+	"fmt"
+	"math"
+)
+
+// =================  FixedBitField =========================================
+// ======== Хранение дробных значений с фиксированным шагом =================
+//
+// FixedBitField квантует float64 из диапазона [Min, Max] с шагом Step
+// и хранит номер шага (value-Min)/Step в беззнаковом поле:
+//
+//	speed, _ := bitpack.NewFixedBitField(0, 7, 0, 20, 0.1, bitpack.RoundNearest) // 200 шагов, 8 бит
+//	crit, _  := bitpack.NewFixedBitFieldAuto(8, 15, 0, 100, bitpack.RoundDown)    // шаг 100/255
+//
+// Get возвращает восстановленное значение Min + шаг*Step, то есть с точностью
+// до Step. Способ округления при записи задаётся RoundingMode.
+
+// RoundingMode — способ округления значения к ближайшему шагу сетки
+type RoundingMode uint8
+
+const (
+	RoundNearest     RoundingMode = iota // к ближайшему шагу, половина — от нуля
+	RoundNearestEven                     // к ближайшему шагу, половина — к чётному (банковское)
+	RoundDown                            // вниз, к Min
+	RoundUp                              // вверх, к Max
+)
+
+func (m RoundingMode) String() string {
+	switch m {
+	case RoundNearest:
+		return "RoundNearest"
+	case RoundNearestEven:
+		return "RoundNearestEven"
+	case RoundDown:
+		return "RoundDown"
+	case RoundUp:
+		return "RoundUp"
+	default:
+		return fmt.Sprintf("RoundingMode(%d)", m)
+	}
+}
+
+// round применяет способ округления к дробному номеру шага
+func (m RoundingMode) round(steps float64) float64 {
+	switch m {
+	case RoundNearestEven:
+		return math.RoundToEven(steps)
+	case RoundDown:
+		return math.Floor(steps)
+	case RoundUp:
+		return math.Ceil(steps)
+	default:
+		return math.Round(steps)
+	}
+}
+
+type FixedBitField struct {
+	Field    UIntBitField // Номер шага в битах: 0 … Field.Max
+	Min      float64      // Минимальное значение (номер шага 0)
+	Max      float64      // Максимальное значение (номер шага Field.Max)
+	Step     float64      // Разрешение: разница между соседними значениями
+	Rounding RoundingMode // Способ округления при записи
+}
+
+// NewFixedBitField создаёт поле для значений [min, max] с шагом step.
+// Диапазон должен делиться на шаг нацело, количество шагов — помещаться в биты поля.
+func NewFixedBitField(start, end BitPosition, min, max, step float64, rounding RoundingMode) (FixedBitField, error) {
+	if start > end {
+		return FixedBitField{}, newStartAfterEndError(start, end)
+	}
+	if end >= 64 {
+		return FixedBitField{}, newEndOutOfRangeError(end)
+	}
+	return newFixedBitField(start, end, min, max, step, rounding)
+}
+
+// NewFixedBitFieldAuto создаёт поле, шаг которого выбран по ширине:
+// весь диапазон [min, max] делится на 2^width-1 шагов
+func NewFixedBitFieldAuto(start, end BitPosition, min, max float64, rounding RoundingMode) (FixedBitField, error) {
+	if start > end {
+		return FixedBitField{}, newStartAfterEndError(start, end)
+	}
+	step := (max - min) / float64(maxAllowedForWidth(end-start+1))
+	return NewFixedBitField(start, end, min, max, step, rounding)
+}
+
+// newFixedBitField строит поле без ограничения end < 64 (для многословных буферов)
+func newFixedBitField(start, end BitPosition, min, max, step float64, rounding RoundingMode) (FixedBitField, error) {
+	steps, err := fixedSteps(min, max, step)
+	if err != nil {
+		return FixedBitField{}, err
+	}
+	field, err := newUIntBitField(start, end, 0, steps)
+	if err != nil {
+		return FixedBitField{}, err
+	}
+	return FixedBitField{
+		Field:    field,
+		Min:      min,
+		Max:      max,
+		Step:     step,
+		Rounding: rounding,
+	}, nil
+}
+
+// MustNewFixedBitField создаёт поле или паникует при ошибке конфигурации
+// Используется ТОЛЬКО для статических конфигураций
+
+func MustNewFixedBitField(start, end BitPosition, min, max, step float64, rounding RoundingMode) FixedBitField {
+	bf, err := NewFixedBitField(start, end, min, max, step, rounding)
+	if err != nil {
+		panic(fmt.Sprintf("FATAL: invalid static fixed bit field configuration [start=%d, end=%d, min=%g, max=%g, step=%g]: %v",
+			start, end, min, max, step, err))
+	}
+	return bf
+}
+
+// Get извлекает номер шага и восстанавливает дробное значение
+func (bf FixedBitField) Get(bitSet BitSet64) float64 {
+	return bf.dequantize(bf.Field.Get(bitSet))
+}
+
+// Update квантует значение и записывает его, отклоняя значения вне [Min, Max] и NaN
+func (bf FixedBitField) Update(bitSet BitSet64, value float64) (BitSet64, error) {
+	if !bf.inRange(value) {
+		return bitSet, bf.Field.label.annotate(newFixedOutOfRangeError(value, bf.Min, bf.Max))
+	}
+	return bf.Field.UpdateUnchecked(bitSet, bf.quantize(value)), nil
+}
+
+// UpdateUnchecked записывает значение без проверки: значения вне диапазона
+// прижимаются к границам, NaN записывается как Min
+func (bf FixedBitField) UpdateUnchecked(bitSet BitSet64, value float64) BitSet64 {
+	return bf.Field.UpdateUnchecked(bitSet, bf.quantize(value))
+}
+
+// Quantize возвращает значение, которое будет прочитано после записи value
+func (bf FixedBitField) Quantize(value float64) float64 {
+	return bf.dequantize(bf.quantize(value))
+}
+
+// ------------- Сервисные методы --------------------------------
+
+// quantize переводит значение в номер шага с прижатием к [0, Field.Max]
+func (bf FixedBitField) quantize(value float64) uint64 {
+	steps := bf.Rounding.round((value - bf.Min) / bf.Step)
+	switch {
+	case !(steps > 0): // включая NaN
+		return 0
+	case steps >= float64(bf.Field.Max):
+		return bf.Field.Max
+	default:
+		return uint64(steps)
+	}
+}
+
+// dequantize восстанавливает значение по номеру шага. Вычисление через долю
+// диапазона даёт точные Min и Max на краях (0.1*7 != 0.7 во float64).
+func (bf FixedBitField) dequantize(raw uint64) float64 {
+	if raw >= bf.Field.Max {
+		return bf.Max
+	}
+	return bf.Min + (bf.Max-bf.Min)*float64(raw)/float64(bf.Field.Max)
+}
+
+func (bf FixedBitField) inRange(value float64) bool {
+	return value >= bf.Min && value <= bf.Max
+}
+
+// fixedSteps — количество шагов сетки в диапазоне [min, max]
+func fixedSteps(min, max, step float64) (uint64, error) {
+	if math.IsNaN(min) || math.IsInf(min, 0) || math.IsNaN(max) || math.IsInf(max, 0) ||
+		!(min < max) || !(step > 0) || math.IsInf(step, 0) {
+		return 0, newFixedDefinitionError(min, max, step)
+	}
+
+	steps := (max - min) / step
+	rounded := math.Round(steps)
+	// Допускаем погрешность float64: (20-0)/0.1 = 199.99999999999997
+	if rounded < 1 || rounded >= math.MaxUint64 || math.Abs(steps-rounded) > 1e-9*rounded {
+		return 0, newFixedDefinitionError(min, max, step)
+	}
+	return uint64(rounded), nil
+}
+
+func (bf FixedBitField) Width() uint8 {
+	return bf.Field.Width()
+}
+
+// Строковое представление для отладки
+func (bf FixedBitField) String() string {
+	return fmt.Sprintf("FixedBitField[%d:%d] range=[%g,%g] step=%g %s",
+		bf.Field.Start, bf.Field.End, bf.Min, bf.Max, bf.Step, bf.Rounding)
+}
+
+// ==================== Функции над срезом ====================
+
+func GetFixedField(packed []byte, field FixedBitField) float64 {
+	return field.dequantize(GetUIntFieldAs[uint64](packed, field.Field))
+}
+
+func SetFixedField(packed []byte, field FixedBitField, value float64) error {
+	if err := validatePacked(packed, field.Field.End); err != nil {
+		return field.Field.label.annotate(err)
+	}
+	if !field.inRange(value) {
+		return field.Field.label.annotate(newFixedOutOfRangeError(value, field.Min, field.Max))
+	}
+	SetUIntFieldUncheckedAs(packed, field.Field, field.quantize(value))
+	return nil
+}
+
+func SetFixedFieldUnchecked(packed []byte, field FixedBitField, value float64) {
+	SetUIntFieldUncheckedAs(packed, field.Field, field.quantize(value))
+}
+
+// ==================== Заданный порядок байтов ====================
+
+func (o ByteOrder) GetFixed(packed []byte, field FixedBitField) (float64, error) {
+	raw, err := o.GetUInt(packed, field.Field)
+	if err != nil {
+		return 0, err
+	}
+	return field.dequantize(raw), nil
+}
+
+func (o ByteOrder) SetFixed(packed []byte, field FixedBitField, value float64) error {
+	if err := validatePacked(packed, field.Field.End); err != nil {
+		return field.Field.label.annotate(err)
+	}
+	if !field.inRange(value) {
+		return field.Field.label.annotate(newFixedOutOfRangeError(value, field.Min, field.Max))
+	}
+	o.SetUIntUnchecked(packed, field.Field, field.quantize(value))
+	return nil
+}
+
+func (o ByteOrder) GetFixedUnchecked(packed []byte, field FixedBitField) float64 {
+	return field.dequantize(o.GetUIntUnchecked(packed, field.Field))
+}
+
+func (o ByteOrder) SetFixedUnchecked(packed []byte, field FixedBitField, value float64) {
+	o.SetUIntUnchecked(packed, field.Field, field.quantize(value))
+}
@@ -0,0 +1,183 @@
+package bitpack
+
+import (
+	"errors"
+	"math"
+	"testing"
+)
+
+// ============ Тесты для FixedBitField ============
+
+func TestNewFixedBitField(t *testing.T) {
+	tests := []struct {
+		name      string
+		start     BitPosition
+		end       BitPosition
+		min, max  float64
+		step      float64
+		wantErr   error
+		wantSteps uint64
+	}{
+		{"speed 0-20 step 0.1", 0, 7, 0, 20, 0.1, nil, 200},
+		{"negative range", 8, 15, -1, 1, 0.01, nil, 200},
+		{"too many steps", 0, 6, 0, 20, 0.1, ErrValueOverflow, 0},
+		{"range not multiple of step", 0, 7, 0, 1, 0.3, ErrFixedDefinition, 0},
+		{"zero step", 0, 7, 0, 1, 0, ErrFixedDefinition, 0},
+		{"inverted range", 0, 7, 1, 0, 0.1, ErrFixedDefinition, 0},
+		{"NaN bound", 0, 7, math.NaN(), 1, 0.1, ErrFixedDefinition, 0},
+		{"start after end", 7, 0, 0, 1, 0.1, ErrStartAfterEnd, 0},
+		{"end out of range", 60, 64, 0, 1, 0.1, ErrEndOutOfRange, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			bf, err := NewFixedBitField(tt.start, tt.end, tt.min, tt.max, tt.step, RoundNearest)
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Errorf("err = %v, want %v", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if bf.Field.Max != tt.wantSteps {
+				t.Errorf("steps = %d, want %d", bf.Field.Max, tt.wantSteps)
+			}
+		})
+	}
+}
+
+func TestFixedBitFieldAuto(t *testing.T) {
+	// Шанс крита 0-100% в 8 битах
+	bf, err := NewFixedBitFieldAuto(0, 7, 0, 100, RoundNearest)
+	if err != nil {
+		t.Fatalf("NewFixedBitFieldAuto: %v", err)
+	}
+	if bf.Field.Max != 255 {
+		t.Errorf("steps = %d, want 255", bf.Field.Max)
+	}
+
+	for _, v := range []float64{0, 100} {
+		bits, err := bf.Update(0, v)
+		if err != nil {
+			t.Fatalf("Update(%g): %v", v, err)
+		}
+		if got := bf.Get(bits); got != v {
+			t.Errorf("Get() = %g, want exact bound %g", got, v)
+		}
+	}
+	if got := bf.Quantize(50); math.Abs(got-50) > bf.Step/2 {
+		t.Errorf("Quantize(50) = %g, error exceeds half step %g", got, bf.Step/2)
+	}
+}
+
+func TestFixedBitFieldRoundTrip(t *testing.T) {
+	bf := MustNewFixedBitField(4, 11, 0, 20, 0.1, RoundNearest)
+	bitSet := BitSet64(0b1111)
+
+	for _, v := range []float64{0, 0.1, 0.7, 9.9, 12.3, 20} {
+		bits, err := bf.Update(bitSet, v)
+		if err != nil {
+			t.Fatalf("Update(%g): %v", v, err)
+		}
+		if got := bf.Get(bits); math.Abs(got-v) > 1e-12 {
+			t.Errorf("Get() = %g, want %g", got, v)
+		}
+		if bits&0b1111 != 0b1111 {
+			t.Errorf("neighbour bits changed: %b", bits)
+		}
+	}
+}
+
+func TestFixedBitFieldRounding(t *testing.T) {
+	tests := []struct {
+		mode  RoundingMode
+		value float64
+		want  float64
+	}{
+		{RoundNearest, 1.25, 1.5},
+		{RoundNearest, 1.24, 1.0},
+		{RoundNearest, 1.75, 2.0},
+		{RoundNearestEven, 1.25, 1.0},
+		{RoundNearestEven, 1.75, 2.0},
+		{RoundDown, 1.49, 1.0},
+		{RoundUp, 1.01, 1.5},
+		{RoundUp, 1.5, 1.5},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.mode.String(), func(t *testing.T) {
+			bf := MustNewFixedBitField(0, 7, 0, 10, 0.5, tt.mode)
+			if got := bf.Quantize(tt.value); got != tt.want {
+				t.Errorf("Quantize(%g) = %g, want %g", tt.value, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFixedBitFieldOutOfRange(t *testing.T) {
+	bf := MustNewFixedBitField(0, 7, 0, 20, 0.1, RoundNearest)
+
+	for _, v := range []float64{-0.01, 20.01, math.NaN(), math.Inf(1)} {
+		if _, err := bf.Update(0, v); !errors.Is(err, ErrFixedOutOfRange) {
+			t.Errorf("Update(%g) err = %v, want out of range", v, err)
+		}
+	}
+
+	// Unchecked прижимает к границам
+	if got := bf.Get(bf.UpdateUnchecked(0, 100)); got != 20 {
+		t.Errorf("UpdateUnchecked(100) = %g, want 20", got)
+	}
+	if got := bf.Get(bf.UpdateUnchecked(0, -5)); got != 0 {
+		t.Errorf("UpdateUnchecked(-5) = %g, want 0", got)
+	}
+	if got := bf.Get(bf.UpdateUnchecked(0, math.NaN())); got != 0 {
+		t.Errorf("UpdateUnchecked(NaN) = %g, want 0", got)
+	}
+}
+
+func TestFixedFieldSliceFunctions(t *testing.T) {
+	var packed Packed96
+	bf := MustNewFixedBitField(50, 57, -1, 1, 0.01, RoundNearest)
+
+	if err := SetFixedField(packed[:], bf, -0.37); err != nil {
+		t.Fatalf("SetFixedField: %v", err)
+	}
+	if got := GetFixedField(packed[:], bf); math.Abs(got+0.37) > 1e-12 {
+		t.Errorf("GetFixedField() = %g, want -0.37", got)
+	}
+	if err := SetFixedField(packed[:], bf, 1.5); !errors.Is(err, ErrFixedOutOfRange) {
+		t.Errorf("SetFixedField(1.5) err = %v, want out of range", err)
+	}
+	if err := SetFixedField(packed[:4], bf, 0); !errors.Is(err, ErrFieldOutOfSlice) {
+		t.Errorf("SetFixedField(short slice) err = %v, want field out of slice", err)
+	}
+
+	SetFixedFieldUnchecked(packed[:], bf, 0.5)
+	if got := GetFixedField(packed[:], bf); got != 0.5 {
+		t.Errorf("GetFixedField() = %g, want 0.5", got)
+	}
+}
+
+func TestLayoutAddFixed(t *testing.T) {
+	layout := NewLayout("stats", 16)
+	speed := layout.AddFixed("speed", 0, 20, 0.1, RoundNearest)
+	crit := layout.AddFixed("crit", 0, 1, 0.25, RoundDown)
+	if err := layout.Err(); err != nil {
+		t.Fatalf("layout error: %v", err)
+	}
+
+	if speed.Field.Start != 0 || speed.Field.End != 7 {
+		t.Errorf("speed = %v, want bits 0-7", speed)
+	}
+	if crit.Field.Start != 8 || crit.Field.End != 10 {
+		t.Errorf("crit = %v, want bits 8-10", crit)
+	}
+
+	bad := NewLayout("bad", 16)
+	bad.AddFixed("ratio", 0, 1, 0.3, RoundNearest)
+	if !errors.Is(bad.Err(), ErrFixedDefinition) {
+		t.Errorf("Err() = %v, want fixed definition error", bad.Err())
+	}
+}
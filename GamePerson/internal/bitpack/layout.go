@@ -0,0 +1,325 @@
+package bitpack
+
+import (
+	"fmt"
+	"math"
+	"math/bits"
+)
+
+// =================  Layout ================================================
+// ============ Декларативное построение схемы битовых полей ================
+//
+// Layout последовательно раскладывает поля в порядке объявления, вычисляя
+// минимальную ширину каждого поля по его диапазону значений. Позиции
+// bit*Start/bit*End больше не нужно подбирать вручную:
+//
+//	layout := bitpack.NewLayout("header", 32)
+//	version  := layout.AddUInt("version", 15)   // биты 0-3
+//	delta    := layout.AddInt("delta", -4, 3)   // биты 4-6
+//	last     := layout.AddBool("last")          // бит 7
+//	if err := layout.Err(); err != nil { ... }
+//
+// Поля получают имена name и схемы: ошибки записи через них содержат
+// «field header.version: ...», а errors.As даёт доступ к Error.Field().
+//
+// Первая ошибка конфигурации запоминается, последующие Add* игнорируются
+// и возвращают нулевые поля. Проверить результат нужно через Err()
+// или MustBuild() (для статических схем).
+
+type Layout struct {
+	name   string
+	bits   int
+	cursor int
+	fields []layoutEntry
+	err    error
+}
+
+// layoutEntry — занятый диапазон битов в схеме
+type layoutEntry struct {
+	name  string
+	start BitPosition
+	end   BitPosition
+	field any // Поле, созданное Add*; nil для Reserve
+}
+
+// NewLayout создаёт пустую схему заданного размера в битах (1..MaxPackedBits).
+// Для PackedN типов удобно передавать 8*len(PackedN{}).
+// Схемы больше 64 бит работают только через функции над срезом (GetUIntFieldAs ...).
+func NewLayout(name string, bits int) *Layout {
+	l := &Layout{name: name, bits: bits}
+	if bits <= 0 || bits > MaxPackedBits {
+		l.err = newLayoutOverflowError(name, 0, 0, bits)
+	}
+	return l
+}
+
+// AddUInt добавляет беззнаковое поле минимальной ширины для значений [0, max]
+func (l *Layout) AddUInt(name string, max uint64) UIntBitField {
+	start, end, ok := l.place(name, uintWidthFor(max))
+	if !ok {
+		return UIntBitField{}
+	}
+	bf, err := newUIntBitField(start, end, 0, max)
+	if err != nil {
+		l.err = err
+		return UIntBitField{}
+	}
+	return attach(l, bf.Named(l.name, name))
+}
+
+// AddBiasedUInt добавляет беззнаковое поле для значений [min, max],
+// ширина вычисляется по max-min
+func (l *Layout) AddBiasedUInt(name string, min, max uint64) UIntBitField {
+	if l.err == nil && min > max {
+		l.err = newValueRangeInvertedError(int64(min), int64(max))
+	}
+	start, end, ok := l.place(name, uintWidthFor(max-min))
+	if !ok {
+		return UIntBitField{}
+	}
+	bf, err := newUIntBitField(start, end, min, max)
+	if err != nil {
+		l.err = err
+		return UIntBitField{}
+	}
+	return attach(l, bf.Named(l.name, name))
+}
+
+// AddInt добавляет знаковое поле минимальной ширины для значений [min, max]
+func (l *Layout) AddInt(name string, min, max int64) IntBitField {
+	if l.err == nil && min > max {
+		l.err = newValueRangeInvertedError(min, max)
+	}
+	start, end, ok := l.place(name, intWidthFor(min, max))
+	if !ok {
+		return IntBitField{}
+	}
+	bf, err := newIntBitField(start, end, min, max)
+	if err != nil {
+		l.err = err
+		return IntBitField{}
+	}
+	return attach(l, bf.Named(l.name, name))
+}
+
+// AddFixed добавляет дробное поле [min, max] с шагом step минимальной ширины
+func (l *Layout) AddFixed(name string, min, max, step float64, rounding RoundingMode) FixedBitField {
+	steps, err := fixedSteps(min, max, step)
+	if err != nil {
+		if l.err == nil {
+			l.err = err
+		}
+		return FixedBitField{}
+	}
+	start, end, ok := l.place(name, uintWidthFor(steps))
+	if !ok {
+		return FixedBitField{}
+	}
+	bf, err := newFixedBitField(start, end, min, max, step, rounding)
+	if err != nil {
+		l.err = err
+		return FixedBitField{}
+	}
+	bf.Field = bf.Field.Named(l.name, name)
+	return attach(l, bf)
+}
+
+// AddArray добавляет массив из length элементов минимальной ширины для значений [0, max]
+func (l *Layout) AddArray(name string, length int, max uint64) PackedArray {
+	width := uintWidthFor(max)
+	if l.err == nil && (length <= 0 || length > MaxPackedBits/width) {
+		l.err = newArrayDefinitionError(BitPosition(min(l.cursor, 255)), uint8(width), length)
+	}
+	start, _, ok := l.place(name, width*length)
+	if !ok {
+		return PackedArray{}
+	}
+	a, err := NewPackedArray(start, uint8(width), length, max)
+	if err != nil {
+		l.err = err
+		return PackedArray{}
+	}
+	return attach(l, a.Named(l.name, name))
+}
+
+// AddOptionalUInt добавляет беззнаковое поле [0, max] и следующий за ним бит присутствия name+"Present"
+func (l *Layout) AddOptionalUInt(name string, max uint64) OptionalUIntBitField {
+	return l.AddUIntPresence(l.AddUInt(name, max))
+}
+
+// AddOptionalInt добавляет знаковое поле [min, max] и следующий за ним бит присутствия name+"Present"
+func (l *Layout) AddOptionalInt(name string, min, max int64) OptionalIntBitField {
+	return l.AddIntPresence(l.AddInt(name, min, max))
+}
+
+// AddUIntPresence добавляет бит присутствия value.Name()+"Present" для поля,
+// уже объявленного в этой схеме (например, после всех полей). Schema знает
+// о паре: Set по имени поля отмечает присутствие.
+func (l *Layout) AddUIntPresence(value UIntBitField) OptionalUIntBitField {
+	present := l.AddBool(value.Name() + "Present")
+	field := OptionalUIntBitField{Value: value, Present: present}
+	if !bindOptional(l, value.Name(), field) {
+		return OptionalUIntBitField{}
+	}
+	return field
+}
+
+// AddIntPresence добавляет бит присутствия value.Name()+"Present" для уже объявленного знакового поля
+func (l *Layout) AddIntPresence(value IntBitField) OptionalIntBitField {
+	present := l.AddBool(value.Name() + "Present")
+	field := OptionalIntBitField{Value: value, Present: present}
+	if !bindOptional(l, value.Name(), field) {
+		return OptionalIntBitField{}
+	}
+	return field
+}
+
+// AddNullableUInt добавляет nullable поле [0, max] без бита присутствия:
+// ширина вычисляется по коду max+1
+func (l *Layout) AddNullableUInt(name string, max uint64) NullableUIntBitField {
+	if l.err == nil && max == math.MaxUint64 {
+		l.err = newValueOverflowError(max, max-1, 64)
+	}
+	start, end, ok := l.place(name, uintWidthFor(max+1))
+	if !ok {
+		return NullableUIntBitField{}
+	}
+	bf, err := newNullableUIntBitField(start, end, max)
+	if err != nil {
+		l.err = err
+		return NullableUIntBitField{}
+	}
+	return attach(l, bf.Named(l.name, name))
+}
+
+// AddBool добавляет однобитовый флаг
+func (l *Layout) AddBool(name string) BoolBitField {
+	start, _, ok := l.place(name, 1)
+	if !ok {
+		return BoolBitField{}
+	}
+	return attach(l, newBoolBitField(start).Named(l.name, name))
+}
+
+// Reserve резервирует width битов под будущие поля (без создания поля)
+func (l *Layout) Reserve(name string, width uint8) {
+	l.place(name, int(width))
+}
+
+// Seek переносит позицию следующего поля. Позволяет явно разместить поле,
+// при этом пересечение с уже объявленными полями будет обнаружено.
+func (l *Layout) Seek(pos BitPosition) {
+	if l.err != nil {
+		return
+	}
+	l.cursor = int(pos)
+}
+
+// Name — имя схемы
+func (l *Layout) Name() string {
+	return l.name
+}
+
+// Bits — размер схемы в битах
+func (l *Layout) Bits() int {
+	return l.bits
+}
+
+// UsedBits — количество битов, занятых полями и резервом
+func (l *Layout) UsedBits() int {
+	used := 0
+	for _, f := range l.fields {
+		used += int(f.end-f.start) + 1
+	}
+	return used
+}
+
+// Err возвращает первую ошибку конфигурации схемы
+func (l *Layout) Err() error {
+	return l.err
+}
+
+// MustBuild паникует, если схема сконфигурирована с ошибкой.
+// Используется ТОЛЬКО для статических схем, проверенных на этапе разработки
+
+func (l *Layout) MustBuild() {
+	if l.err != nil {
+		panic(fmt.Sprintf("FATAL: invalid static bit layout %q: %v", l.name, l.err))
+	}
+}
+
+// Строковое представление для отладки
+func (l *Layout) String() string {
+	return fmt.Sprintf("Layout[%s] %d/%d bits, %d fields", l.name, l.UsedBits(), l.bits, len(l.fields))
+}
+
+// ------------- Сервисные методы --------------------------------
+
+// place занимает width битов начиная с текущей позиции и сдвигает курсор
+func (l *Layout) place(name string, width int) (start, end BitPosition, ok bool) {
+	if l.err != nil {
+		return 0, 0, false
+	}
+	for _, f := range l.fields {
+		if f.name == name {
+			l.err = newDuplicateFieldError(name)
+			return 0, 0, false
+		}
+	}
+
+	last := l.cursor + width - 1
+	if width <= 0 || last >= l.bits {
+		l.err = newLayoutOverflowError(name, BitPosition(min(l.cursor, 255)), BitPosition(min(last, 255)), l.bits)
+		return 0, 0, false
+	}
+
+	start, end = BitPosition(l.cursor), BitPosition(last)
+	for _, f := range l.fields {
+		if start <= f.end && f.start <= end {
+			l.err = newFieldOverlapError(name, start, end, f.name)
+			return 0, 0, false
+		}
+	}
+
+	l.fields = append(l.fields, layoutEntry{name: name, start: start, end: end})
+	l.cursor = last + 1
+	return start, end, true
+}
+
+// attach запоминает поле, только что размещённое place, для Schema
+func attach[F any](l *Layout, field F) F {
+	l.fields[len(l.fields)-1].field = field
+	return field
+}
+
+// bindOptional заменяет поле значения name optional полем для Schema.
+// Поле должно быть объявлено в этой схеме.
+func bindOptional(l *Layout, name string, field any) bool {
+	if l.err != nil {
+		return false
+	}
+	for i := range l.fields {
+		if l.fields[i].name == name && l.fields[i].field != nil {
+			l.fields[i].field = field
+			return true
+		}
+	}
+	l.err = newUnknownFieldError(l.name, name)
+	return false
+}
+
+// uintWidthFor — минимальная ширина для беззнакового значения max (не меньше 1 бита)
+func uintWidthFor(maxValue uint64) int {
+	return max(bits.Len64(maxValue), 1)
+}
+
+// intWidthFor — минимальная ширина дополнительного кода для диапазона [min, max]
+func intWidthFor(min, max int64) int {
+	for width := uint8(1); width < 64; width++ {
+		allowedMin, allowedMax := intRangeForWidth(width)
+		if min >= allowedMin && max <= allowedMax {
+			return int(width)
+		}
+	}
+	return 64
+}
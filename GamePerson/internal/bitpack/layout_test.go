@@ -0,0 +1,322 @@
+package bitpack
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+// ============ Тесты для Layout ============
+
+func TestLayoutSequentialAllocation(t *testing.T) {
+	l := NewLayout("header", 32)
+	version := l.AddUInt("version", 15)     // 4 бита
+	delta := l.AddInt("delta", -4, 3)       // 3 бита
+	encrypted := l.AddBool("encrypted")     // 1 бит
+	sequence := l.AddUInt("sequence", 1000) // 10 бит
+	l.Reserve("reserved", 14)
+
+	if err := l.Err(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	checks := []struct {
+		name       string
+		start, end BitPosition
+		wantStart  BitPosition
+		wantEnd    BitPosition
+	}{
+		{"version", version.Start, version.End, 0, 3},
+		{"delta", delta.Start, delta.End, 4, 6},
+		{"encrypted", encrypted.Position, encrypted.Position, 7, 7},
+		{"sequence", sequence.Start, sequence.End, 8, 17},
+	}
+	for _, c := range checks {
+		if c.start != c.wantStart || c.end != c.wantEnd {
+			t.Errorf("%s = [%d:%d], want [%d:%d]", c.name, c.start, c.end, c.wantStart, c.wantEnd)
+		}
+	}
+
+	if sequence.Max != 1000 {
+		t.Errorf("sequence.Max = %d, want 1000", sequence.Max)
+	}
+	if delta.Min != -4 || delta.Max != 3 {
+		t.Errorf("delta range = [%d,%d], want [-4,3]", delta.Min, delta.Max)
+	}
+	if got := l.UsedBits(); got != 32 {
+		t.Errorf("UsedBits() = %d, want 32", got)
+	}
+}
+
+func TestLayoutFieldsWork(t *testing.T) {
+	l := NewLayout("mixed", 16)
+	a := l.AddUInt("a", 5)
+	b := l.AddInt("b", -10, 10)
+	c := l.AddBool("c")
+	l.MustBuild()
+
+	var bits BitSet64
+	bits = a.UpdateUnchecked(bits, 5)
+	bits = b.UpdateUnchecked(bits, -7)
+	bits = c.UpdateUnchecked(bits, true)
+
+	if a.Get(bits) != 5 || b.Get(bits) != -7 || !c.Get(bits) {
+		t.Errorf("round trip failed: a=%d b=%d c=%v", a.Get(bits), b.Get(bits), c.Get(bits))
+	}
+}
+
+func TestLayoutMinimalWidths(t *testing.T) {
+	tests := []struct {
+		name      string
+		add       func(l *Layout) uint8
+		wantWidth uint8
+	}{
+		{"uint max 0", func(l *Layout) uint8 { return l.AddUInt("f", 0).Width() }, 1},
+		{"uint max 1", func(l *Layout) uint8 { return l.AddUInt("f", 1).Width() }, 1},
+		{"uint max 42", func(l *Layout) uint8 { return l.AddUInt("f", 42).Width() }, 6},
+		{"uint max 1023", func(l *Layout) uint8 { return l.AddUInt("f", 1023).Width() }, 10},
+		{"uint max 1024", func(l *Layout) uint8 { return l.AddUInt("f", 1024).Width() }, 11},
+		{"int -1..0", func(l *Layout) uint8 { return l.AddInt("f", -1, 0).Width() }, 1},
+		{"int -128..127", func(l *Layout) uint8 { return l.AddInt("f", -128, 127).Width() }, 8},
+		{"int -129..0", func(l *Layout) uint8 { return l.AddInt("f", -129, 0).Width() }, 9},
+		{"int 0..100", func(l *Layout) uint8 { return l.AddInt("f", 0, 100).Width() }, 8},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			l := NewLayout("widths", 64)
+			got := tt.add(l)
+			if err := l.Err(); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got != tt.wantWidth {
+				t.Errorf("Width() = %d, want %d", got, tt.wantWidth)
+			}
+		})
+	}
+}
+
+func TestLayoutErrors(t *testing.T) {
+	tests := []struct {
+		name  string
+		build func(l *Layout)
+		want  error
+	}{
+		{
+			name: "overflow of layout size",
+			build: func(l *Layout) {
+				l.AddUInt("a", 255)
+				l.AddUInt("b", 255) // 16 бит в 12-битной схеме
+			},
+			want: ErrLayoutOverflow,
+		},
+		{
+			name: "overlap after seek",
+			build: func(l *Layout) {
+				l.AddUInt("a", 15) // 0-3
+				l.Seek(2)
+				l.AddBool("b")
+			},
+			want: ErrFieldOverlap,
+		},
+		{
+			name: "duplicate name",
+			build: func(l *Layout) {
+				l.AddBool("flag")
+				l.AddBool("flag")
+			},
+			want: ErrDuplicateField,
+		},
+		{
+			name: "inverted int range",
+			build: func(l *Layout) {
+				l.AddInt("a", 5, -5)
+			},
+			want: ErrValueRangeInverted,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			l := NewLayout("broken", 12)
+			tt.build(l)
+			if err := l.Err(); !errors.Is(err, tt.want) {
+				t.Errorf("Err() = %v, want %v", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestLayoutFirstErrorWins(t *testing.T) {
+	l := NewLayout("small", 4)
+	l.AddUInt("big", 255)
+	f := l.AddBool("after")
+
+	if !errors.Is(l.Err(), ErrLayoutOverflow) {
+		t.Fatalf("Err() = %v, want overflow", l.Err())
+	}
+	if f != (BoolBitField{}) {
+		t.Errorf("field after error must be zero, got %+v", f)
+	}
+}
+
+func TestLayoutInvalidSize(t *testing.T) {
+	for _, size := range []int{0, -1, MaxPackedBits + 1} {
+		if err := NewLayout("bad", size).Err(); !errors.Is(err, ErrLayoutOverflow) {
+			t.Errorf("NewLayout(%d).Err() = %v, want overflow", size, err)
+		}
+	}
+}
+
+func TestLayoutMustBuildPanics(t *testing.T) {
+	defer func() {
+		if r := recover(); r == nil {
+			t.Error("expected panic but got none")
+		}
+	}()
+	l := NewLayout("panic", 2)
+	l.AddUInt("wide", 100)
+	l.MustBuild()
+}
+
+func TestLayoutErrorFormatting(t *testing.T) {
+	tests := []struct {
+		err  error
+		want string
+	}{
+		{
+			err:  newFieldOverlapError("b", 2, 2, "a"),
+			want: `layout error: field "b" [2:2] overlaps field "a"`,
+		},
+		{
+			err:  newLayoutOverflowError("b", 8, 15, 12),
+			want: `layout error: field "b" [8:15] does not fit into 12-bit layout`,
+		},
+		{
+			err:  newDuplicateFieldError("flag"),
+			want: `layout error: duplicate field name "flag"`,
+		},
+	}
+
+	for _, tt := range tests {
+		if got := tt.err.Error(); got != tt.want {
+			t.Errorf("Error() = %q\nwant %q", got, tt.want)
+		}
+	}
+}
+
+func TestLayoutMultiWord(t *testing.T) {
+	l := NewLayout("wide", 8*len(Packed128{}))
+	l.Reserve("head", 60)
+	straddle := l.AddUInt("straddle", 1000) // 60-69: пересекает границу слов
+	signed := l.AddInt("signed", -500, 500) // 70-79
+	flag := l.AddBool("flag")               // 80
+	l.Seek(127)
+	last := l.AddBool("last")
+	l.MustBuild()
+
+	if straddle.Start != 60 || straddle.End != 69 {
+		t.Errorf("straddle = [%d:%d], want [60:69]", straddle.Start, straddle.End)
+	}
+	if signed.Start != 70 || signed.End != 79 || flag.Position != 80 || last.Position != 127 {
+		t.Errorf("unexpected positions: signed=%v flag=%v last=%v", signed, flag, last)
+	}
+
+	var packed Packed128
+	if err := SetUIntFieldAs[uint16](packed[:], straddle, 1000); err != nil {
+		t.Fatalf("SetUIntFieldAs: %v", err)
+	}
+	if err := SetIntFieldAs[int16](packed[:], signed, -321); err != nil {
+		t.Fatalf("SetIntFieldAs: %v", err)
+	}
+	if err := SetBoolField(packed[:], last, true); err != nil {
+		t.Fatalf("SetBoolField: %v", err)
+	}
+
+	if got := GetUIntFieldAs[uint16](packed[:], straddle); got != 1000 {
+		t.Errorf("straddle = %d, want 1000", got)
+	}
+	if got := GetIntFieldAs[int16](packed[:], signed); got != -321 {
+		t.Errorf("signed = %d, want -321", got)
+	}
+	if GetBoolField(packed[:], flag) || !GetBoolField(packed[:], last) {
+		t.Error("flag/last mismatch")
+	}
+	if packed[15] != 0x80 {
+		t.Errorf("last byte = 0x%02X, want 0x80", packed[15])
+	}
+}
+
+func TestLayoutMultiWordOverflow(t *testing.T) {
+	l := NewLayout("wide", 96)
+	l.Reserve("head", 90)
+	l.AddUInt("tail", 255) // 90-97 не помещается в 96 бит
+
+	if !errors.Is(l.Err(), ErrLayoutOverflow) {
+		t.Errorf("Err() = %v, want overflow", l.Err())
+	}
+}
+
+func TestLayoutFieldNamesInErrors(t *testing.T) {
+	layout := NewLayout("stats", 64)
+	health := layout.AddUInt("health", 1000)
+	level := layout.AddBiasedUInt("level", 1, 10)
+	delta := layout.AddInt("delta", -4, 3)
+	speed := layout.AddFixed("speed", 0, 10, 0.5, RoundNearest)
+	slots := layout.AddArray("slots", 4, 7)
+	flag := layout.AddBool("flag")
+	layout.MustBuild()
+
+	if health.Name() != "health" || health.Schema() != "stats" || flag.Name() != "flag" {
+		t.Errorf("names = %q.%q, %q", health.Schema(), health.Name(), flag.Name())
+	}
+
+	var packed Packed64
+	short := packed[:1]
+	tests := []struct {
+		name  string
+		err   error
+		field string
+		kind  error
+	}{
+		{"uint overflow", SetUIntFieldAs(packed[:], health, uint32(1001)), "health", ErrValueOverflow},
+		{"uint underflow", SetUIntFieldAs(packed[:], level, uint8(0)), "level", ErrValueUnderflow},
+		{"int range", SetIntFieldAs(packed[:], delta, int8(5)), "delta", ErrValueOverflow},
+		{"fixed range", SetFixedField(packed[:], speed, 11), "speed", ErrFixedOutOfRange},
+		{"array index", slots.Set(packed[:], 4, 1), "slots", ErrIndexOutOfRange},
+		{"bool short slice", SetBoolField(short, flag, true), "flag", ErrFieldOutOfSlice},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var bpErr *Error
+			if !errors.As(tt.err, &bpErr) {
+				t.Fatalf("err = %v, want *Error", tt.err)
+			}
+			if bpErr.Field() != tt.field || bpErr.Schema() != "stats" {
+				t.Errorf("Field/Schema = %q/%q, want %q/stats", bpErr.Field(), bpErr.Schema(), tt.field)
+			}
+			if !errors.Is(tt.err, tt.kind) {
+				t.Errorf("errors.Is(%v, %v) = false", tt.err, tt.kind)
+			}
+			if want := "field stats." + tt.field + ": "; !strings.HasPrefix(tt.err.Error(), want) {
+				t.Errorf("Error() = %q, want prefix %q", tt.err.Error(), want)
+			}
+		})
+	}
+}
+
+func TestUnnamedFieldErrors(t *testing.T) {
+	_, err := MustNewUIntBitField(0, 3, 10).Update(0, 11)
+	var bpErr *Error
+	if !errors.As(err, &bpErr) || bpErr.Field() != "" {
+		t.Fatalf("err = %v, want unnamed *Error", err)
+	}
+	if strings.HasPrefix(err.Error(), "field ") {
+		t.Errorf("Error() = %q, unnamed field must not be prefixed", err.Error())
+	}
+
+	named := MustNewUIntBitField(0, 3, 10).Named("", "gold")
+	if _, err := named.Update(0, 11); !strings.HasPrefix(err.Error(), "field gold: ") {
+		t.Errorf("Error() = %q, want prefix %q", err.Error(), "field gold: ")
+	}
+}
@@ -0,0 +1,401 @@
+package bitpack
+
+import (
+	"fmt"
+	"math"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// =================  Marshal / Unmarshal ===================================
+// ======== Упаковка обычных структур по тегам bitpack ======================
+//
+// Позиции и диапазоны полей задаются тегами, сеттеры писать не нужно:
+//
+//	type Stats struct {
+//		NameSize uint8  `bitpack:"0:5,max=42"`
+//		Level    uint8  `bitpack:"6:9,min=1,max=10"`  // хранится level-1
+//		Delta    int8   `bitpack:"10:13"`             // [-8, 7] по ширине
+//		Type     uint8  `bitpack:"14:15,enum=0|1|2"`
+//		House    bool   `bitpack:"16"`
+//		Name     string // без тега — не упаковывается
+//	}
+//
+//	err := bitpack.Marshal(&stats, packed[:])
+//	err = bitpack.Unmarshal(packed[:], &stats)
+//
+// Marshal и Unmarshal работают с буфером LittleEndian, методы ByteOrder
+// с теми же именами — с буфером в заданном порядке:
+//
+//	err = bitpack.BigEndian.Marshal(&stats, packed[:])
+//
+// Формат тега: "start:end" или "pos" (один бит), затем опции через запятую:
+// min=N, max=N (uint и int), enum=A|B|C (беззнаковые). Без min/max диапазон
+// определяется шириной поля и типом Go. Тег "-" пропускает поле.
+//
+// Раскладка проверяется один раз на тип (кэшируется): пересечения,
+// ширина, соответствие диапазона типу Go. Marshal и Unmarshal проверяют все
+// значения до записи — при ошибке буфер и структура не меняются.
+
+// structCodec — проверенная раскладка типа структуры
+type structCodec struct {
+	schema *Schema
+	fields []structField
+}
+
+// structField — поле структуры и его битовое представление
+type structField struct {
+	index int                   // Номер поля в структуре (reflect)
+	field any                   // UIntBitField, IntBitField или BoolBitField
+	enum  *EnumBitField[uint64] // Список допустимых значений (nil — не перечисление)
+}
+
+// codecEntry — результат разбора типа (ошибка тоже кэшируется)
+type codecEntry struct {
+	codec *structCodec
+	err   error
+}
+
+var codecs sync.Map // reflect.Type → codecEntry
+
+// Marshal упаковывает теговые поля структуры (или указателя на неё) в packed
+func Marshal(v any, packed []byte) error {
+	return LittleEndian.Marshal(v, packed)
+}
+
+// Unmarshal распаковывает packed в теговые поля структуры по указателю v.
+// Значения вне диапазонов тегов отклоняются.
+func Unmarshal(packed []byte, v any) error {
+	return LittleEndian.Unmarshal(packed, v)
+}
+
+// Marshal упаковывает теговые поля структуры в packed с порядком байтов o
+func (o ByteOrder) Marshal(v any, packed []byte) error {
+	rv := reflect.ValueOf(v)
+	if rv.Kind() == reflect.Pointer && !rv.IsNil() {
+		rv = rv.Elem()
+	}
+	if !rv.IsValid() {
+		return newUnsupportedTypeError("nil", "want struct or pointer to struct")
+	}
+	codec, err := codecFor(rv.Type())
+	if err != nil {
+		return err
+	}
+	if err := codec.validatePacked(packed); err != nil {
+		return err
+	}
+
+	// Пишем в копию буфера: при ошибке исходный буфер не меняется
+	var scratch [MaxPackedBytes]byte
+	buf := scratch[:len(packed)]
+	copy(buf, packed)
+	for _, sf := range codec.fields {
+		if err := sf.set(o, buf, rv.Field(sf.index)); err != nil {
+			return err
+		}
+	}
+	copy(packed, buf)
+	return nil
+}
+
+// Unmarshal распаковывает packed с порядком байтов o в теговые поля структуры по указателю v
+func (o ByteOrder) Unmarshal(packed []byte, v any) error {
+	rv := reflect.ValueOf(v)
+	if rv.Kind() != reflect.Pointer || rv.IsNil() {
+		return newUnsupportedTypeError(fmt.Sprintf("%T", v), "want non-nil pointer to struct")
+	}
+	rv = rv.Elem()
+	codec, err := codecFor(rv.Type())
+	if err != nil {
+		return err
+	}
+	if err := codec.validatePacked(packed); err != nil {
+		return err
+	}
+	if err := codec.schema.WithOrder(o).Validate(packed); err != nil {
+		return err
+	}
+	for _, sf := range codec.fields {
+		if sf.enum != nil {
+			if value := GetEnumFieldOrderUnchecked(o, packed, *sf.enum); !sf.enum.Contains(value) {
+				return sf.enum.Field.label.annotate(newEnumValueUnknownError(value))
+			}
+		}
+	}
+
+	for _, sf := range codec.fields {
+		sf.get(o, packed, rv.Field(sf.index))
+	}
+	return nil
+}
+
+// SchemaOf возвращает описание теговых полей структуры (для Diff, Validate, BitMap)
+func SchemaOf(v any) (*Schema, error) {
+	t := reflect.TypeOf(v)
+	if t != nil && t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	codec, err := codecFor(t)
+	if err != nil {
+		return nil, err
+	}
+	return codec.schema, nil
+}
+
+// ------------- Разбор тегов --------------------------------
+
+// codecFor возвращает закэшированную раскладку типа, разбирая теги при первом обращении
+func codecFor(t reflect.Type) (*structCodec, error) {
+	if t == nil || t.Kind() != reflect.Struct {
+		return nil, newUnsupportedTypeError(fmt.Sprint(t), "want struct or pointer to struct")
+	}
+	if entry, ok := codecs.Load(t); ok {
+		return entry.(codecEntry).codec, entry.(codecEntry).err
+	}
+	codec, err := buildCodec(t)
+	entry, _ := codecs.LoadOrStore(t, codecEntry{codec: codec, err: err})
+	return entry.(codecEntry).codec, entry.(codecEntry).err
+}
+
+// fieldTag — разобранный тег поля
+type fieldTag struct {
+	raw            string
+	start, end     BitPosition
+	min, max       string
+	hasMin, hasMax bool
+	enumValues     []uint64
+	enumNames      []string
+
+	index  int          // Номер поля в структуре
+	name   string       // Имя поля в структуре
+	goType reflect.Type // Тип поля в структуре
+}
+
+func buildCodec(t reflect.Type) (*structCodec, error) {
+	schemaName := t.String()
+	var tags []fieldTag
+	bits := 0
+	for i := range t.NumField() {
+		sf := t.Field(i)
+		raw, ok := sf.Tag.Lookup("bitpack")
+		if !ok || raw == "-" {
+			continue
+		}
+		label := newFieldLabel(schemaName, sf.Name)
+		if !sf.IsExported() {
+			return nil, label.annotate(newUnsupportedTypeError(sf.Type.String(), "unexported field"))
+		}
+		tag, err := parseFieldTag(raw)
+		if err != nil {
+			return nil, label.annotate(err)
+		}
+		tag.index, tag.name, tag.goType = i, sf.Name, sf.Type
+		tags = append(tags, tag)
+		bits = max(bits, int(tag.end)+1)
+	}
+	if len(tags) == 0 {
+		return nil, newUnsupportedTypeError(schemaName, "no fields with bitpack tag")
+	}
+
+	layout := NewLayout(schemaName, bits)
+	codec := &structCodec{}
+	for _, tag := range tags {
+		label := newFieldLabel(schemaName, tag.name)
+		layout.Seek(tag.start)
+		if _, _, ok := layout.place(tag.name, int(tag.end-tag.start)+1); !ok {
+			return nil, layout.Err()
+		}
+		sf, err := newStructField(tag)
+		if err != nil {
+			return nil, label.annotate(err)
+		}
+		switch f := sf.field.(type) {
+		case UIntBitField:
+			sf.field = attach(layout, f.Named(schemaName, tag.name))
+			if sf.enum != nil {
+				sf.enum.Field = sf.field.(UIntBitField)
+			}
+		case IntBitField:
+			sf.field = attach(layout, f.Named(schemaName, tag.name))
+		case BoolBitField:
+			sf.field = attach(layout, f.Named(schemaName, tag.name))
+		}
+		codec.fields = append(codec.fields, sf)
+	}
+
+	schema, err := layout.Schema()
+	if err != nil {
+		return nil, err
+	}
+	codec.schema = schema
+	return codec, nil
+}
+
+// parseFieldTag разбирает "start:end,min=N,max=N,enum=A|B"
+func parseFieldTag(raw string) (fieldTag, error) {
+	tag := fieldTag{raw: raw}
+	parts := strings.Split(raw, ",")
+
+	startText, endText, isRange := strings.Cut(parts[0], ":")
+	if !isRange {
+		endText = startText
+	}
+	start, errStart := strconv.ParseUint(strings.TrimSpace(startText), 10, 8)
+	end, errEnd := strconv.ParseUint(strings.TrimSpace(endText), 10, 8)
+	if errStart != nil || errEnd != nil {
+		return tag, newInvalidTagError(raw, "bit position must be \"start:end\" or \"pos\" in 0..255")
+	}
+	if start > end || end-start >= 64 {
+		return tag, newInvalidTagError(raw, "want start <= end and width up to 64 bits")
+	}
+	tag.start, tag.end = BitPosition(start), BitPosition(end)
+
+	for _, option := range parts[1:] {
+		key, value, _ := strings.Cut(strings.TrimSpace(option), "=")
+		switch key {
+		case "min":
+			tag.min, tag.hasMin = value, true
+		case "max":
+			tag.max, tag.hasMax = value, true
+		case "enum":
+			for _, name := range strings.Split(value, "|") {
+				v, err := strconv.ParseUint(name, 10, 64)
+				if err != nil {
+					return tag, newInvalidTagError(raw, fmt.Sprintf("enum value %q is not an unsigned integer", name))
+				}
+				tag.enumValues = append(tag.enumValues, v)
+				tag.enumNames = append(tag.enumNames, name)
+			}
+		default:
+			return tag, newInvalidTagError(raw, fmt.Sprintf("unknown option %q", key))
+		}
+	}
+	return tag, nil
+}
+
+// newStructField строит битовое поле по тегу и типу Go
+func newStructField(tag fieldTag) (structField, error) {
+	sf := structField{index: tag.index}
+	width := uint8(tag.end - tag.start + 1)
+	raw, goType := tag.raw, tag.goType
+
+	switch goType.Kind() {
+	case reflect.Bool:
+		if width != 1 || tag.hasMin || tag.hasMax || tag.enumValues != nil {
+			return sf, newInvalidTagError(raw, "bool field takes a single bit and no options")
+		}
+		sf.field = newBoolBitField(tag.start)
+
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		typeMax := uint64(math.MaxUint64) >> (64 - goType.Bits())
+		lo, hi := uint64(0), min(maxAllowedForWidth(width), typeMax)
+		if tag.enumValues != nil {
+			if tag.hasMin || tag.hasMax {
+				return sf, newInvalidTagError(raw, "enum cannot be combined with min/max")
+			}
+			hi = 0
+			for _, v := range tag.enumValues {
+				hi = max(hi, v)
+			}
+		}
+		var err error
+		if tag.hasMin {
+			if lo, err = strconv.ParseUint(tag.min, 10, 64); err != nil {
+				return sf, newInvalidTagError(raw, fmt.Sprintf("min %q is not an unsigned integer", tag.min))
+			}
+			hi = typeMax
+			if span := maxAllowedForWidth(width); lo <= typeMax-span {
+				hi = lo + span
+			}
+		}
+		if tag.hasMax {
+			if hi, err = strconv.ParseUint(tag.max, 10, 64); err != nil {
+				return sf, newInvalidTagError(raw, fmt.Sprintf("max %q is not an unsigned integer", tag.max))
+			}
+		}
+		if hi > typeMax {
+			return sf, newInvalidTagError(raw, fmt.Sprintf("max %d does not fit %s", hi, goType))
+		}
+		field, err := newUIntBitField(tag.start, tag.end, lo, hi)
+		if err != nil {
+			return sf, err
+		}
+		sf.field = field
+		if tag.enumValues != nil {
+			enum, err := NewEnumBitField(field, tag.enumValues, tag.enumNames)
+			if err != nil {
+				return sf, err
+			}
+			sf.enum = &enum
+		}
+
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		if tag.enumValues != nil {
+			return sf, newInvalidTagError(raw, "enum requires an unsigned Go type")
+		}
+		typeMin, typeMax := intRangeForWidth(uint8(goType.Bits()))
+		lo, hi := intRangeForWidth(width)
+		lo, hi = max(lo, typeMin), min(hi, typeMax)
+		var err error
+		if tag.hasMin {
+			if lo, err = strconv.ParseInt(tag.min, 10, 64); err != nil {
+				return sf, newInvalidTagError(raw, fmt.Sprintf("min %q is not an integer", tag.min))
+			}
+		}
+		if tag.hasMax {
+			if hi, err = strconv.ParseInt(tag.max, 10, 64); err != nil {
+				return sf, newInvalidTagError(raw, fmt.Sprintf("max %q is not an integer", tag.max))
+			}
+		}
+		if lo < typeMin || hi > typeMax {
+			return sf, newInvalidTagError(raw, fmt.Sprintf("range [%d, %d] does not fit %s", lo, hi, goType))
+		}
+		field, err := newIntBitField(tag.start, tag.end, lo, hi)
+		if err != nil {
+			return sf, err
+		}
+		sf.field = field
+
+	default:
+		return sf, newUnsupportedTypeError(goType.String(), "want bool, signed or unsigned integer")
+	}
+	return sf, nil
+}
+
+// ------------- Чтение и запись полей --------------------------------
+
+// validatePacked проверяет, что буфер вмещает все поля структуры
+func (c *structCodec) validatePacked(packed []byte) error {
+	return validatePacked(packed, BitPosition(c.schema.Bits()-1))
+}
+
+// set записывает значение поля структуры с проверкой диапазона
+func (sf structField) set(o ByteOrder, packed []byte, value reflect.Value) error {
+	if sf.enum != nil {
+		return SetEnumFieldOrder(o, packed, *sf.enum, value.Uint())
+	}
+	switch f := sf.field.(type) {
+	case UIntBitField:
+		return o.SetUInt(packed, f, value.Uint())
+	case IntBitField:
+		return o.SetInt(packed, f, value.Int())
+	case BoolBitField:
+		return o.SetBool(packed, f, value.Bool())
+	}
+	return nil
+}
+
+// get читает значение поля без проверок (буфер уже проверен)
+func (sf structField) get(o ByteOrder, packed []byte, value reflect.Value) {
+	switch f := sf.field.(type) {
+	case UIntBitField:
+		value.SetUint(o.GetUIntUnchecked(packed, f))
+	case IntBitField:
+		value.SetInt(o.GetIntUnchecked(packed, f))
+	case BoolBitField:
+		value.SetBool(o.GetBoolUnchecked(packed, f))
+	}
+}
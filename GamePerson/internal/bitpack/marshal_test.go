@@ -0,0 +1,198 @@
+package bitpack
+
+import (
+	"errors"
+	"testing"
+)
+
+// ============ Тесты для Marshal / Unmarshal ============
+
+type taggedStats struct {
+	NameSize uint8  `bitpack:"0:5,max=42"`
+	Level    uint16 `bitpack:"6:9,min=1,max=10"`
+	Delta    int8   `bitpack:"10:13"`
+	Type     uint8  `bitpack:"14:15,enum=0|1|3"`
+	House    bool   `bitpack:"16"`
+	Gold     uint64 `bitpack:"60:79"` // пересекает границу 64 бит
+	Name     string
+	Skipped  uint8 `bitpack:"-"`
+}
+
+func TestMarshalRoundTrip(t *testing.T) {
+	in := taggedStats{NameSize: 42, Level: 10, Delta: -8, Type: 3, House: true, Gold: 1<<20 - 1, Name: "Bob", Skipped: 7}
+	var packed Packed96
+	if err := Marshal(&in, packed[:]); err != nil {
+		t.Fatalf("Marshal: %v", err)
+	}
+
+	// Поля лежат по позициям тегов, Level хранится со смещением
+	if got := readBits(packed[:], 0, 6); got != 42 {
+		t.Errorf("NameSize bits = %d, want 42", got)
+	}
+	if got := readBits(packed[:], 6, 4); got != 9 {
+		t.Errorf("Level bits = %d, want 9 (10 - min)", got)
+	}
+	if got := readBits(packed[:], 16, 1); got != 1 {
+		t.Errorf("House bit = %d, want 1", got)
+	}
+
+	out := taggedStats{Name: "keep"}
+	if err := Unmarshal(packed[:], &out); err != nil {
+		t.Fatalf("Unmarshal: %v", err)
+	}
+	want := in
+	want.Name, want.Skipped = "keep", 0
+	if out != want {
+		t.Errorf("Unmarshal() = %+v, want %+v", out, want)
+	}
+
+	// Marshal принимает и значение структуры
+	var byValue Packed96
+	if err := Marshal(in, byValue[:]); err != nil || byValue != packed {
+		t.Errorf("Marshal(value) = %x, %v, want %x", byValue, err, packed)
+	}
+}
+
+func TestMarshalRejectsValues(t *testing.T) {
+	valid := taggedStats{Level: 1}
+	tests := []struct {
+		name   string
+		mutate func(*taggedStats)
+		field  string
+		want   error
+	}{
+		{"max", func(s *taggedStats) { s.NameSize = 43 }, "NameSize", ErrValueOverflow},
+		{"biased min", func(s *taggedStats) { s.Level = 0 }, "Level", ErrValueUnderflow},
+		{"int width", func(s *taggedStats) { s.Delta = 8 }, "Delta", ErrValueOverflow},
+		{"enum", func(s *taggedStats) { s.Type = 2 }, "Type", ErrEnumValueUnknown},
+		{"width", func(s *taggedStats) { s.Gold = 1 << 20 }, "Gold", ErrValueOverflow},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var packed Packed96
+			packed[0] = 0xAA
+			v := valid
+			tt.mutate(&v)
+			err := Marshal(&v, packed[:])
+			if !errors.Is(err, tt.want) {
+				t.Fatalf("err = %v, want %v", err, tt.want)
+			}
+			var bpErr *Error
+			if !errors.As(err, &bpErr) || bpErr.Field() != tt.field || bpErr.Schema() != "bitpack.taggedStats" {
+				t.Errorf("err = %v, want field bitpack.taggedStats.%s", err, tt.field)
+			}
+			if packed != (Packed96{0xAA}) {
+				t.Errorf("failed Marshal changed buffer: %x", packed)
+			}
+		})
+	}
+
+	var short Packed16
+	if err := Marshal(&valid, short[:]); !errors.Is(err, ErrFieldOutOfSlice) {
+		t.Errorf("Marshal(short) err = %v, want field out of slice", err)
+	}
+}
+
+func TestUnmarshalRejectsValues(t *testing.T) {
+	tests := []struct {
+		name  string
+		start BitPosition
+		width uint8
+		raw   uint64
+		want  error
+	}{
+		{"max", 0, 6, 43, ErrValueOverflow},
+		{"biased max", 6, 4, 10, ErrValueOverflow}, // 10 + min = 11
+		{"enum", 14, 2, 2, ErrEnumValueUnknown},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var packed Packed96
+			writeBits(packed[:], tt.start, tt.width, tt.raw)
+			out := taggedStats{NameSize: 1}
+			if err := Unmarshal(packed[:], &out); !errors.Is(err, tt.want) {
+				t.Errorf("err = %v, want %v", err, tt.want)
+			}
+			if out != (taggedStats{NameSize: 1}) {
+				t.Errorf("failed Unmarshal changed struct: %+v", out)
+			}
+		})
+	}
+}
+
+func TestMarshalInvalidTypes(t *testing.T) {
+	type overlap struct {
+		A uint8 `bitpack:"0:3"`
+		B uint8 `bitpack:"3:5"`
+	}
+	type badSyntax struct {
+		A uint8 `bitpack:"0-3"`
+	}
+	type unknownOption struct {
+		A uint8 `bitpack:"0:3,step=2"`
+	}
+	type tooWideForType struct {
+		A uint8 `bitpack:"0:9,max=300"`
+	}
+	type intEnum struct {
+		A int8 `bitpack:"0:3,enum=1|2"`
+	}
+	type wideBool struct {
+		A bool `bitpack:"0:1"`
+	}
+	type floatField struct {
+		A float64 `bitpack:"0:7"`
+	}
+	type noTags struct {
+		A uint8
+	}
+
+	tests := []struct {
+		name string
+		v    any
+		want error
+	}{
+		{"overlap", &overlap{}, ErrFieldOverlap},
+		{"syntax", &badSyntax{}, ErrInvalidTag},
+		{"unknown option", &unknownOption{}, ErrInvalidTag},
+		{"max exceeds Go type", &tooWideForType{}, ErrInvalidTag},
+		{"signed enum", &intEnum{}, ErrInvalidTag},
+		{"wide bool", &wideBool{}, ErrInvalidTag},
+		{"float", &floatField{}, ErrUnsupportedType},
+		{"no tags", &noTags{}, ErrUnsupportedType},
+		{"not a struct", new(int), ErrUnsupportedType},
+		{"nil", nil, ErrUnsupportedType},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var packed Packed64
+			if err := Marshal(tt.v, packed[:]); !errors.Is(err, tt.want) {
+				t.Errorf("Marshal err = %v, want %v", err, tt.want)
+			}
+		})
+	}
+
+	// Ошибка разбора кэшируется и возвращается повторно
+	var packed Packed64
+	first, second := Marshal(&overlap{}, packed[:]), Marshal(&overlap{}, packed[:])
+	if first != second {
+		t.Errorf("cached error differs: %v vs %v", first, second)
+	}
+	if err := Unmarshal(packed[:], taggedStats{}); !errors.Is(err, ErrUnsupportedType) {
+		t.Errorf("Unmarshal(non-pointer) err = %v, want unsupported type", err)
+	}
+}
+
+func TestSchemaOf(t *testing.T) {
+	schema, err := SchemaOf(taggedStats{})
+	if err != nil {
+		t.Fatalf("SchemaOf: %v", err)
+	}
+	if schema.Bits() != 80 || len(schema.Fields()) != 6 {
+		t.Errorf("SchemaOf() = %v, want 80 bits, 6 fields", schema)
+	}
+	level, ok := schema.Field("Level")
+	if !ok || level.Min != 1 || level.Max != 10 || level.Start != 6 || level.End != 9 {
+		t.Errorf("Level = %v, want [6:9] range [1, 10]", level)
+	}
+}
@@ -0,0 +1,467 @@
+package bitpack
+
+import (
+	"fmt"
+	"math"
+)
+
+// =================  Optional поля =========================================
+// ======== Значение + бит присутствия ======================================
+//
+// Упакованное поле не отличает «мана 0» от «мана не задана». Optional поле
+// сопровождает значение битом присутствия Present:
+//
+//	mana := layout.AddOptionalUInt("mana", 1000) // 10 бит значения + 1 бит присутствия
+//	bits, _ = mana.Update(bits, 0)               // задано: 0
+//	v, ok := mana.GetOptional(bits)              // 0, true
+//	bits = mana.Clear(bits)                      // не задано: 0, false
+//
+// Запись значения (checked или unchecked) отмечает его присутствие.
+// Clear сбрасывает бит присутствия и обнуляет биты значения, поэтому у
+// незаданного поля в буфере всегда нули. Бит присутствия может лежать
+// отдельно от значения (например, в резервном бите существующей схемы).
+//
+// Nullable поле обходится без бита присутствия: признак хранится в самом
+// коде значения (0 — не задано, v+1 — значение v). Поле [0, max] занимает
+// ширину для max+1 и часто не требует лишнего бита: мана 0-1000 по-прежнему
+// помещается в 10 бит (коды 0-1001).
+//
+//	mana := layout.AddNullableUInt("mana", 1000) // 10 бит, без бита присутствия
+//	bits, _ = mana.Update(bits, 0)               // код 1
+//	v, ok := mana.GetOptional(bits)              // 0, true
+
+type OptionalUIntBitField struct {
+	Value   UIntBitField // Значение поля
+	Present BoolBitField // Бит присутствия: 1 — значение задано
+}
+
+type OptionalIntBitField struct {
+	Value   IntBitField  // Значение поля
+	Present BoolBitField // Бит присутствия: 1 — значение задано
+}
+
+// NewOptionalUIntBitField объединяет поле значения и бит присутствия.
+// Бит присутствия не должен попадать в биты значения.
+func NewOptionalUIntBitField(value UIntBitField, present BoolBitField) (OptionalUIntBitField, error) {
+	if err := checkPresenceBit(value.Start, value.End, present); err != nil {
+		return OptionalUIntBitField{}, err
+	}
+	return OptionalUIntBitField{Value: value, Present: present}, nil
+}
+
+// NewOptionalIntBitField объединяет знаковое поле значения и бит присутствия
+func NewOptionalIntBitField(value IntBitField, present BoolBitField) (OptionalIntBitField, error) {
+	if err := checkPresenceBit(value.Start, value.End, present); err != nil {
+		return OptionalIntBitField{}, err
+	}
+	return OptionalIntBitField{Value: value, Present: present}, nil
+}
+
+// MustNewOptionalUIntBitField создаёт optional поле или паникует при ошибке конфигурации
+// Используется ТОЛЬКО для статических конфигураций
+
+func MustNewOptionalUIntBitField(value UIntBitField, present BoolBitField) OptionalUIntBitField {
+	bf, err := NewOptionalUIntBitField(value, present)
+	if err != nil {
+		panic(fmt.Sprintf("FATAL: invalid static optional bit field configuration [value=%v, present=%d]: %v",
+			value, present.Position, err))
+	}
+	return bf
+}
+
+// MustNewOptionalIntBitField создаёт optional поле или паникует при ошибке конфигурации
+// Используется ТОЛЬКО для статических конфигураций
+
+func MustNewOptionalIntBitField(value IntBitField, present BoolBitField) OptionalIntBitField {
+	bf, err := NewOptionalIntBitField(value, present)
+	if err != nil {
+		panic(fmt.Sprintf("FATAL: invalid static optional bit field configuration [value=%v, present=%d]: %v",
+			value, present.Position, err))
+	}
+	return bf
+}
+
+// ==================== OptionalUIntBitField ====================
+
+// GetOptional возвращает значение и признак присутствия; незаданное поле — (0, false)
+func (bf OptionalUIntBitField) GetOptional(bitSet BitSet64) (uint64, bool) {
+	if !bf.Present.Get(bitSet) {
+		return 0, false
+	}
+	return bf.Value.Get(bitSet), true
+}
+
+// IsSet сообщает, задано ли значение
+func (bf OptionalUIntBitField) IsSet(bitSet BitSet64) bool {
+	return bf.Present.Get(bitSet)
+}
+
+// Update записывает значение с проверкой диапазона и отмечает присутствие
+func (bf OptionalUIntBitField) Update(bitSet BitSet64, value uint64) (BitSet64, error) {
+	bits, err := bf.Value.Update(bitSet, value)
+	if err != nil {
+		return bitSet, err
+	}
+	return bf.Present.Set(bits), nil
+}
+
+func (bf OptionalUIntBitField) UpdateUnchecked(bitSet BitSet64, value uint64) BitSet64 {
+	return bf.Present.Set(bf.Value.UpdateUnchecked(bitSet, value))
+}
+
+// Clear сбрасывает присутствие и обнуляет биты значения
+func (bf OptionalUIntBitField) Clear(bitSet BitSet64) BitSet64 {
+	return bf.Present.Clear(bf.Value.UpdateUnchecked(bitSet, bf.Value.Min))
+}
+
+// Строковое представление для отладки
+func (bf OptionalUIntBitField) String() string {
+	return fmt.Sprintf("Optional%v present=%d", bf.Value, bf.Present.Position)
+}
+
+// ==================== OptionalIntBitField ====================
+
+// GetOptional возвращает значение и признак присутствия; незаданное поле — (0, false)
+func (bf OptionalIntBitField) GetOptional(bitSet BitSet64) (int64, bool) {
+	if !bf.Present.Get(bitSet) {
+		return 0, false
+	}
+	return bf.Value.Get(bitSet), true
+}
+
+// IsSet сообщает, задано ли значение
+func (bf OptionalIntBitField) IsSet(bitSet BitSet64) bool {
+	return bf.Present.Get(bitSet)
+}
+
+// Update записывает значение с проверкой диапазона и отмечает присутствие
+func (bf OptionalIntBitField) Update(bitSet BitSet64, value int64) (BitSet64, error) {
+	bits, err := bf.Value.Update(bitSet, value)
+	if err != nil {
+		return bitSet, err
+	}
+	return bf.Present.Set(bits), nil
+}
+
+func (bf OptionalIntBitField) UpdateUnchecked(bitSet BitSet64, value int64) BitSet64 {
+	return bf.Present.Set(bf.Value.UpdateUnchecked(bitSet, value))
+}
+
+// Clear сбрасывает присутствие и обнуляет биты значения
+func (bf OptionalIntBitField) Clear(bitSet BitSet64) BitSet64 {
+	return bf.Present.Clear(bf.Value.UpdateUnchecked(bitSet, 0))
+}
+
+// Строковое представление для отладки
+func (bf OptionalIntBitField) String() string {
+	return fmt.Sprintf("Optional%v present=%d", bf.Value, bf.Present.Position)
+}
+
+// ==================== NullableUIntBitField ====================
+
+type NullableUIntBitField struct {
+	Code UIntBitField // Код значения: 0 — не задано, v+1 — значение v
+	Max  uint64       // Максимальное значение
+}
+
+// NewNullableUIntBitField создаёт nullable поле для значений [0, max] в битах [start, end].
+// Ширина поля должна вмещать код max+1.
+func NewNullableUIntBitField(start, end BitPosition, max uint64) (NullableUIntBitField, error) {
+	if start > end {
+		return NullableUIntBitField{}, newStartAfterEndError(start, end)
+	}
+	if end >= 64 {
+		return NullableUIntBitField{}, newEndOutOfRangeError(end)
+	}
+	return newNullableUIntBitField(start, end, max)
+}
+
+// newNullableUIntBitField строит поле без ограничения end < 64 (для многословных буферов)
+func newNullableUIntBitField(start, end BitPosition, max uint64) (NullableUIntBitField, error) {
+	if max == math.MaxUint64 {
+		return NullableUIntBitField{}, newValueOverflowError(max, max-1, 64)
+	}
+	code, err := newUIntBitField(start, end, 0, max+1)
+	if err != nil {
+		return NullableUIntBitField{}, err
+	}
+	return NullableUIntBitField{Code: code, Max: max}, nil
+}
+
+// MustNewNullableUIntBitField создаёт nullable поле или паникует при ошибке конфигурации
+// Используется ТОЛЬКО для статических конфигураций
+
+func MustNewNullableUIntBitField(start, end BitPosition, max uint64) NullableUIntBitField {
+	bf, err := NewNullableUIntBitField(start, end, max)
+	if err != nil {
+		panic(fmt.Sprintf("FATAL: invalid static nullable bit field configuration [start=%d, end=%d, max=%d]: %v",
+			start, end, max, err))
+	}
+	return bf
+}
+
+// GetOptional возвращает значение и признак присутствия; незаданное поле — (0, false)
+func (bf NullableUIntBitField) GetOptional(bitSet BitSet64) (uint64, bool) {
+	return decodeNullable(bf.Code.Get(bitSet))
+}
+
+// IsSet сообщает, задано ли значение
+func (bf NullableUIntBitField) IsSet(bitSet BitSet64) bool {
+	return bf.Code.Get(bitSet) != 0
+}
+
+// Update записывает значение с проверкой диапазона [0, Max]
+func (bf NullableUIntBitField) Update(bitSet BitSet64, value uint64) (BitSet64, error) {
+	if err := bf.checkValue(value); err != nil {
+		return bitSet, err
+	}
+	return bf.UpdateUnchecked(bitSet, value), nil
+}
+
+func (bf NullableUIntBitField) UpdateUnchecked(bitSet BitSet64, value uint64) BitSet64 {
+	return bf.Code.UpdateUnchecked(bitSet, value+1)
+}
+
+// Clear записывает код 0 («не задано»)
+func (bf NullableUIntBitField) Clear(bitSet BitSet64) BitSet64 {
+	return bf.Code.UpdateUnchecked(bitSet, 0)
+}
+
+// Named возвращает копию поля с именем: ошибки, полученные через него, содержат schema.name
+func (bf NullableUIntBitField) Named(schema, name string) NullableUIntBitField {
+	bf.Code = bf.Code.Named(schema, name)
+	return bf
+}
+
+// Строковое представление для отладки
+func (bf NullableUIntBitField) String() string {
+	return fmt.Sprintf("NullableUIntBitField[%d:%d] max=%d", bf.Code.Start, bf.Code.End, bf.Max)
+}
+
+// ==================== Функции над срезом ====================
+
+func GetOptionalUIntFieldAs[T UnsignedInteger](packed []byte, field OptionalUIntBitField) (T, bool) {
+	if !GetBoolField(packed, field.Present) {
+		return 0, false
+	}
+	return GetUIntFieldAs[T](packed, field.Value), true
+}
+
+func SetOptionalUIntFieldAs[T UnsignedInteger](packed []byte, field OptionalUIntBitField, value T) error {
+	if err := validatePacked(packed, field.Present.Position); err != nil {
+		return field.Value.label.annotate(err)
+	}
+	if err := SetUIntFieldAs(packed, field.Value, value); err != nil {
+		return err
+	}
+	SetBoolFieldUnchecked(packed, field.Present, true)
+	return nil
+}
+
+func SetOptionalUIntFieldUncheckedAs[T UnsignedInteger](packed []byte, field OptionalUIntBitField, value T) {
+	SetUIntFieldUncheckedAs(packed, field.Value, value)
+	SetBoolFieldUnchecked(packed, field.Present, true)
+}
+
+// ClearOptionalUIntField сбрасывает присутствие и обнуляет биты значения
+func ClearOptionalUIntField(packed []byte, field OptionalUIntBitField) {
+	SetUIntFieldUncheckedAs(packed, field.Value, field.Value.Min)
+	SetBoolFieldUnchecked(packed, field.Present, false)
+}
+
+func GetOptionalIntFieldAs[T SignedInteger](packed []byte, field OptionalIntBitField) (T, bool) {
+	if !GetBoolField(packed, field.Present) {
+		return 0, false
+	}
+	return GetIntFieldAs[T](packed, field.Value), true
+}
+
+func SetOptionalIntFieldAs[T SignedInteger](packed []byte, field OptionalIntBitField, value T) error {
+	if err := validatePacked(packed, field.Present.Position); err != nil {
+		return field.Value.label.annotate(err)
+	}
+	if err := SetIntFieldAs(packed, field.Value, value); err != nil {
+		return err
+	}
+	SetBoolFieldUnchecked(packed, field.Present, true)
+	return nil
+}
+
+func SetOptionalIntFieldUncheckedAs[T SignedInteger](packed []byte, field OptionalIntBitField, value T) {
+	SetIntFieldUncheckedAs(packed, field.Value, value)
+	SetBoolFieldUnchecked(packed, field.Present, true)
+}
+
+// ClearOptionalIntField сбрасывает присутствие и обнуляет биты значения
+func ClearOptionalIntField(packed []byte, field OptionalIntBitField) {
+	SetIntFieldUncheckedAs[int64](packed, field.Value, 0)
+	SetBoolFieldUnchecked(packed, field.Present, false)
+}
+
+func GetNullableUIntFieldAs[T UnsignedInteger](packed []byte, field NullableUIntBitField) (T, bool) {
+	value, ok := decodeNullable(GetUIntFieldAs[uint64](packed, field.Code))
+	return T(value), ok
+}
+
+func SetNullableUIntFieldAs[T UnsignedInteger](packed []byte, field NullableUIntBitField, value T) error {
+	if err := validatePacked(packed, field.Code.End); err != nil {
+		return field.Code.label.annotate(err)
+	}
+	if err := field.checkValue(uint64(value)); err != nil {
+		return err
+	}
+	SetNullableUIntFieldUncheckedAs(packed, field, value)
+	return nil
+}
+
+func SetNullableUIntFieldUncheckedAs[T UnsignedInteger](packed []byte, field NullableUIntBitField, value T) {
+	SetUIntFieldUncheckedAs(packed, field.Code, uint64(value)+1)
+}
+
+// ClearNullableUIntField записывает код 0 («не задано»)
+func ClearNullableUIntField(packed []byte, field NullableUIntBitField) {
+	SetUIntFieldUncheckedAs[uint64](packed, field.Code, 0)
+}
+
+// ==================== Заданный порядок байтов ====================
+// Checked Get проверяет буфер, checked Set — буфер и диапазон значения.
+
+func (o ByteOrder) GetOptionalUInt(packed []byte, field OptionalUIntBitField) (uint64, bool, error) {
+	if err := validatePacked(packed, max(field.Value.End, field.Present.Position)); err != nil {
+		return 0, false, field.Value.label.annotate(err)
+	}
+	value, ok := o.GetOptionalUIntUnchecked(packed, field)
+	return value, ok, nil
+}
+
+func (o ByteOrder) SetOptionalUInt(packed []byte, field OptionalUIntBitField, value uint64) error {
+	if err := validatePacked(packed, field.Present.Position); err != nil {
+		return field.Value.label.annotate(err)
+	}
+	if err := o.SetUInt(packed, field.Value, value); err != nil {
+		return err
+	}
+	o.SetBoolUnchecked(packed, field.Present, true)
+	return nil
+}
+
+func (o ByteOrder) GetOptionalUIntUnchecked(packed []byte, field OptionalUIntBitField) (uint64, bool) {
+	if !o.GetBoolUnchecked(packed, field.Present) {
+		return 0, false
+	}
+	return o.GetUIntUnchecked(packed, field.Value), true
+}
+
+func (o ByteOrder) SetOptionalUIntUnchecked(packed []byte, field OptionalUIntBitField, value uint64) {
+	o.SetUIntUnchecked(packed, field.Value, value)
+	o.SetBoolUnchecked(packed, field.Present, true)
+}
+
+// ClearOptionalUInt сбрасывает присутствие и обнуляет биты значения
+func (o ByteOrder) ClearOptionalUInt(packed []byte, field OptionalUIntBitField) {
+	o.SetUIntUnchecked(packed, field.Value, field.Value.Min)
+	o.SetBoolUnchecked(packed, field.Present, false)
+}
+
+func (o ByteOrder) GetOptionalInt(packed []byte, field OptionalIntBitField) (int64, bool, error) {
+	if err := validatePacked(packed, max(field.Value.End, field.Present.Position)); err != nil {
+		return 0, false, field.Value.label.annotate(err)
+	}
+	value, ok := o.GetOptionalIntUnchecked(packed, field)
+	return value, ok, nil
+}
+
+func (o ByteOrder) SetOptionalInt(packed []byte, field OptionalIntBitField, value int64) error {
+	if err := validatePacked(packed, field.Present.Position); err != nil {
+		return field.Value.label.annotate(err)
+	}
+	if err := o.SetInt(packed, field.Value, value); err != nil {
+		return err
+	}
+	o.SetBoolUnchecked(packed, field.Present, true)
+	return nil
+}
+
+func (o ByteOrder) GetOptionalIntUnchecked(packed []byte, field OptionalIntBitField) (int64, bool) {
+	if !o.GetBoolUnchecked(packed, field.Present) {
+		return 0, false
+	}
+	return o.GetIntUnchecked(packed, field.Value), true
+}
+
+func (o ByteOrder) SetOptionalIntUnchecked(packed []byte, field OptionalIntBitField, value int64) {
+	o.SetIntUnchecked(packed, field.Value, value)
+	o.SetBoolUnchecked(packed, field.Present, true)
+}
+
+// ClearOptionalInt сбрасывает присутствие и обнуляет биты значения
+func (o ByteOrder) ClearOptionalInt(packed []byte, field OptionalIntBitField) {
+	o.SetIntUnchecked(packed, field.Value, 0)
+	o.SetBoolUnchecked(packed, field.Present, false)
+}
+
+func (o ByteOrder) GetNullableUInt(packed []byte, field NullableUIntBitField) (uint64, bool, error) {
+	code, err := o.GetUInt(packed, field.Code)
+	if err != nil {
+		return 0, false, err
+	}
+	value, ok := decodeNullable(code)
+	return value, ok, nil
+}
+
+func (o ByteOrder) SetNullableUInt(packed []byte, field NullableUIntBitField, value uint64) error {
+	if err := validatePacked(packed, field.Code.End); err != nil {
+		return field.Code.label.annotate(err)
+	}
+	if err := field.checkValue(value); err != nil {
+		return err
+	}
+	o.SetNullableUIntUnchecked(packed, field, value)
+	return nil
+}
+
+func (o ByteOrder) GetNullableUIntUnchecked(packed []byte, field NullableUIntBitField) (uint64, bool) {
+	return decodeNullable(o.GetUIntUnchecked(packed, field.Code))
+}
+
+func (o ByteOrder) SetNullableUIntUnchecked(packed []byte, field NullableUIntBitField, value uint64) {
+	o.SetUIntUnchecked(packed, field.Code, value+1)
+}
+
+// ClearNullableUInt записывает код 0 («не задано»)
+func (o ByteOrder) ClearNullableUInt(packed []byte, field NullableUIntBitField) {
+	o.SetUIntUnchecked(packed, field.Code, 0)
+}
+
+// ------------- Сервисные методы --------------------------------
+
+// decodeNullable переводит код nullable поля в значение и признак присутствия
+func decodeNullable(code uint64) (uint64, bool) {
+	if code == 0 {
+		return 0, false
+	}
+	return code - 1, true
+}
+
+// checkValue проверяет диапазон значения nullable поля
+func (bf NullableUIntBitField) checkValue(value uint64) error {
+	if value > bf.Max {
+		return bf.Code.label.annotate(newValueOverflowError(value, bf.Max, bf.Code.Width()))
+	}
+	return nil
+}
+
+// valueField — поле с диапазоном значений [0, Max] для проверок арифметики
+func (bf NullableUIntBitField) valueField() UIntBitField {
+	value := bf.Code
+	value.Max = bf.Max
+	return value
+}
+
+// checkPresenceBit проверяет, что бит присутствия не пересекается со значением
+func checkPresenceBit(start, end BitPosition, present BoolBitField) error {
+	if present.Position >= start && present.Position <= end {
+		return newFieldOverlapError("present", present.Position, present.Position, "value")
+	}
+	return nil
+}
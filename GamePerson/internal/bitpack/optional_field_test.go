@@ -0,0 +1,281 @@
+package bitpack
+
+import (
+	"errors"
+	"testing"
+)
+
+// ============ Тесты для Optional полей ============
+
+func TestOptionalUIntBitField(t *testing.T) {
+	mana := MustNewOptionalUIntBitField(MustNewUIntBitField(0, 9, 1000), MustNewBoolBitField(15))
+	bits := BitSet64(0b1 << 12) // соседний бит
+
+	if v, ok := mana.GetOptional(bits); ok || v != 0 {
+		t.Errorf("GetOptional() = (%d, %v), want (0, false)", v, ok)
+	}
+
+	bits, err := mana.Update(bits, 0)
+	if err != nil {
+		t.Fatalf("Update(0): %v", err)
+	}
+	if v, ok := mana.GetOptional(bits); !ok || v != 0 {
+		t.Errorf("GetOptional() = (%d, %v), want (0, true)", v, ok)
+	}
+
+	if _, err := mana.Update(bits, 1001); !errors.Is(err, ErrValueOverflow) {
+		t.Errorf("Update(1001) err = %v, want overflow", err)
+	}
+
+	bits = mana.UpdateUnchecked(bits, 700)
+	bits = mana.Clear(bits)
+	if mana.IsSet(bits) || mana.Value.Get(bits) != 0 {
+		t.Errorf("Clear() left value %d, present %v", mana.Value.Get(bits), mana.IsSet(bits))
+	}
+	if bits != 0b1<<12 {
+		t.Errorf("neighbour bits changed: %b", bits)
+	}
+}
+
+func TestOptionalIntBitField(t *testing.T) {
+	delta := MustNewOptionalIntBitField(MustNewIntBitField(4, 7, -8, 7), MustNewBoolBitField(0))
+
+	bits, err := delta.Update(0, -5)
+	if err != nil {
+		t.Fatalf("Update(-5): %v", err)
+	}
+	if v, ok := delta.GetOptional(bits); !ok || v != -5 {
+		t.Errorf("GetOptional() = (%d, %v), want (-5, true)", v, ok)
+	}
+	if _, err := delta.Update(bits, -9); !errors.Is(err, ErrValueUnderflow) {
+		t.Errorf("Update(-9) err = %v, want underflow", err)
+	}
+	if bits = delta.Clear(bits); bits != 0 {
+		t.Errorf("Clear() = %b, want 0", bits)
+	}
+}
+
+func TestNewOptionalBitFieldOverlap(t *testing.T) {
+	_, err := NewOptionalUIntBitField(MustNewUIntBitField(0, 9, 1000), MustNewBoolBitField(9))
+	if !errors.Is(err, ErrFieldOverlap) {
+		t.Errorf("err = %v, want field overlap", err)
+	}
+}
+
+func TestOptionalFieldSliceFunctions(t *testing.T) {
+	var packed Packed96
+	// Значение пересекает границу 64-битных слов, бит присутствия — в конце буфера
+	value, err := newUIntBitField(60, 69, 0, 1000)
+	if err != nil {
+		t.Fatal(err)
+	}
+	field := OptionalUIntBitField{Value: value, Present: newBoolBitField(95)}
+
+	if _, ok := GetOptionalUIntFieldAs[uint32](packed[:], field); ok {
+		t.Error("GetOptional on zero buffer: ok = true")
+	}
+	if err := SetOptionalUIntFieldAs[uint32](packed[:], field, 999); err != nil {
+		t.Fatalf("SetOptionalUIntFieldAs: %v", err)
+	}
+	if v, ok := GetOptionalUIntFieldAs[uint32](packed[:], field); !ok || v != 999 {
+		t.Errorf("GetOptional() = (%d, %v), want (999, true)", v, ok)
+	}
+	if err := SetOptionalUIntFieldAs[uint32](packed[:], field, 1001); !errors.Is(err, ErrValueOverflow) {
+		t.Errorf("Set(1001) err = %v, want overflow", err)
+	}
+	if err := SetOptionalUIntFieldAs[uint32](packed[:8], field, 1); !errors.Is(err, ErrFieldOutOfSlice) {
+		t.Errorf("Set(short slice) err = %v, want field out of slice", err)
+	}
+
+	ClearOptionalUIntField(packed[:], field)
+	if packed != (Packed96{}) {
+		t.Errorf("Clear left bits: %x", packed)
+	}
+
+	signed := MustNewOptionalIntBitField(MustNewIntBitField(8, 15, -100, 100), MustNewBoolBitField(16))
+	SetOptionalIntFieldUncheckedAs[int8](packed[:], signed, -42)
+	if v, ok := GetOptionalIntFieldAs[int8](packed[:], signed); !ok || v != -42 {
+		t.Errorf("GetOptional() = (%d, %v), want (-42, true)", v, ok)
+	}
+	ClearOptionalIntField(packed[:], signed)
+	if packed != (Packed96{}) {
+		t.Errorf("Clear left bits: %x", packed)
+	}
+}
+
+func TestLayoutAddOptional(t *testing.T) {
+	layout := NewLayout("stats", 32)
+	mana := layout.AddOptionalUInt("mana", 1000)
+	delta := layout.AddOptionalInt("delta", -4, 3)
+	if err := layout.Err(); err != nil {
+		t.Fatalf("layout error: %v", err)
+	}
+
+	if mana.Value.Start != 0 || mana.Value.End != 9 || mana.Present.Position != 10 {
+		t.Errorf("mana = %v, want bits 0-9, present 10", mana)
+	}
+	if delta.Value.Start != 11 || delta.Value.End != 13 || delta.Present.Position != 14 {
+		t.Errorf("delta = %v, want bits 11-13, present 14", delta)
+	}
+
+	dup := NewLayout("dup", 32)
+	dup.AddBool("manaPresent")
+	dup.AddOptionalUInt("mana", 10)
+	if !errors.Is(dup.Err(), ErrDuplicateField) {
+		t.Errorf("Err() = %v, want duplicate field", dup.Err())
+	}
+}
+
+func TestViewOptional(t *testing.T) {
+	layout := NewLayout("view", 16)
+	mana := layout.AddOptionalUInt("mana", 1000)
+	layout.MustBuild()
+
+	packed := Packed16{}
+	view := MustNewView(packed[:])
+	if err := view.SetOptionalUInt(mana, 5); err != nil {
+		t.Fatalf("SetOptionalUInt: %v", err)
+	}
+	if err := view.Commit(); err != nil {
+		t.Fatalf("Commit: %v", err)
+	}
+	if v, ok := GetOptionalUIntFieldAs[uint16](packed[:], mana); !ok || v != 5 {
+		t.Errorf("after Commit = (%d, %v), want (5, true)", v, ok)
+	}
+
+	view.ClearOptionalUInt(mana)
+	if _, ok := view.OptionalUInt(mana); ok {
+		t.Error("OptionalUInt() after Clear: ok = true")
+	}
+}
+
+func TestNullableUIntBitField(t *testing.T) {
+	mana := MustNewNullableUIntBitField(0, 9, 1000)
+	bits := BitSet64(0b1 << 12) // соседний бит
+
+	if v, ok := mana.GetOptional(bits); ok || v != 0 {
+		t.Errorf("GetOptional() = (%d, %v), want (0, false)", v, ok)
+	}
+
+	bits, err := mana.Update(bits, 0)
+	if err != nil {
+		t.Fatalf("Update(0): %v", err)
+	}
+	if v, ok := mana.GetOptional(bits); !ok || v != 0 || mana.Code.Get(bits) != 1 {
+		t.Errorf("GetOptional() = (%d, %v), code %d, want (0, true), code 1", v, ok, mana.Code.Get(bits))
+	}
+
+	var bfErr *Error
+	if _, err := mana.Update(bits, 1001); !errors.As(err, &bfErr) || bfErr.Kind != KindValueOverflow || bfErr.Details.AllowedMax != 1000 {
+		t.Errorf("Update(1001) err = %v, want overflow with max 1000", err)
+	}
+
+	bits = mana.UpdateUnchecked(bits, 1000)
+	if v, ok := mana.GetOptional(bits); !ok || v != 1000 {
+		t.Errorf("GetOptional() = (%d, %v), want (1000, true)", v, ok)
+	}
+	if bits = mana.Clear(bits); bits != 0b1<<12 {
+		t.Errorf("Clear() = %b, want only neighbour bit", bits)
+	}
+
+	if _, err := NewNullableUIntBitField(0, 9, 1023); !errors.Is(err, ErrValueOverflow) {
+		t.Errorf("NewNullableUIntBitField(max=1023) err = %v, want overflow: code 1024 needs 11 bits", err)
+	}
+}
+
+func TestNullableFieldSliceFunctions(t *testing.T) {
+	var packed Packed96
+	// Код пересекает границу 64-битных слов
+	field, err := newNullableUIntBitField(60, 69, 1000)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, ok := GetNullableUIntFieldAs[uint32](packed[:], field); ok {
+		t.Error("GetNullable on zero buffer: ok = true")
+	}
+	if err := SetNullableUIntFieldAs[uint32](packed[:], field, 999); err != nil {
+		t.Fatalf("SetNullableUIntFieldAs: %v", err)
+	}
+	if v, ok := GetNullableUIntFieldAs[uint32](packed[:], field); !ok || v != 999 {
+		t.Errorf("GetNullable() = (%d, %v), want (999, true)", v, ok)
+	}
+	if err := SetNullableUIntFieldAs[uint32](packed[:], field, 1001); !errors.Is(err, ErrValueOverflow) {
+		t.Errorf("Set(1001) err = %v, want overflow", err)
+	}
+	if err := SetNullableUIntFieldAs[uint32](packed[:8], field, 1); !errors.Is(err, ErrFieldOutOfSlice) {
+		t.Errorf("Set(short slice) err = %v, want field out of slice", err)
+	}
+
+	ClearNullableUIntField(packed[:], field)
+	if packed != (Packed96{}) {
+		t.Errorf("Clear left bits: %x", packed)
+	}
+}
+
+func TestNullableFieldArith(t *testing.T) {
+	var packed Packed16
+	mana := MustNewNullableUIntBitField(0, 9, 1000)
+
+	// Незаданное значение считается нулём, успешная операция задаёт его
+	if _, err := SubNullableUIntFieldAs[uint32](packed[:], mana, 1); !errors.Is(err, ErrValueUnderflow) {
+		t.Errorf("Sub(unset, 1) err = %v, want underflow", err)
+	}
+	if _, ok := GetNullableUIntFieldAs[uint32](packed[:], mana); ok {
+		t.Error("failed Sub marked value as set")
+	}
+	if v, err := AddNullableUIntFieldAs[uint32](packed[:], mana, 0); err != nil || v != 0 {
+		t.Errorf("Add(unset, 0) = %d, %v, want 0", v, err)
+	}
+	if v, ok := GetNullableUIntFieldAs[uint32](packed[:], mana); !ok || v != 0 {
+		t.Errorf("after Add(0) = (%d, %v), want (0, true)", v, ok)
+	}
+
+	if v, err := AddNullableUIntFieldAs[uint32](packed[:], mana, 1001); !errors.Is(err, ErrValueOverflow) || v != 0 {
+		t.Errorf("Add(1001) = %d, %v, want 0, overflow", v, err)
+	}
+	if v := AddNullableUIntFieldSaturatingAs[uint32](packed[:], mana, 5000); v != 1000 {
+		t.Errorf("AddSaturating(5000) = %d, want 1000", v)
+	}
+	if v := SubNullableUIntFieldSaturatingAs[uint32](packed[:], mana, 5000); v != 0 {
+		t.Errorf("SubSaturating(5000) = %d, want 0", v)
+	}
+	// Насыщение до нуля оставляет значение заданным
+	if v, ok := GetNullableUIntFieldAs[uint32](packed[:], mana); !ok || v != 0 {
+		t.Errorf("after SubSaturating = (%d, %v), want (0, true)", v, ok)
+	}
+}
+
+func TestLayoutAddNullable(t *testing.T) {
+	layout := NewLayout("stats", 16)
+	mana := layout.AddNullableUInt("mana", 1000)
+	full := layout.AddNullableUInt("full", 31) // код 32 требует 6 бит
+	if err := layout.Err(); err != nil {
+		t.Fatalf("layout error: %v", err)
+	}
+	if mana.Code.Start != 0 || mana.Code.End != 9 || full.Code.Start != 10 || full.Code.End != 15 {
+		t.Errorf("mana = %v, full = %v, want bits 0-9 and 10-15", mana, full)
+	}
+
+	var packed Packed16
+	err := SetNullableUIntFieldAs(packed[:], full, uint8(32))
+	var bfErr *Error
+	if !errors.As(err, &bfErr) || bfErr.Field() != "full" || bfErr.Schema() != "stats" {
+		t.Errorf("Set(32) err = %v, want error of field stats.full", err)
+	}
+
+	view := MustNewView(packed[:])
+	if err := view.SetNullableUInt(mana, 7); err != nil {
+		t.Fatalf("SetNullableUInt: %v", err)
+	}
+	if err := view.Commit(); err != nil {
+		t.Fatalf("Commit: %v", err)
+	}
+	if v, ok := GetNullableUIntFieldAs[uint16](packed[:], mana); !ok || v != 7 {
+		t.Errorf("after Commit = (%d, %v), want (7, true)", v, ok)
+	}
+	view.ClearNullableUInt(mana)
+	if _, ok := view.NullableUInt(mana); ok {
+		t.Error("NullableUInt() after Clear: ok = true")
+	}
+}
@@ -0,0 +1,208 @@
+package bitpack
+
+import (
+	"fmt"
+	"iter"
+)
+
+// =================  PackedArray ===========================================
+// ======== Массив элементов фиксированной ширины внутри буфера =============
+//
+// PackedArray адресует Len элементов по Width битов, лежащих подряд начиная
+// с бита Start. Элемент i занимает биты [Start+i*Width, Start+(i+1)*Width-1]
+// и может пересекать границы байтов и 64-битных слов:
+//
+//	slots, _ := bitpack.NewPackedArray(0, 5, 8, 31) // 8 слотов инвентаря по 5 бит
+//	_ = slots.Set(packed[:], 3, 17)
+//	item, _ := slots.Get(packed[:], 3)
+//	items, _ := slots.All(packed[:])
+//	for i, item := range items { ... }
+//
+// Массив целиком должен помещаться в MaxPackedBits, элементы хранят значения [0, Max].
+// По умолчанию буфер LittleEndian; WithOrder возвращает копию для другого порядка:
+//
+//	beSlots := slots.WithOrder(bitpack.BigEndian)
+
+type PackedArray struct {
+	Start BitPosition // Позиция первого бита элемента 0
+	Width uint8       // Ширина одного элемента в битах (1..64)
+	Len   int         // Количество элементов
+	Max   uint64      // Максимальное допустимое значение элемента
+	label *fieldLabel // Имя массива и схемы для ошибок (nil — безымянный)
+	order ByteOrder   // Порядок байтов буфера (по умолчанию LittleEndian)
+}
+
+// NewPackedArray создаёт массив из length элементов по width битов со значениями [0, max]
+func NewPackedArray(start BitPosition, width uint8, length int, max uint64) (PackedArray, error) {
+	// length сравнивается до умножения: произведение большого length переполняет int
+	if width == 0 || width > 64 || length <= 0 || length > MaxPackedBits/int(width) ||
+		int(start)+int(width)*length > MaxPackedBits {
+		return PackedArray{}, newArrayDefinitionError(start, width, length)
+	}
+	if allowedMax := maxAllowedForWidth(width); max > allowedMax {
+		return PackedArray{}, newValueOverflowError(max, allowedMax, width)
+	}
+	return PackedArray{Start: start, Width: width, Len: length, Max: max}, nil
+}
+
+// MustNewPackedArray создаёт массив или паникует при ошибке конфигурации
+// Используется ТОЛЬКО для статических конфигураций
+
+func MustNewPackedArray(start BitPosition, width uint8, length int, max uint64) PackedArray {
+	a, err := NewPackedArray(start, width, length, max)
+	if err != nil {
+		panic(fmt.Sprintf("FATAL: invalid static packed array configuration [start=%d, width=%d, len=%d, max=%d]: %v",
+			start, width, length, max, err))
+	}
+	return a
+}
+
+// End — позиция последнего бита массива
+func (a PackedArray) End() BitPosition {
+	return BitPosition(int(a.Start) + a.Bits() - 1)
+}
+
+// Bits — количество битов, занятых массивом
+func (a PackedArray) Bits() int {
+	return int(a.Width) * a.Len
+}
+
+// Validate проверяет, что массив целиком помещается в буфер
+func (a PackedArray) Validate(packed []byte) error {
+	return a.label.annotate(validatePacked(packed, a.End()))
+}
+
+// Named возвращает копию массива с именем: ошибки, полученные через него, содержат schema.name
+func (a PackedArray) Named(schema, name string) PackedArray {
+	a.label = newFieldLabel(schema, name)
+	return a
+}
+
+// Name — имя массива (пусто для безымянного)
+func (a PackedArray) Name() string {
+	return a.label.fieldName()
+}
+
+// Schema — имя схемы, которой принадлежит массив
+func (a PackedArray) Schema() string {
+	return a.label.schemaName()
+}
+
+// WithOrder возвращает копию массива, читающую и пишущую буфер в порядке o
+func (a PackedArray) WithOrder(o ByteOrder) PackedArray {
+	a.order = o
+	return a
+}
+
+// Order — порядок байтов буфера массива
+func (a PackedArray) Order() ByteOrder {
+	return a.order
+}
+
+// ==================== Get / Set ====================
+
+// Get читает элемент i с проверкой буфера и индекса
+func (a PackedArray) Get(packed []byte, i int) (uint64, error) {
+	if err := a.check(packed, i); err != nil {
+		return 0, err
+	}
+	return a.GetUnchecked(packed, i), nil
+}
+
+// Set записывает элемент i с проверкой буфера, индекса и диапазона значения
+func (a PackedArray) Set(packed []byte, i int, value uint64) error {
+	if err := a.check(packed, i); err != nil {
+		return err
+	}
+	if value > a.Max {
+		return a.label.annotate(newValueOverflowError(value, a.Max, a.Width))
+	}
+	a.SetUnchecked(packed, i, value)
+	return nil
+}
+
+// GetUnchecked читает элемент i без проверок
+func (a PackedArray) GetUnchecked(packed []byte, i int) uint64 {
+	return a.order.readBits(packed, a.position(i), a.Width)
+}
+
+// SetUnchecked записывает элемент i без проверок, лишние старшие биты value отбрасываются
+func (a PackedArray) SetUnchecked(packed []byte, i int, value uint64) {
+	a.order.writeBits(packed, a.position(i), a.Width, value)
+}
+
+// ==================== Групповые операции ====================
+
+// All перебирает пары (индекс, значение). Буфер проверяется сразу:
+// для слишком короткого буфера возвращается ошибка, как у Get.
+func (a PackedArray) All(packed []byte) (iter.Seq2[int, uint64], error) {
+	if err := a.Validate(packed); err != nil {
+		return nil, err
+	}
+	return a.all(packed), nil
+}
+
+// all перебирает элементы без проверки буфера
+func (a PackedArray) all(packed []byte) iter.Seq2[int, uint64] {
+	return func(yield func(int, uint64) bool) {
+		for i := range a.Len {
+			if !yield(i, a.GetUnchecked(packed, i)) {
+				return
+			}
+		}
+	}
+}
+
+// Fill записывает value во все элементы
+func (a PackedArray) Fill(packed []byte, value uint64) error {
+	if err := a.Validate(packed); err != nil {
+		return err
+	}
+	if value > a.Max {
+		return a.label.annotate(newValueOverflowError(value, a.Max, a.Width))
+	}
+	for i := range a.Len {
+		a.SetUnchecked(packed, i, value)
+	}
+	return nil
+}
+
+// Count возвращает количество элементов, равных value, с проверкой буфера
+func (a PackedArray) Count(packed []byte, value uint64) (int, error) {
+	if err := a.Validate(packed); err != nil {
+		return 0, err
+	}
+	count := 0
+	for _, v := range a.all(packed) {
+		if v == value {
+			count++
+		}
+	}
+	return count, nil
+}
+
+// ------------- Сервисные методы --------------------------------
+
+// check проверяет буфер и индекс элемента
+func (a PackedArray) check(packed []byte, i int) error {
+	if err := a.Validate(packed); err != nil {
+		return err
+	}
+	if i < 0 || i >= a.Len {
+		return a.label.annotate(newIndexOutOfRangeError(i, a.Len))
+	}
+	return nil
+}
+
+// position — позиция первого бита элемента i
+func (a PackedArray) position(i int) BitPosition {
+	return BitPosition(int(a.Start) + i*int(a.Width))
+}
+
+// Строковое представление для отладки
+func (a PackedArray) String() string {
+	if a.order.big {
+		return fmt.Sprintf("PackedArray[%d:%d] %d x %d bits, max=%d, %v", a.Start, a.End(), a.Len, a.Width, a.Max, a.order)
+	}
+	return fmt.Sprintf("PackedArray[%d:%d] %d x %d bits, max=%d", a.Start, a.End(), a.Len, a.Width, a.Max)
+}
@@ -0,0 +1,181 @@
+package bitpack
+
+import (
+	"errors"
+	"testing"
+)
+
+// ============ Тесты для PackedArray ============
+
+func TestNewPackedArray(t *testing.T) {
+	tests := []struct {
+		name    string
+		start   BitPosition
+		width   uint8
+		length  int
+		max     uint64
+		wantErr error
+	}{
+		{"inventory 8x5", 0, 5, 8, 31, nil},
+		{"skills 4x3", 40, 3, 4, 5, nil},
+		{"full 256 bits", 0, 64, 4, 1<<64 - 1, nil},
+		{"zero width", 0, 0, 4, 0, ErrArrayDefinition},
+		{"width over 64", 0, 65, 1, 0, ErrArrayDefinition},
+		{"zero length", 0, 5, 0, 31, ErrArrayDefinition},
+		{"beyond max bits", 200, 8, 8, 255, ErrArrayDefinition},
+		{"width*length overflows int", 0, 64, 1 << 58, 1<<64 - 1, ErrArrayDefinition},
+		{"max over width", 0, 3, 4, 8, ErrValueOverflow},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := NewPackedArray(tt.start, tt.width, tt.length, tt.max)
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("err = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestPackedArrayGetSet(t *testing.T) {
+	// 7 элементов по 9 бит с позиции 60: пересекают байты и границу 64-битного слова
+	var packed Packed128
+	arr := MustNewPackedArray(60, 9, 7, 500)
+	if arr.End() != 122 {
+		t.Fatalf("End() = %d, want 122", arr.End())
+	}
+
+	for i := range arr.Len {
+		if err := arr.Set(packed[:], i, uint64(100+i*50)); err != nil {
+			t.Fatalf("Set(%d): %v", i, err)
+		}
+	}
+	for i := range arr.Len {
+		got, err := arr.Get(packed[:], i)
+		if err != nil {
+			t.Fatalf("Get(%d): %v", i, err)
+		}
+		if want := uint64(100 + i*50); got != want {
+			t.Errorf("Get(%d) = %d, want %d", i, got, want)
+		}
+	}
+
+	// Биты вне массива (0-59 и 123-127) не затронуты
+	if packed[0] != 0 || packed[7]&0x0F != 0 || packed[15]>>3 != 0 {
+		t.Errorf("bits outside of array changed: %x", packed)
+	}
+}
+
+func TestPackedArrayErrors(t *testing.T) {
+	var packed Packed64
+	arr := MustNewPackedArray(8, 5, 8, 20)
+
+	tests := []struct {
+		name string
+		err  error
+		want error
+	}{
+		{"negative index", arr.Set(packed[:], -1, 0), ErrIndexOutOfRange},
+		{"index == len", arr.Set(packed[:], 8, 0), ErrIndexOutOfRange},
+		{"value over max", arr.Set(packed[:], 0, 21), ErrValueOverflow},
+		{"short slice", arr.Set(packed[:4], 0, 1), ErrFieldOutOfSlice},
+		{"empty slice", arr.Fill(nil, 1), ErrSliceEmpty},
+		{"fill over max", arr.Fill(packed[:], 31), ErrValueOverflow},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if !errors.Is(tt.err, tt.want) {
+				t.Errorf("err = %v, want %v", tt.err, tt.want)
+			}
+		})
+	}
+
+	if _, err := arr.Get(packed[:], 8); !errors.Is(err, ErrIndexOutOfRange) {
+		t.Errorf("Get(8) err = %v, want index out of range", err)
+	}
+	if packed != (Packed64{}) {
+		t.Errorf("failed Set modified buffer: %x", packed)
+	}
+}
+
+func TestPackedArrayFillCountAll(t *testing.T) {
+	packed := Packed32{0xFF, 0, 0, 0xFF}
+	arr := MustNewPackedArray(8, 4, 4, 15)
+
+	if err := arr.Fill(packed[:], 7); err != nil {
+		t.Fatalf("Fill: %v", err)
+	}
+	arr.SetUnchecked(packed[:], 2, 0)
+
+	if got, err := arr.Count(packed[:], 7); err != nil || got != 3 {
+		t.Errorf("Count(7) = %d, %v, want 3", got, err)
+	}
+	if got, err := arr.Count(packed[:], 0); err != nil || got != 1 {
+		t.Errorf("Count(0) = %d, %v, want 1", got, err)
+	}
+	if packed[0] != 0xFF || packed[3] != 0xFF {
+		t.Errorf("neighbour bytes changed: %x", packed)
+	}
+
+	items, err := arr.All(packed[:])
+	if err != nil {
+		t.Fatalf("All: %v", err)
+	}
+	var values []uint64
+	for i, v := range items {
+		if i == 3 {
+			break
+		}
+		values = append(values, v)
+	}
+	if len(values) != 3 || values[0] != 7 || values[2] != 0 {
+		t.Errorf("All() = %v, want [7 7 0]", values)
+	}
+
+	// Короткий буфер — ошибка, а не пустой результат
+	if got, err := arr.Count(packed[:1], 7); !errors.Is(err, ErrFieldOutOfSlice) || got != 0 {
+		t.Errorf("Count(short slice) = %d, %v, want 0, ErrFieldOutOfSlice", got, err)
+	}
+	if items, err := arr.All(packed[:1]); !errors.Is(err, ErrFieldOutOfSlice) || items != nil {
+		t.Errorf("All(short slice) error = %v, want ErrFieldOutOfSlice", err)
+	}
+}
+
+func TestLayoutAddArray(t *testing.T) {
+	layout := NewLayout("inventory", 64)
+	gold := layout.AddUInt("gold", 1000)
+	slots := layout.AddArray("slots", 8, 31)
+	skills := layout.AddArray("skills", 4, 5)
+	if err := layout.Err(); err != nil {
+		t.Fatalf("layout error: %v", err)
+	}
+
+	if gold.End != 9 {
+		t.Errorf("gold = %v, want bits 0-9", gold)
+	}
+	if slots.Start != 10 || slots.Width != 5 || slots.End() != 49 {
+		t.Errorf("slots = %v, want 8x5 bits from 10", slots)
+	}
+	if skills.Start != 50 || skills.Width != 3 || skills.End() != 61 {
+		t.Errorf("skills = %v, want 4x3 bits from 50", skills)
+	}
+
+	bad := NewLayout("bad", 64)
+	bad.AddArray("empty", 0, 31)
+	if !errors.Is(bad.Err(), ErrArrayDefinition) {
+		t.Errorf("Err() = %v, want array definition error", bad.Err())
+	}
+
+	// 64 * 2^58 переполняет int и не должно пройти проверку размера
+	huge := NewLayout("huge", 64)
+	huge.AddArray("slots", 1<<58, 1<<64-1)
+	if !errors.Is(huge.Err(), ErrArrayDefinition) {
+		t.Errorf("Err() = %v, want array definition error", huge.Err())
+	}
+
+	overflow := NewLayout("overflow", 32)
+	overflow.AddArray("slots", 8, 31)
+	if !errors.Is(overflow.Err(), ErrLayoutOverflow) {
+		t.Errorf("Err() = %v, want layout overflow", overflow.Err())
+	}
+}
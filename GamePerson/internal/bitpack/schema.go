@@ -0,0 +1,476 @@
+package bitpack
+
+import (
+	"fmt"
+	"math"
+	"slices"
+)
+
+// =================  Schema ================================================
+// ============ Описание полей схемы для интроспекции =======================
+//
+// Schema — неизменяемый снимок Layout: упорядоченный по позиции список
+// FieldDescriptor и доступ к значениям по имени поля. Нужна инструментам,
+// которые не знают полей заранее (отладочные дампы, админки, сравнение и
+// проверка сохранённых буферов):
+//
+//	schema := layout.MustSchema()
+//	for _, f := range schema.Fields() {
+//		v, err := schema.Get(packed[:], f.Name)
+//		...
+//	}
+//	err := schema.Set(packed[:], "health", 500)
+//
+// Get/Set работают с целыми полями (uint, int, bool как 0/1),
+// GetFixed/SetFixed — с дробными. Optional и nullable поля Set отмечает
+// заданными, незаданное Get читает как 0, а GetOptional и Clear
+// различают «0» и «не задано». Массивы и резерв доступны только
+// через описание и Diff/Validate.
+//
+// По умолчанию буфер LittleEndian; WithOrder возвращает схему для другого
+// порядка байтов с теми же полями:
+//
+//	beSchema := schema.WithOrder(bitpack.BigEndian)
+//	err := beSchema.Validate(packed[:])
+
+type FieldKind int
+
+const (
+	FieldKindUInt     FieldKind = iota // беззнаковое поле (в т.ч. со смещением Min)
+	FieldKindInt                       // знаковое поле
+	FieldKindBool                      // однобитовый флаг
+	FieldKindFixed                     // дробное поле с фиксированным шагом
+	FieldKindArray                     // PackedArray
+	FieldKindReserved                  // зарезервированные биты (Layout.Reserve)
+)
+
+func (k FieldKind) String() string {
+	switch k {
+	case FieldKindUInt:
+		return "uint"
+	case FieldKindInt:
+		return "int"
+	case FieldKindBool:
+		return "bool"
+	case FieldKindFixed:
+		return "fixed"
+	case FieldKindArray:
+		return "array"
+	case FieldKindReserved:
+		return "reserved"
+	default:
+		return fmt.Sprintf("FieldKind(%d)", int(k))
+	}
+}
+
+// FieldDescriptor описывает одно поле схемы
+type FieldDescriptor struct {
+	Name     string
+	Kind     FieldKind
+	Start    BitPosition // Первый бит поля (включительно)
+	End      BitPosition // Последний бит поля (включительно)
+	Width    int         // Количество битов поля (для массива — всех элементов)
+	Len      int         // Количество элементов массива (0 для остальных полей)
+	Min      int64       // Минимальное значение целого поля (элемента массива)
+	Max      int64       // Максимальное значение целого поля (элемента массива)
+	Optional bool        // Значение может быть не задано (nullable или optional с битом присутствия)
+	FixedMin float64     // Диапазон и шаг дробного поля
+	FixedMax float64
+	Step     float64
+
+	field any
+}
+
+// Reserved сообщает, что биты зарезервированы и не содержат значения
+func (d FieldDescriptor) Reserved() bool {
+	return d.Kind == FieldKindReserved
+}
+
+// Строковое представление для отладки
+func (d FieldDescriptor) String() string {
+	switch d.Kind {
+	case FieldKindFixed:
+		return fmt.Sprintf("%s %s[%d:%d] range=[%g,%g] step=%g", d.Name, d.Kind, d.Start, d.End, d.FixedMin, d.FixedMax, d.Step)
+	case FieldKindArray:
+		return fmt.Sprintf("%s %s[%d:%d] %d x %d bits, max=%d", d.Name, d.Kind, d.Start, d.End, d.Len, d.Width/d.Len, d.Max)
+	case FieldKindReserved:
+		return fmt.Sprintf("%s %s[%d:%d]", d.Name, d.Kind, d.Start, d.End)
+	default:
+		if d.Optional {
+			return fmt.Sprintf("%s %s[%d:%d] range=[%d,%d] optional", d.Name, d.Kind, d.Start, d.End, d.Min, d.Max)
+		}
+		return fmt.Sprintf("%s %s[%d:%d] range=[%d,%d]", d.Name, d.Kind, d.Start, d.End, d.Min, d.Max)
+	}
+}
+
+type Schema struct {
+	name   string
+	bits   int
+	fields []FieldDescriptor
+	index  map[string]int
+	order  ByteOrder // Порядок байтов буфера (по умолчанию LittleEndian)
+}
+
+// Schema возвращает описание полей схемы или первую ошибку конфигурации
+func (l *Layout) Schema() (*Schema, error) {
+	if l.err != nil {
+		return nil, l.err
+	}
+	s := &Schema{name: l.name, bits: l.bits, index: make(map[string]int, len(l.fields))}
+	for _, e := range l.fields {
+		s.fields = append(s.fields, describe(e))
+	}
+	slices.SortStableFunc(s.fields, func(a, b FieldDescriptor) int {
+		return int(a.Start) - int(b.Start)
+	})
+	for i, f := range s.fields {
+		s.index[f.Name] = i
+	}
+	return s, nil
+}
+
+// MustSchema возвращает описание полей или паникует при ошибке конфигурации.
+// Используется ТОЛЬКО для статических схем, проверенных на этапе разработки
+
+func (l *Layout) MustSchema() *Schema {
+	l.MustBuild()
+	s, _ := l.Schema()
+	return s
+}
+
+// Name — имя схемы
+func (s *Schema) Name() string {
+	return s.name
+}
+
+// Bits — размер схемы в битах
+func (s *Schema) Bits() int {
+	return s.bits
+}
+
+// UsedBits — количество битов, занятых полями и резервом
+func (s *Schema) UsedBits() int {
+	used := 0
+	for _, f := range s.fields {
+		used += f.Width
+	}
+	return used
+}
+
+// Fields возвращает описания полей в порядке возрастания позиции
+func (s *Schema) Fields() []FieldDescriptor {
+	return slices.Clone(s.fields)
+}
+
+// Field возвращает описание поля по имени
+func (s *Schema) Field(name string) (FieldDescriptor, bool) {
+	i, ok := s.index[name]
+	if !ok {
+		return FieldDescriptor{}, false
+	}
+	return s.fields[i], true
+}
+
+// WithOrder возвращает копию схемы, читающую и пишущую буфер в порядке o
+func (s *Schema) WithOrder(o ByteOrder) *Schema {
+	c := *s
+	c.order = o
+	return &c
+}
+
+// Order — порядок байтов буфера схемы
+func (s *Schema) Order() ByteOrder {
+	return s.order
+}
+
+// Строковое представление для отладки
+func (s *Schema) String() string {
+	return fmt.Sprintf("Schema[%s] %d/%d bits, %d fields", s.name, s.UsedBits(), s.bits, len(s.fields))
+}
+
+// ==================== Доступ по имени ====================
+
+// Get читает целое поле (uint, int; bool как 0/1) по имени
+func (s *Schema) Get(packed []byte, name string) (int64, error) {
+	d, err := s.lookup(packed, name)
+	if err != nil {
+		return 0, err
+	}
+	switch f := d.field.(type) {
+	case UIntBitField:
+		v := s.order.GetUIntUnchecked(packed, f)
+		if v > math.MaxInt64 {
+			return 0, f.label.annotate(newValueOverflowError(v, math.MaxInt64, f.Width()))
+		}
+		return int64(v), nil
+	case IntBitField:
+		return s.order.GetIntUnchecked(packed, f), nil
+	case BoolBitField:
+		if s.order.GetBoolUnchecked(packed, f) {
+			return 1, nil
+		}
+		return 0, nil
+	case NullableUIntBitField, OptionalUIntBitField, OptionalIntBitField:
+		v, _, err := s.getOptional(packed, d)
+		return v, err
+	default:
+		return 0, s.kindMismatch(d, "integer access")
+	}
+}
+
+// Set записывает целое поле (uint, int; bool как 0/1) по имени с проверкой диапазона
+func (s *Schema) Set(packed []byte, name string, value int64) error {
+	d, err := s.lookup(packed, name)
+	if err != nil {
+		return err
+	}
+	switch f := d.field.(type) {
+	case UIntBitField:
+		if value < 0 {
+			return f.label.annotate(newValueOutOfRangeError(value, value, d.Min, d.Max, f.Width()))
+		}
+		return s.order.SetUInt(packed, f, uint64(value))
+	case NullableUIntBitField:
+		if value < 0 {
+			return f.Code.label.annotate(newValueOutOfRangeError(value, value, d.Min, d.Max, f.Code.Width()))
+		}
+		return s.order.SetNullableUInt(packed, f, uint64(value))
+	case OptionalUIntBitField:
+		if value < 0 {
+			return f.Value.label.annotate(newValueOutOfRangeError(value, value, d.Min, d.Max, f.Value.Width()))
+		}
+		return s.order.SetOptionalUInt(packed, f, uint64(value))
+	case OptionalIntBitField:
+		return s.order.SetOptionalInt(packed, f, value)
+	case IntBitField:
+		return s.order.SetInt(packed, f, value)
+	case BoolBitField:
+		if value != 0 && value != 1 {
+			return f.label.annotate(newValueOutOfRangeError(value, value, 0, 1, 1))
+		}
+		return s.order.SetBool(packed, f, value == 1)
+	default:
+		return s.kindMismatch(d, "integer access")
+	}
+}
+
+// GetOptional читает optional или nullable поле по имени: (0, false) — не задано.
+// Для остальных целых полей значение всегда задано.
+func (s *Schema) GetOptional(packed []byte, name string) (int64, bool, error) {
+	d, err := s.lookup(packed, name)
+	if err != nil {
+		return 0, false, err
+	}
+	if !d.Optional {
+		v, err := s.Get(packed, name)
+		return v, err == nil, err
+	}
+	return s.getOptional(packed, d)
+}
+
+// Clear переводит optional или nullable поле в «не задано»
+func (s *Schema) Clear(packed []byte, name string) error {
+	d, err := s.lookup(packed, name)
+	if err != nil {
+		return err
+	}
+	switch f := d.field.(type) {
+	case NullableUIntBitField:
+		s.order.ClearNullableUInt(packed, f)
+	case OptionalUIntBitField:
+		s.order.ClearOptionalUInt(packed, f)
+	case OptionalIntBitField:
+		s.order.ClearOptionalInt(packed, f)
+	default:
+		return s.kindMismatch(d, "clear")
+	}
+	return nil
+}
+
+// GetFixed читает дробное поле по имени
+func (s *Schema) GetFixed(packed []byte, name string) (float64, error) {
+	d, err := s.lookup(packed, name)
+	if err != nil {
+		return 0, err
+	}
+	f, ok := d.field.(FixedBitField)
+	if !ok {
+		return 0, s.kindMismatch(d, "fixed-point access")
+	}
+	return s.order.GetFixedUnchecked(packed, f), nil
+}
+
+// SetFixed записывает дробное поле по имени с проверкой диапазона
+func (s *Schema) SetFixed(packed []byte, name string, value float64) error {
+	d, err := s.lookup(packed, name)
+	if err != nil {
+		return err
+	}
+	f, ok := d.field.(FixedBitField)
+	if !ok {
+		return s.kindMismatch(d, "fixed-point access")
+	}
+	return s.order.SetFixed(packed, f, value)
+}
+
+// ==================== Проверка и сравнение буферов ====================
+
+// Validate проверяет, что значения всех полей буфера лежат в своих диапазонах.
+// Нужна для данных, записанных в обход checked Set (файлы, сеть, старые версии).
+func (s *Schema) Validate(packed []byte) error {
+	if err := validatePacked(packed, BitPosition(s.bits-1)); err != nil {
+		return err
+	}
+	for _, d := range s.fields {
+		var err error
+		switch f := d.field.(type) {
+		case UIntBitField:
+			if raw := s.order.readBits(packed, f.Start, f.Width()); raw > f.Max-f.Min {
+				err = newValueOverflowError(raw+f.Min, f.Max, f.Width())
+			}
+		case NullableUIntBitField:
+			if code := s.order.readBits(packed, f.Code.Start, f.Code.Width()); code > f.Max+1 {
+				err = newValueOverflowError(code-1, f.Max, f.Code.Width())
+			}
+		case OptionalUIntBitField:
+			if raw := s.order.readBits(packed, f.Value.Start, f.Value.Width()); raw > f.Value.Max-f.Value.Min {
+				err = newValueOverflowError(raw+f.Value.Min, f.Value.Max, f.Value.Width())
+			}
+		case OptionalIntBitField:
+			if v := f.Value.signExtend(s.order.readBits(packed, f.Value.Start, f.Value.Width())); v < f.Value.Min || v > f.Value.Max {
+				err = newValueOutOfRangeError(v, v, f.Value.Min, f.Value.Max, f.Value.Width())
+			}
+		case IntBitField:
+			if v := f.signExtend(s.order.readBits(packed, f.Start, f.Width())); v < f.Min || v > f.Max {
+				err = newValueOutOfRangeError(v, v, f.Min, f.Max, f.Width())
+			}
+		case FixedBitField:
+			if raw := s.order.readBits(packed, f.Field.Start, f.Width()); raw > f.Field.Max {
+				err = newFixedOutOfRangeError(f.dequantize(raw), f.Min, f.Max)
+			}
+		case PackedArray:
+			for _, v := range f.WithOrder(s.order).all(packed) {
+				if v > f.Max {
+					err = newValueOverflowError(v, f.Max, f.Width)
+					break
+				}
+			}
+		}
+		if err != nil {
+			return newFieldLabel(s.name, d.Name).annotate(err)
+		}
+	}
+	return nil
+}
+
+// Diff возвращает имена полей (включая резерв), биты которых различаются в a и b
+func (s *Schema) Diff(a, b []byte) ([]string, error) {
+	end := BitPosition(s.bits - 1)
+	if err := validatePacked(a, end); err != nil {
+		return nil, err
+	}
+	if err := validatePacked(b, end); err != nil {
+		return nil, err
+	}
+	var changed []string
+	for _, d := range s.fields {
+		if !s.bitsEqual(a, b, d.Start, d.Width) {
+			changed = append(changed, d.Name)
+		}
+	}
+	return changed, nil
+}
+
+// ------------- Сервисные методы --------------------------------
+
+// lookup находит поле по имени и проверяет, что оно помещается в буфер
+func (s *Schema) lookup(packed []byte, name string) (FieldDescriptor, error) {
+	d, ok := s.Field(name)
+	if !ok {
+		return FieldDescriptor{}, newUnknownFieldError(s.name, name)
+	}
+	if err := validatePacked(packed, max(d.End, presenceBit(d.field))); err != nil {
+		return FieldDescriptor{}, newFieldLabel(s.name, name).annotate(err)
+	}
+	return d, nil
+}
+
+// getOptional читает значение и признак присутствия поля, найденного lookup
+func (s *Schema) getOptional(packed []byte, d FieldDescriptor) (int64, bool, error) {
+	switch f := d.field.(type) {
+	case NullableUIntBitField:
+		v, ok := s.order.GetNullableUIntUnchecked(packed, f)
+		return clampToInt64(v), ok, nil
+	case OptionalUIntBitField:
+		v, ok := s.order.GetOptionalUIntUnchecked(packed, f)
+		return clampToInt64(v), ok, nil
+	case OptionalIntBitField:
+		v, ok := s.order.GetOptionalIntUnchecked(packed, f)
+		return v, ok, nil
+	default:
+		return 0, false, s.kindMismatch(d, "optional access")
+	}
+}
+
+// presenceBit — бит присутствия optional поля (0 для остальных полей)
+func presenceBit(field any) BitPosition {
+	switch f := field.(type) {
+	case OptionalUIntBitField:
+		return f.Present.Position
+	case OptionalIntBitField:
+		return f.Present.Position
+	default:
+		return 0
+	}
+}
+
+func (s *Schema) kindMismatch(d FieldDescriptor, operation string) error {
+	return newFieldLabel(s.name, d.Name).annotate(newFieldKindMismatchError(d.Kind, operation))
+}
+
+// describe строит описание поля по записи Layout
+func describe(e layoutEntry) FieldDescriptor {
+	d := FieldDescriptor{
+		Name:  e.name,
+		Kind:  FieldKindReserved,
+		Start: e.start,
+		End:   e.end,
+		Width: int(e.end-e.start) + 1,
+		field: e.field,
+	}
+	switch f := e.field.(type) {
+	case UIntBitField:
+		d.Kind, d.Min, d.Max = FieldKindUInt, clampToInt64(f.Min), clampToInt64(f.Max)
+	case NullableUIntBitField:
+		d.Kind, d.Min, d.Max, d.Optional = FieldKindUInt, 0, clampToInt64(f.Max), true
+	case OptionalUIntBitField:
+		d.Kind, d.Min, d.Max, d.Optional = FieldKindUInt, clampToInt64(f.Value.Min), clampToInt64(f.Value.Max), true
+	case OptionalIntBitField:
+		d.Kind, d.Min, d.Max, d.Optional = FieldKindInt, f.Value.Min, f.Value.Max, true
+	case IntBitField:
+		d.Kind, d.Min, d.Max = FieldKindInt, f.Min, f.Max
+	case BoolBitField:
+		d.Kind, d.Min, d.Max = FieldKindBool, 0, 1
+	case FixedBitField:
+		d.Kind, d.FixedMin, d.FixedMax, d.Step = FieldKindFixed, f.Min, f.Max, f.Step
+	case PackedArray:
+		d.Kind, d.Len, d.Max = FieldKindArray, f.Len, clampToInt64(f.Max)
+	}
+	return d
+}
+
+func clampToInt64(v uint64) int64 {
+	return int64(min(v, math.MaxInt64))
+}
+
+// bitsEqual сравнивает width битов начиная со start в двух буферах
+func (s *Schema) bitsEqual(a, b []byte, start BitPosition, width int) bool {
+	for offset := 0; offset < width; offset += 64 {
+		pos := BitPosition(int(start) + offset)
+		chunk := uint8(min(width-offset, 64))
+		if s.order.readBits(a, pos, chunk) != s.order.readBits(b, pos, chunk) {
+			return false
+		}
+	}
+	return true
+}
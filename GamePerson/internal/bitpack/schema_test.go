@@ -0,0 +1,293 @@
+package bitpack
+
+import (
+	"errors"
+	"slices"
+	"testing"
+)
+
+// ============ Тесты для Schema ============
+
+func newTestSchema(t *testing.T) *Schema {
+	t.Helper()
+	layout := NewLayout("stats", 96)
+	layout.AddUInt("health", 1000)                     // 0-9
+	layout.AddBiasedUInt("level", 1, 10)               // 10-13
+	layout.AddInt("delta", -4, 3)                      // 14-16
+	layout.AddBool("alive")                            // 17
+	layout.Reserve("future", 6)                        // 18-23
+	layout.AddFixed("speed", 0, 10, 0.5, RoundNearest) // 24-28
+	layout.AddArray("slots", 10, 31)                   // 29-78
+	schema, err := layout.Schema()
+	if err != nil {
+		t.Fatalf("Schema(): %v", err)
+	}
+	return schema
+}
+
+func TestSchemaDescriptors(t *testing.T) {
+	schema := newTestSchema(t)
+	tests := []struct {
+		name       string
+		kind       FieldKind
+		start, end BitPosition
+		width      int
+		min, max   int64
+	}{
+		{"health", FieldKindUInt, 0, 9, 10, 0, 1000},
+		{"level", FieldKindUInt, 10, 13, 4, 1, 10},
+		{"delta", FieldKindInt, 14, 16, 3, -4, 3},
+		{"alive", FieldKindBool, 17, 17, 1, 0, 1},
+		{"future", FieldKindReserved, 18, 23, 6, 0, 0},
+		{"speed", FieldKindFixed, 24, 28, 5, 0, 0},
+		{"slots", FieldKindArray, 29, 78, 50, 0, 31},
+	}
+
+	fields := schema.Fields()
+	if len(fields) != len(tests) {
+		t.Fatalf("Fields() = %d fields, want %d", len(fields), len(tests))
+	}
+	for i, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d := fields[i]
+			if d.Name != tt.name || d.Kind != tt.kind || d.Start != tt.start || d.End != tt.end ||
+				d.Width != tt.width || d.Min != tt.min || d.Max != tt.max {
+				t.Errorf("Fields()[%d] = %v, want %+v", i, d, tt)
+			}
+			if byName, ok := schema.Field(tt.name); !ok || byName.Start != tt.start {
+				t.Errorf("Field(%q) = %v, %v", tt.name, byName, ok)
+			}
+		})
+	}
+
+	if speed, _ := schema.Field("speed"); speed.FixedMax != 10 || speed.Step != 0.5 {
+		t.Errorf("speed = %v, want range [0,10] step 0.5", speed)
+	}
+	if slots, _ := schema.Field("slots"); slots.Len != 10 {
+		t.Errorf("slots.Len = %d, want 10", slots.Len)
+	}
+	if got := schema.UsedBits(); got != 79 {
+		t.Errorf("UsedBits() = %d, want 79", got)
+	}
+	if _, ok := schema.Field("missing"); ok {
+		t.Error("Field(missing): ok = true")
+	}
+}
+
+func TestSchemaGetSet(t *testing.T) {
+	schema := newTestSchema(t)
+	var packed Packed96
+
+	values := map[string]int64{"health": 999, "level": 10, "delta": -4, "alive": 1}
+	for name, value := range values {
+		if err := schema.Set(packed[:], name, value); err != nil {
+			t.Fatalf("Set(%s, %d): %v", name, value, err)
+		}
+	}
+	for name, want := range values {
+		if got, err := schema.Get(packed[:], name); err != nil || got != want {
+			t.Errorf("Get(%s) = %d, %v, want %d", name, got, err, want)
+		}
+	}
+	if err := schema.SetFixed(packed[:], "speed", 7.5); err != nil {
+		t.Fatalf("SetFixed: %v", err)
+	}
+	if got, err := schema.GetFixed(packed[:], "speed"); err != nil || got != 7.5 {
+		t.Errorf("GetFixed(speed) = %g, %v, want 7.5", got, err)
+	}
+
+	errTests := []struct {
+		name string
+		err  error
+		want error
+	}{
+		{"uint overflow", schema.Set(packed[:], "health", 1001), ErrValueOverflow},
+		{"uint negative", schema.Set(packed[:], "health", -1), ErrValueUnderflow},
+		{"biased underflow", schema.Set(packed[:], "level", 0), ErrValueUnderflow},
+		{"bool not 0/1", schema.Set(packed[:], "alive", 2), ErrValueOverflow},
+		{"unknown field", schema.Set(packed[:], "mana", 1), ErrUnknownField},
+		{"fixed via Set", schema.Set(packed[:], "speed", 1), ErrFieldKindMismatch},
+		{"uint via SetFixed", schema.SetFixed(packed[:], "health", 1), ErrFieldKindMismatch},
+		{"reserved", schema.Set(packed[:], "future", 0), ErrFieldKindMismatch},
+		{"short slice", schema.Set(packed[:1], "health", 1), ErrFieldOutOfSlice},
+	}
+	for _, tt := range errTests {
+		t.Run(tt.name, func(t *testing.T) {
+			if !errors.Is(tt.err, tt.want) {
+				t.Errorf("err = %v, want %v", tt.err, tt.want)
+			}
+			var bpErr *Error
+			if errors.As(tt.err, &bpErr) && (bpErr.Schema() != "stats" || bpErr.Field() == "") {
+				t.Errorf("err = %v, want field name of schema stats", tt.err)
+			}
+		})
+	}
+	if got, _ := schema.Get(packed[:], "health"); got != 999 {
+		t.Errorf("failed Set changed health to %d", got)
+	}
+}
+
+func TestSchemaValidate(t *testing.T) {
+	schema := newTestSchema(t)
+	var packed Packed96
+	if err := schema.Validate(packed[:]); err != nil {
+		t.Fatalf("Validate(zero) = %v", err)
+	}
+
+	// health хранится в битах 0-9 без проверки: 1023 > 1000
+	corrupt := packed
+	writeBits(corrupt[:], 0, 10, 1023)
+	err := schema.Validate(corrupt[:])
+	var bpErr *Error
+	if !errors.Is(err, ErrValueOverflow) || !errors.As(err, &bpErr) || bpErr.Field() != "health" {
+		t.Errorf("Validate(health=1023) = %v, want health overflow", err)
+	}
+
+	// уровень со смещением: сырое 10 означает 11 > 10
+	corrupt = packed
+	writeBits(corrupt[:], 10, 4, 10)
+	if err := schema.Validate(corrupt[:]); !errors.Is(err, ErrValueOverflow) {
+		t.Errorf("Validate(level=11) = %v, want overflow", err)
+	}
+
+	// дробное поле: 21 шаг при максимуме 20
+	corrupt = packed
+	writeBits(corrupt[:], 24, 5, 21)
+	if err := schema.Validate(corrupt[:]); !errors.Is(err, ErrFixedOutOfRange) {
+		t.Errorf("Validate(speed) = %v, want fixed out of range", err)
+	}
+
+	if err := schema.Validate(packed[:4]); !errors.Is(err, ErrFieldOutOfSlice) {
+		t.Errorf("Validate(short) = %v, want field out of slice", err)
+	}
+}
+
+func TestSchemaDiff(t *testing.T) {
+	schema := newTestSchema(t)
+	var a, b Packed96
+	_ = schema.Set(b[:], "delta", -1)
+	writeBits(b[:], 20, 1, 1) // резерв
+	writeBits(b[:], 70, 5, 3) // элемент slots, пересекающий границу 64 бит
+
+	got, err := schema.Diff(a[:], b[:])
+	if err != nil {
+		t.Fatalf("Diff: %v", err)
+	}
+	if want := []string{"delta", "future", "slots"}; !slices.Equal(got, want) {
+		t.Errorf("Diff() = %v, want %v", got, want)
+	}
+	if got, _ := schema.Diff(a[:], a[:]); len(got) != 0 {
+		t.Errorf("Diff(a, a) = %v, want empty", got)
+	}
+	if _, err := schema.Diff(a[:], b[:2]); !errors.Is(err, ErrFieldOutOfSlice) {
+		t.Errorf("Diff(short) err = %v, want field out of slice", err)
+	}
+}
+
+func TestSchemaNullable(t *testing.T) {
+	layout := NewLayout("stats", 16)
+	layout.AddNullableUInt("mana", 1000) // 0-9
+	layout.AddUInt("armor", 15)          // 10-13
+	schema := layout.MustSchema()
+
+	mana, _ := schema.Field("mana")
+	if mana.Kind != FieldKindUInt || !mana.Optional || mana.Min != 0 || mana.Max != 1000 || mana.Width != 10 {
+		t.Errorf("Field(mana) = %v, want nullable uint [0,1000] in 10 bits", mana)
+	}
+
+	var packed Packed16
+	if v, ok, err := schema.GetOptional(packed[:], "mana"); err != nil || ok || v != 0 {
+		t.Errorf("GetOptional(unset) = %d, %v, %v, want 0, false", v, ok, err)
+	}
+	if err := schema.Set(packed[:], "mana", 0); err != nil {
+		t.Fatalf("Set(mana, 0): %v", err)
+	}
+	if v, ok, err := schema.GetOptional(packed[:], "mana"); err != nil || !ok || v != 0 {
+		t.Errorf("GetOptional() = %d, %v, %v, want 0, true", v, ok, err)
+	}
+	if err := schema.Set(packed[:], "mana", 1001); !errors.Is(err, ErrValueOverflow) {
+		t.Errorf("Set(mana, 1001) = %v, want overflow", err)
+	}
+	if err := schema.Clear(packed[:], "mana"); err != nil || packed != (Packed16{}) {
+		t.Errorf("Clear(mana) = %v, buffer %x, want zero buffer", err, packed)
+	}
+	if err := schema.Clear(packed[:], "armor"); !errors.Is(err, ErrFieldKindMismatch) {
+		t.Errorf("Clear(armor) = %v, want kind mismatch", err)
+	}
+	if v, ok, err := schema.GetOptional(packed[:], "armor"); err != nil || !ok || v != 0 {
+		t.Errorf("GetOptional(armor) = %d, %v, %v, want 0, true", v, ok, err)
+	}
+
+	// код 1002 означает значение 1001 > 1000
+	writeBits(packed[:], 0, 10, 1002)
+	if err := schema.Validate(packed[:]); !errors.Is(err, ErrValueOverflow) {
+		t.Errorf("Validate(mana code 1002) = %v, want overflow", err)
+	}
+}
+
+func TestSchemaOptionalPresence(t *testing.T) {
+	layout := NewLayout("stats", 16)
+	layout.AddOptionalUInt("mana", 1000) // 0-9, manaPresent 10
+	delta := layout.AddInt("delta", -4, 3)
+	layout.AddIntPresence(delta) // 11-13, deltaPresent 14
+	schema := layout.MustSchema()
+
+	mana, _ := schema.Field("mana")
+	if mana.Kind != FieldKindUInt || !mana.Optional || mana.Max != 1000 {
+		t.Errorf("Field(mana) = %v, want optional uint [0,1000]", mana)
+	}
+
+	var packed Packed16
+	if err := schema.Set(packed[:], "mana", 0); err != nil {
+		t.Fatalf("Set(mana, 0): %v", err)
+	}
+	if v, ok, err := schema.GetOptional(packed[:], "mana"); err != nil || !ok || v != 0 {
+		t.Errorf("GetOptional(mana) = %d, %v, %v, want 0, true", v, ok, err)
+	}
+	if err := schema.Set(packed[:], "delta", -4); err != nil {
+		t.Fatalf("Set(delta, -4): %v", err)
+	}
+	if v, err := schema.Get(packed[:], "delta"); err != nil || v != -4 {
+		t.Errorf("Get(delta) = %d, %v, want -4", v, err)
+	}
+	if present, _ := schema.Get(packed[:], "deltaPresent"); present != 1 {
+		t.Errorf("deltaPresent = %d after Set(delta), want 1", present)
+	}
+	if err := schema.Set(packed[:], "mana", -1); !errors.Is(err, ErrValueUnderflow) {
+		t.Errorf("Set(mana, -1) = %v, want underflow", err)
+	}
+
+	if err := schema.Clear(packed[:], "mana"); err != nil {
+		t.Fatalf("Clear(mana): %v", err)
+	}
+	if err := schema.Clear(packed[:], "delta"); err != nil {
+		t.Fatalf("Clear(delta): %v", err)
+	}
+	if _, ok, err := schema.GetOptional(packed[:], "delta"); err != nil || ok {
+		t.Errorf("GetOptional(delta) after Clear = %v, %v, want not set", ok, err)
+	}
+	if packed != (Packed16{}) {
+		t.Errorf("buffer after Clear = %x, want zero", packed)
+	}
+
+	// значение optional поля проверяется так же, как у обычного: 1023 > 1000
+	writeBits(packed[:], 0, 10, 1023)
+	if err := schema.Validate(packed[:]); !errors.Is(err, ErrValueOverflow) {
+		t.Errorf("Validate(mana=1023) = %v, want overflow", err)
+	}
+
+	other := NewLayout("other", 8)
+	foreign := MustNewUIntBitField(0, 3, 15)
+	other.AddUIntPresence(foreign)
+	if err := other.Err(); !errors.Is(err, ErrUnknownField) {
+		t.Errorf("AddUIntPresence(foreign) err = %v, want unknown field", err)
+	}
+}
+
+func TestLayoutSchemaError(t *testing.T) {
+	layout := NewLayout("bad", 8)
+	layout.AddUInt("big", 1000)
+	if _, err := layout.Schema(); !errors.Is(err, ErrLayoutOverflow) {
+		t.Errorf("Schema() err = %v, want layout overflow", err)
+	}
+}
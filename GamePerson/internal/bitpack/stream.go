@@ -0,0 +1,426 @@
+package bitpack
+
+import "fmt"
+
+// =================  Writer / Reader =======================================
+// ============ Потоковая запись битов произвольной ширины ==================
+//
+// Writer дописывает значения в растущий буфер байтов без выравнивания:
+// 3-битное поле, флаг и 12-битное поле займут 16 бит. Reader читает их
+// в том же порядке. Биты внутри байта идут от младшего к старшему, как
+// в PackedN, поэтому выровненный поток совпадает с упакованным буфером.
+//
+//	w := bitpack.NewWriter(64)
+//	w.WriteBits(5, 3)
+//	w.WriteBool(true)
+//	w.WriteSigned(-42, 8)       // zig-zag: малые по модулю значения — малые коды
+//	w.WriteUVarint(100500)      // группы по 7 бит, как в encoding/binary
+//	w.WriteUIntField(healthField, hp) // с проверкой диапазона поля
+//	data := w.Bytes()
+//
+//	r := bitpack.NewReader(data)
+//	kind, err := r.ReadBits(3)
+//
+// WriteSchema и ReadSchema читают и пишут буфер в порядке байтов схемы
+// (Schema.WithOrder), а в поток кладут значения полей, поэтому поток
+// не зависит от порядка: буфер BigEndian можно прочитать в LittleEndian.
+//
+// Ошибки «залипают»: после первой ошибки Writer и Reader больше не
+// пишут и не читают, а возвращают её же (см. Err). Поэтому длинную
+// последовательность операций достаточно проверить один раз в конце.
+
+// varintMaxGroups — максимальное число 7-битных групп varint для uint64
+const varintMaxGroups = 10
+
+// ==================== Writer ====================
+
+type Writer struct {
+	buf  []byte
+	bits int // Количество записанных битов
+	err  error
+}
+
+// NewWriter создаёт Writer с буфером ёмкостью capacity байт.
+// Нулевое значение Writer тоже готово к работе.
+func NewWriter(capacity int) *Writer {
+	return &Writer{buf: make([]byte, 0, max(capacity, 0))}
+}
+
+// Bytes возвращает записанные байты; последний неполный байт дополнен нулями.
+// Срез принадлежит Writer и меняется при следующей записи.
+func (w *Writer) Bytes() []byte {
+	return w.buf
+}
+
+// Len — количество записанных битов
+func (w *Writer) Len() int {
+	return w.bits
+}
+
+// Err возвращает первую ошибку записи
+func (w *Writer) Err() error {
+	return w.err
+}
+
+// Reset очищает поток и ошибку, сохраняя выделенный буфер
+func (w *Writer) Reset() {
+	w.buf = w.buf[:0]
+	w.bits = 0
+	w.err = nil
+}
+
+// WriteBits записывает width (1..64) младших битов value.
+// Значение, не помещающееся в width битов, отклоняется с ErrValueOverflow.
+func (w *Writer) WriteBits(value uint64, width uint8) error {
+	if w.err != nil {
+		return w.err
+	}
+	if width == 0 || width > 64 {
+		return w.fail(newInvalidWidthError(width))
+	}
+	if allowedMax := maxAllowedForWidth(width); value > allowedMax {
+		return w.fail(newValueOverflowError(value, allowedMax, width))
+	}
+	w.put(value, int(width))
+	return nil
+}
+
+func (w *Writer) WriteBool(value bool) error {
+	var bit uint64
+	if value {
+		bit = 1
+	}
+	return w.WriteBits(bit, 1)
+}
+
+// WriteSigned записывает знаковое значение в zig-zag кодировке (0, -1, 1, -2 ... → 0, 1, 2, 3 ...).
+// В width битов помещается тот же диапазон, что и в IntBitField той же ширины.
+func (w *Writer) WriteSigned(value int64, width uint8) error {
+	if w.err != nil {
+		return w.err
+	}
+	if width == 0 || width > 64 {
+		return w.fail(newInvalidWidthError(width))
+	}
+	if lo, hi := intRangeForWidth(width); value < lo || value > hi {
+		return w.fail(newValueOutOfRangeError(value, value, lo, hi, width))
+	}
+	w.put(zigZagEncode(value), int(width))
+	return nil
+}
+
+// WriteUVarint записывает значение группами по 7 бит со старшим битом продолжения.
+// Выровненный varint совпадает с binary.AppendUvarint.
+func (w *Writer) WriteUVarint(value uint64) error {
+	if w.err != nil {
+		return w.err
+	}
+	for value >= 0x80 {
+		w.put(value&0x7F|0x80, 8)
+		value >>= 7
+	}
+	w.put(value, 8)
+	return nil
+}
+
+// WriteVarint записывает знаковое значение как zig-zag varint
+func (w *Writer) WriteVarint(value int64) error {
+	return w.WriteUVarint(zigZagEncode(value))
+}
+
+// Align дописывает нулевые биты до границы байта
+func (w *Writer) Align() {
+	if w.err == nil {
+		w.bits = 8 * len(w.buf)
+	}
+}
+
+// ==================== Writer: поля ====================
+
+// WriteUIntField проверяет значение по диапазону поля и записывает value-Min в Width() битов
+func (w *Writer) WriteUIntField(field UIntBitField, value uint64) error {
+	if w.err != nil {
+		return w.err
+	}
+	if err := field.checkValue(value); err != nil {
+		return w.fail(err)
+	}
+	w.put(value-field.Min, int(field.Width()))
+	return nil
+}
+
+// WriteIntField проверяет значение по диапазону поля и записывает его
+// в дополнительном коде шириной Width() битов
+func (w *Writer) WriteIntField(field IntBitField, value int64) error {
+	if w.err != nil {
+		return w.err
+	}
+	if err := field.checkValue(value); err != nil {
+		return w.fail(err)
+	}
+	w.put(uint64(value)&field.mask, int(field.Width()))
+	return nil
+}
+
+// WriteSchema записывает поля схемы из буфера по порядку позиций, пропуская резерв.
+// Буфер предварительно проверяется Schema.Validate; при ошибке поток не меняется.
+func (w *Writer) WriteSchema(s *Schema, packed []byte) error {
+	if w.err != nil {
+		return w.err
+	}
+	if err := s.Validate(packed); err != nil {
+		return w.fail(err)
+	}
+	for _, d := range s.fields {
+		if d.Reserved() {
+			continue
+		}
+		for offset := 0; offset < d.Width; offset += 64 {
+			chunk := min(d.Width-offset, 64)
+			w.put(s.order.readBits(packed, BitPosition(int(d.Start)+offset), uint8(chunk)), chunk)
+		}
+	}
+	return nil
+}
+
+// ------------- Сервисные методы --------------------------------
+
+// put дописывает width младших битов value; биты за w.bits в последнем байте нулевые
+func (w *Writer) put(value uint64, width int) {
+	for width > 0 {
+		shift := w.bits % 8
+		if shift == 0 {
+			w.buf = append(w.buf, 0)
+		}
+		n := min(8-shift, width)
+		w.buf[len(w.buf)-1] |= byte(value&computeMask(uint8(n))) << shift
+		value >>= n
+		width -= n
+		w.bits += n
+	}
+}
+
+// fail запоминает первую ошибку
+func (w *Writer) fail(err error) error {
+	if w.err == nil {
+		w.err = err
+	}
+	return err
+}
+
+// Строковое представление для отладки
+func (w *Writer) String() string {
+	return fmt.Sprintf("Writer[%d bits] err=%v", w.bits, w.err)
+}
+
+// ==================== Reader ====================
+
+type Reader struct {
+	data []byte
+	pos  int // Номер следующего бита
+	err  error
+}
+
+// NewReader создаёт Reader поверх data; данные не копируются
+func NewReader(data []byte) *Reader {
+	return &Reader{data: data}
+}
+
+// Offset — номер следующего читаемого бита
+func (r *Reader) Offset() int {
+	return r.pos
+}
+
+// Remaining — количество непрочитанных битов
+func (r *Reader) Remaining() int {
+	return 8*len(r.data) - r.pos
+}
+
+// Err возвращает первую ошибку чтения
+func (r *Reader) Err() error {
+	return r.err
+}
+
+// ReadBits читает width (1..64) битов.
+// Если в потоке меньше width битов, возвращается ErrStreamTruncated и позиция не меняется.
+func (r *Reader) ReadBits(width uint8) (uint64, error) {
+	if r.err != nil {
+		return 0, r.err
+	}
+	if width == 0 || width > 64 {
+		return 0, r.fail(newInvalidWidthError(width))
+	}
+	if err := r.need(int(width)); err != nil {
+		return 0, err
+	}
+	return r.take(int(width)), nil
+}
+
+func (r *Reader) ReadBool() (bool, error) {
+	bit, err := r.ReadBits(1)
+	return bit == 1, err
+}
+
+// ReadSigned читает zig-zag значение, записанное WriteSigned
+func (r *Reader) ReadSigned(width uint8) (int64, error) {
+	raw, err := r.ReadBits(width)
+	if err != nil {
+		return 0, err
+	}
+	return zigZagDecode(raw), nil
+}
+
+// ReadUVarint читает varint, записанный WriteUVarint.
+// Более 64 значащих битов — ErrVarintOverflow.
+func (r *Reader) ReadUVarint() (uint64, error) {
+	if r.err != nil {
+		return 0, r.err
+	}
+	start := r.pos
+	var value uint64
+	for i := 0; i < varintMaxGroups; i++ {
+		if err := r.need(8); err != nil {
+			r.pos = start
+			return 0, err
+		}
+		group := r.take(8)
+		if i == varintMaxGroups-1 && group > 1 {
+			r.pos = start
+			return 0, r.fail(newVarintOverflowError(start))
+		}
+		value |= (group & 0x7F) << (7 * i)
+		if group < 0x80 {
+			return value, nil
+		}
+	}
+	r.pos = start
+	return 0, r.fail(newVarintOverflowError(start))
+}
+
+// ReadVarint читает zig-zag varint, записанный WriteVarint
+func (r *Reader) ReadVarint() (int64, error) {
+	raw, err := r.ReadUVarint()
+	if err != nil {
+		return 0, err
+	}
+	return zigZagDecode(raw), nil
+}
+
+// Align пропускает биты до границы байта
+func (r *Reader) Align() {
+	if r.err == nil {
+		r.pos = (r.pos + 7) / 8 * 8
+	}
+}
+
+// ==================== Reader: поля ====================
+
+// ReadUIntField читает значение поля и проверяет его диапазон
+func (r *Reader) ReadUIntField(field UIntBitField) (uint64, error) {
+	if r.err != nil {
+		return 0, r.err
+	}
+	if err := r.need(int(field.Width())); err != nil {
+		return 0, field.label.annotate(err)
+	}
+	raw := r.take(int(field.Width()))
+	if raw > field.Max-field.Min {
+		return 0, r.fail(field.label.annotate(newValueOverflowError(raw+field.Min, field.Max, field.Width())))
+	}
+	return raw + field.Min, nil
+}
+
+// ReadIntField читает значение поля в дополнительном коде и проверяет его диапазон
+func (r *Reader) ReadIntField(field IntBitField) (int64, error) {
+	if r.err != nil {
+		return 0, r.err
+	}
+	if err := r.need(int(field.Width())); err != nil {
+		return 0, field.label.annotate(err)
+	}
+	value := field.signExtend(r.take(int(field.Width())))
+	if err := field.checkValue(value); err != nil {
+		return 0, r.fail(err)
+	}
+	return value, nil
+}
+
+// ReadSchema читает поля схемы, записанные WriteSchema, в буфер packed.
+// Значения проверяются Schema.Validate; при любой ошибке буфер не меняется,
+// а биты резерва сохраняют прежнее содержимое.
+func (r *Reader) ReadSchema(s *Schema, packed []byte) error {
+	if r.err != nil {
+		return r.err
+	}
+	if err := validatePacked(packed, BitPosition(s.bits-1)); err != nil {
+		return r.fail(err)
+	}
+	start := r.pos
+	scratch := make([]byte, len(packed))
+	copy(scratch, packed)
+	for _, d := range s.fields {
+		if d.Reserved() {
+			continue
+		}
+		if err := r.need(d.Width); err != nil {
+			r.pos = start
+			return newFieldLabel(s.name, d.Name).annotate(err)
+		}
+		for offset := 0; offset < d.Width; offset += 64 {
+			chunk := min(d.Width-offset, 64)
+			s.order.writeBits(scratch, BitPosition(int(d.Start)+offset), uint8(chunk), r.take(chunk))
+		}
+	}
+	if err := s.Validate(scratch); err != nil {
+		return r.fail(err)
+	}
+	copy(packed, scratch)
+	return nil
+}
+
+// ------------- Сервисные методы --------------------------------
+
+// need проверяет, что в потоке осталось не меньше width битов
+func (r *Reader) need(width int) error {
+	if width > r.Remaining() {
+		return r.fail(newStreamTruncatedError(width, r.pos, 8*len(r.data)))
+	}
+	return nil
+}
+
+// take читает width битов без проверки длины
+func (r *Reader) take(width int) uint64 {
+	var result uint64
+	for got := 0; got < width; {
+		shift := r.pos % 8
+		n := min(8-shift, width-got)
+		chunk := uint64(r.data[r.pos/8]>>shift) & computeMask(uint8(n))
+		result |= chunk << got
+		got += n
+		r.pos += n
+	}
+	return result
+}
+
+// fail запоминает первую ошибку
+func (r *Reader) fail(err error) error {
+	if r.err == nil {
+		r.err = err
+	}
+	return err
+}
+
+// Строковое представление для отладки
+func (r *Reader) String() string {
+	return fmt.Sprintf("Reader[%d/%d bits] err=%v", r.pos, 8*len(r.data), r.err)
+}
+
+// ==================== Zig-zag ====================
+
+func zigZagEncode(value int64) uint64 {
+	return uint64(value<<1) ^ uint64(value>>63)
+}
+
+func zigZagDecode(raw uint64) int64 {
+	return int64(raw>>1) ^ -int64(raw&1)
+}
@@ -0,0 +1,323 @@
+package bitpack
+
+import (
+	"encoding/binary"
+	"errors"
+	"math"
+	"math/rand"
+	"slices"
+	"strings"
+	"testing"
+)
+
+// ============ Тесты Writer / Reader ============
+
+func TestStreamRoundTrip(t *testing.T) {
+	w := NewWriter(0)
+	steps := []func() error{
+		func() error { return w.WriteBits(5, 3) },
+		func() error { return w.WriteBool(true) },
+		func() error { return w.WriteSigned(-42, 8) },
+		func() error { return w.WriteBits(math.MaxUint64, 64) },
+		func() error { return w.WriteUVarint(100500) },
+		func() error { return w.WriteVarint(-3) },
+		func() error { return w.WriteSigned(math.MinInt64, 64) },
+		func() error { return w.WriteBool(false) },
+	}
+	for i, step := range steps {
+		if err := step(); err != nil {
+			t.Fatalf("step %d: %v", i, err)
+		}
+	}
+	// 3 + 1 + 8 + 64 + 24 (varint 3 группы) + 8 + 64 + 1
+	if w.Len() != 173 || len(w.Bytes()) != 22 {
+		t.Fatalf("Len() = %d, len(Bytes()) = %d, want 173 bits in 22 bytes", w.Len(), len(w.Bytes()))
+	}
+
+	r := NewReader(w.Bytes())
+	if v, err := r.ReadBits(3); v != 5 || err != nil {
+		t.Errorf("ReadBits(3) = %d, %v", v, err)
+	}
+	if v, err := r.ReadBool(); !v || err != nil {
+		t.Errorf("ReadBool() = %v, %v", v, err)
+	}
+	if v, err := r.ReadSigned(8); v != -42 || err != nil {
+		t.Errorf("ReadSigned(8) = %d, %v", v, err)
+	}
+	if v, err := r.ReadBits(64); v != math.MaxUint64 || err != nil {
+		t.Errorf("ReadBits(64) = %d, %v", v, err)
+	}
+	if v, err := r.ReadUVarint(); v != 100500 || err != nil {
+		t.Errorf("ReadUVarint() = %d, %v", v, err)
+	}
+	if v, err := r.ReadVarint(); v != -3 || err != nil {
+		t.Errorf("ReadVarint() = %d, %v", v, err)
+	}
+	if v, err := r.ReadSigned(64); v != math.MinInt64 || err != nil {
+		t.Errorf("ReadSigned(64) = %d, %v", v, err)
+	}
+	if v, err := r.ReadBool(); v || err != nil {
+		t.Errorf("ReadBool() = %v, %v", v, err)
+	}
+	if r.Offset() != 173 || r.Remaining() != 3 {
+		t.Errorf("Offset() = %d, Remaining() = %d, want 173 and 3", r.Offset(), r.Remaining())
+	}
+}
+
+func TestStreamRandomRoundTrip(t *testing.T) {
+	rng := rand.New(rand.NewSource(17))
+	type item struct {
+		value uint64
+		width uint8
+	}
+	items := make([]item, 500)
+	w := NewWriter(0)
+	for i := range items {
+		width := uint8(1 + rng.Intn(64))
+		items[i] = item{rng.Uint64() & computeMask(width), width}
+		if err := w.WriteBits(items[i].value, width); err != nil {
+			t.Fatalf("WriteBits: %v", err)
+		}
+	}
+
+	r := NewReader(w.Bytes())
+	for i, it := range items {
+		if v, err := r.ReadBits(it.width); v != it.value || err != nil {
+			t.Fatalf("item %d: ReadBits(%d) = %#x, %v, want %#x", i, it.width, v, err, it.value)
+		}
+	}
+}
+
+func TestZigZag(t *testing.T) {
+	tests := []struct {
+		value int64
+		raw   uint64
+	}{
+		{0, 0}, {-1, 1}, {1, 2}, {-2, 3}, {2, 4},
+		{math.MaxInt64, math.MaxUint64 - 1}, {math.MinInt64, math.MaxUint64},
+	}
+	for _, tt := range tests {
+		if got := zigZagEncode(tt.value); got != tt.raw {
+			t.Errorf("zigZagEncode(%d) = %d, want %d", tt.value, got, tt.raw)
+		}
+		if got := zigZagDecode(tt.raw); got != tt.value {
+			t.Errorf("zigZagDecode(%d) = %d, want %d", tt.raw, got, tt.value)
+		}
+	}
+}
+
+// TestStreamVarintMatchesBinary — выровненный varint совпадает с encoding/binary
+func TestStreamVarintMatchesBinary(t *testing.T) {
+	values := []uint64{0, 1, 127, 128, 300, 1 << 35, math.MaxUint64}
+	w := NewWriter(0)
+	var want []byte
+	for _, v := range values {
+		if err := w.WriteUVarint(v); err != nil {
+			t.Fatalf("WriteUVarint(%d): %v", v, err)
+		}
+		want = binary.AppendUvarint(want, v)
+	}
+	if !slices.Equal(w.Bytes(), want) {
+		t.Errorf("Bytes() = % x, want % x", w.Bytes(), want)
+	}
+}
+
+func TestStreamAlign(t *testing.T) {
+	w := NewWriter(0)
+	_ = w.WriteBits(0b101, 3)
+	w.Align()
+	w.Align() // на границе байта ничего не дописывает
+	_ = w.WriteBits(0xAB, 8)
+	if want := []byte{0b101, 0xAB}; !slices.Equal(w.Bytes(), want) {
+		t.Fatalf("Bytes() = % x, want % x", w.Bytes(), want)
+	}
+
+	r := NewReader(w.Bytes())
+	_, _ = r.ReadBits(3)
+	r.Align()
+	if v, err := r.ReadBits(8); v != 0xAB || err != nil {
+		t.Errorf("ReadBits after Align = %#x, %v", v, err)
+	}
+}
+
+func TestStreamWriteErrors(t *testing.T) {
+	field := MustNewUIntBitField(0, 3, 10).Named("stats", "level")
+	tests := []struct {
+		name  string
+		write func(w *Writer) error
+		want  error
+	}{
+		{"zero width", func(w *Writer) error { return w.WriteBits(0, 0) }, ErrInvalidWidth},
+		{"wide", func(w *Writer) error { return w.WriteBits(0, 65) }, ErrInvalidWidth},
+		{"value too wide", func(w *Writer) error { return w.WriteBits(8, 3) }, ErrValueOverflow},
+		{"signed overflow", func(w *Writer) error { return w.WriteSigned(128, 8) }, ErrValueOverflow},
+		{"signed underflow", func(w *Writer) error { return w.WriteSigned(-129, 8) }, ErrValueUnderflow},
+		{"field max", func(w *Writer) error { return w.WriteUIntField(field, 11) }, ErrValueOverflow},
+		{"int field", func(w *Writer) error {
+			return w.WriteIntField(MustNewIntBitField(0, 7, -5, 5), -6)
+		}, ErrValueUnderflow},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := NewWriter(0)
+			_ = w.WriteBits(1, 1)
+			err := tt.write(w)
+			if !errors.Is(err, tt.want) {
+				t.Fatalf("err = %v, want %v", err, tt.want)
+			}
+			if w.Len() != 1 {
+				t.Errorf("Len() = %d after error, want 1", w.Len())
+			}
+			// Ошибка залипает
+			if err := w.WriteBool(true); !errors.Is(err, tt.want) || w.Err() != err {
+				t.Errorf("write after error = %v, Err() = %v", err, w.Err())
+			}
+		})
+	}
+
+	w := NewWriter(0)
+	if err := w.WriteUIntField(field, 11); err == nil || err.Error() != "field stats.level: value 11 exceeds capacity of 4-bit field (max allowed: 10)" {
+		t.Errorf("err = %v, want named field error", err)
+	}
+	w.Reset()
+	if w.Err() != nil || w.Len() != 0 {
+		t.Errorf("Reset: Err() = %v, Len() = %d", w.Err(), w.Len())
+	}
+}
+
+func TestStreamReadErrors(t *testing.T) {
+	t.Run("truncated", func(t *testing.T) {
+		r := NewReader([]byte{0xFF})
+		if _, err := r.ReadBits(5); err != nil {
+			t.Fatalf("ReadBits(5): %v", err)
+		}
+		_, err := r.ReadBits(4)
+		if !errors.Is(err, ErrStreamTruncated) {
+			t.Fatalf("err = %v, want ErrStreamTruncated", err)
+		}
+		if r.Offset() != 5 {
+			t.Errorf("Offset() = %d after truncated read, want 5", r.Offset())
+		}
+		if err.Error() != "bit stream truncated: reading 4 bits at bit offset 5 of 8-bit stream" {
+			t.Errorf("message = %q", err.Error())
+		}
+		if _, err := r.ReadBits(1); !errors.Is(err, ErrStreamTruncated) {
+			t.Errorf("read after error = %v, want sticky ErrStreamTruncated", err)
+		}
+	})
+
+	t.Run("truncated varint", func(t *testing.T) {
+		r := NewReader([]byte{0x80, 0x80})
+		if _, err := r.ReadUVarint(); !errors.Is(err, ErrStreamTruncated) {
+			t.Errorf("err = %v, want ErrStreamTruncated", err)
+		}
+	})
+
+	t.Run("varint overflow", func(t *testing.T) {
+		data := slices.Repeat([]byte{0xFF}, 10)
+		data = append(data, 0x01)
+		r := NewReader(data)
+		if _, err := r.ReadUVarint(); !errors.Is(err, ErrVarintOverflow) {
+			t.Errorf("err = %v, want ErrVarintOverflow", err)
+		}
+	})
+
+	t.Run("field range", func(t *testing.T) {
+		field := MustNewUIntBitField(0, 3, 10).Named("stats", "level")
+		r := NewReader([]byte{0x0F})
+		if _, err := r.ReadUIntField(field); !errors.Is(err, ErrValueOverflow) || !strings.HasPrefix(err.Error(), "field stats.level:") {
+			t.Errorf("err = %v, want named ErrValueOverflow", err)
+		}
+	})
+
+	t.Run("invalid width", func(t *testing.T) {
+		if _, err := NewReader([]byte{0}).ReadBits(0); !errors.Is(err, ErrInvalidWidth) {
+			t.Errorf("err = %v, want ErrInvalidWidth", err)
+		}
+	})
+}
+
+func TestStreamFields(t *testing.T) {
+	level := MustNewBiasedUIntBitField(0, 5, 1, 50)
+	delta := MustNewIntBitField(6, 15, -300, 300)
+
+	w := NewWriter(0)
+	_ = w.WriteUIntField(level, 50)
+	_ = w.WriteIntField(delta, -300)
+	if err := w.Err(); err != nil {
+		t.Fatalf("write: %v", err)
+	}
+	// Поток полей подряд совпадает с упакованным буфером тех же полей
+	var packed Packed16
+	_ = SetUIntFieldAs(packed[:], level, uint64(50))
+	_ = SetIntFieldAs(packed[:], delta, int64(-300))
+	if !slices.Equal(w.Bytes(), packed[:]) {
+		t.Errorf("stream % x, packed % x", w.Bytes(), packed)
+	}
+
+	r := NewReader(w.Bytes())
+	if v, err := r.ReadUIntField(level); v != 50 || err != nil {
+		t.Errorf("ReadUIntField = %d, %v", v, err)
+	}
+	if v, err := r.ReadIntField(delta); v != -300 || err != nil {
+		t.Errorf("ReadIntField = %d, %v", v, err)
+	}
+}
+
+func TestStreamSchema(t *testing.T) {
+	l := NewLayout("unit", 96)
+	kind := l.AddUInt("kind", 5)
+	l.Reserve("pad", 5)
+	hp := l.AddBiasedUInt("hp", 1, 1000)
+	offset := l.AddInt("offset", -1<<39, 1<<39-1)
+	alive := l.AddBool("alive")
+	schema := l.MustSchema()
+
+	var src Packed96
+	_ = SetUIntFieldAs(src[:], kind, uint8(4))
+	_ = SetUIntFieldAs(src[:], hp, uint16(999))
+	_ = SetIntFieldAs(src[:], offset, int64(-123456789))
+	_ = SetBoolField(src[:], alive, true)
+
+	w := NewWriter(0)
+	_ = w.WriteBits(1, 1) // поток не обязан начинаться с границы байта
+	if err := w.WriteSchema(schema, src[:]); err != nil {
+		t.Fatalf("WriteSchema: %v", err)
+	}
+	if want := 1 + 3 + 10 + 40 + 1; w.Len() != want {
+		t.Errorf("Len() = %d, want %d (резерв не пишется)", w.Len(), want)
+	}
+
+	r := NewReader(w.Bytes())
+	_, _ = r.ReadBits(1)
+	var dst Packed96
+	if err := r.ReadSchema(schema, dst[:]); err != nil {
+		t.Fatalf("ReadSchema: %v", err)
+	}
+	if dst != src {
+		t.Errorf("ReadSchema = % x, want % x", dst, src)
+	}
+
+	t.Run("truncated keeps buffer", func(t *testing.T) {
+		r := NewReader(w.Bytes()[:4])
+		dst := Packed96{0xAA}
+		err := r.ReadSchema(schema, dst[:])
+		if !errors.Is(err, ErrStreamTruncated) {
+			t.Fatalf("err = %v, want ErrStreamTruncated", err)
+		}
+		if dst != (Packed96{0xAA}) {
+			t.Errorf("buffer changed on error: % x", dst)
+		}
+	})
+
+	t.Run("out of range", func(t *testing.T) {
+		bad := NewWriter(0)
+		_ = bad.WriteBits(7, 3) // kind > 5
+		_ = bad.WriteBits(0, 51)
+		err := NewReader(bad.Bytes()).ReadSchema(schema, dst[:])
+		if !errors.Is(err, ErrValueOverflow) {
+			t.Errorf("err = %v, want ErrValueOverflow", err)
+		}
+	})
+}
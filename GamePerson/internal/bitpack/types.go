@@ -13,6 +13,17 @@ type (
 	Packed48 [6]byte // 48 бит
 	Packed56 [7]byte // 56 бит
 	Packed64 [8]byte // 64 бита
+
+	// Многословные контейнеры: поля размещаются в любом месте буфера,
+	// в том числе на границе 64-битных слов (см. bit_pack.go)
+	Packed96  [12]byte // 96 бит
+	Packed128 [16]byte // 128 бит
+)
+
+// Максимальный размер упакованного буфера: позиция бита хранится в uint8
+const (
+	MaxPackedBits  = 256
+	MaxPackedBytes = MaxPackedBits / 8
 )
 
 type UnsignedInteger interface {
@@ -0,0 +1,249 @@
+package bitpack
+
+import "fmt"
+
+// =================  View ==================================================
+// ======== Пакетное чтение-изменение-запись упакованного буфера ============
+//
+// Каждый Set*Field распаковывает и заново упаковывает весь буфер. View
+// распаковывает буфер один раз, все Get/Set работают с BitSet64 в регистре,
+// а Commit записывает результат обратно одной упаковкой:
+//
+//	view, err := bitpack.NewView(packed[:])
+//	view.SetUIntUnchecked(healthField, 100)
+//	view.SetBool(houseField, true)
+//	if err := view.Commit(); err != nil { ... }
+//
+// Checked Set возвращают ошибку и запоминают первую из них (как Layout),
+// при ошибке Commit оставляет буфер без изменений.
+// View работает с буферами до 8 байт (одно слово BitSet64).
+
+type View struct {
+	packed []byte
+	bits   BitSet64
+	order  ByteOrder
+	err    error
+}
+
+// NewView распаковывает буфер (1..8 байт, little-endian) для пакетной работы с полями.
+// Для буфера big-endian используйте BigEndian.NewView.
+func NewView(packed []byte) (View, error) {
+	return LittleEndian.NewView(packed)
+}
+
+// NewView распаковывает буфер (1..8 байт) в заданном порядке байтов.
+// Commit записывает слово обратно в том же порядке.
+func (o ByteOrder) NewView(packed []byte) (View, error) {
+	if len(packed) == 0 {
+		return View{}, newSliceEmptyError()
+	}
+	if len(packed) > 8 {
+		return View{}, newViewTooLargeError(len(packed))
+	}
+	return View{packed: packed, bits: o.Unpack(packed), order: o}, nil
+}
+
+// MustNewView создаёт View или паникует, если размер буфера не подходит
+// Используется для PackedN типов, размер которых известен на этапе компиляции
+
+func MustNewView(packed []byte) View {
+	v, err := NewView(packed)
+	if err != nil {
+		panic(fmt.Sprintf("FATAL: invalid packed buffer for view [len=%d]: %v", len(packed), err))
+	}
+	return v
+}
+
+// Bits возвращает текущее (ещё не записанное) состояние слова
+func (v *View) Bits() BitSet64 {
+	return v.bits
+}
+
+// Err возвращает первую ошибку checked Set
+func (v *View) Err() error {
+	return v.err
+}
+
+// Commit записывает слово в буфер одной упаковкой.
+// Если checked Set вернул ошибку, буфер не меняется и возвращается эта ошибка.
+func (v *View) Commit() error {
+	if v.err != nil {
+		return v.err
+	}
+	v.order.Pack(v.packed, v.bits)
+	return nil
+}
+
+// Reset отбрасывает незаписанные изменения и ошибку, заново читая буфер
+func (v *View) Reset() {
+	v.bits = v.order.Unpack(v.packed)
+	v.err = nil
+}
+
+// ==================== Get ====================
+
+func (v *View) UInt(field UIntBitField) uint64 {
+	return field.Get(v.bits)
+}
+
+func (v *View) Int(field IntBitField) int64 {
+	return field.Get(v.bits)
+}
+
+func (v *View) Bool(field BoolBitField) bool {
+	return field.Get(v.bits)
+}
+
+func (v *View) Fixed(field FixedBitField) float64 {
+	return field.Get(v.bits)
+}
+
+// OptionalUInt возвращает значение optional поля и признак присутствия
+func (v *View) OptionalUInt(field OptionalUIntBitField) (uint64, bool) {
+	return field.GetOptional(v.bits)
+}
+
+// OptionalInt возвращает значение optional поля и признак присутствия
+func (v *View) OptionalInt(field OptionalIntBitField) (int64, bool) {
+	return field.GetOptional(v.bits)
+}
+
+// NullableUInt возвращает значение nullable поля и признак присутствия
+func (v *View) NullableUInt(field NullableUIntBitField) (uint64, bool) {
+	return field.GetOptional(v.bits)
+}
+
+// ==================== Set Unchecked версии ====================
+
+func (v *View) SetUIntUnchecked(field UIntBitField, value uint64) {
+	v.bits = field.UpdateUnchecked(v.bits, value)
+}
+
+func (v *View) SetIntUnchecked(field IntBitField, value int64) {
+	v.bits = field.UpdateUnchecked(v.bits, value)
+}
+
+func (v *View) SetBoolUnchecked(field BoolBitField, value bool) {
+	v.bits = field.UpdateUnchecked(v.bits, value)
+}
+
+func (v *View) SetFixedUnchecked(field FixedBitField, value float64) {
+	v.bits = field.UpdateUnchecked(v.bits, value)
+}
+
+func (v *View) SetOptionalUIntUnchecked(field OptionalUIntBitField, value uint64) {
+	v.bits = field.UpdateUnchecked(v.bits, value)
+}
+
+func (v *View) SetOptionalIntUnchecked(field OptionalIntBitField, value int64) {
+	v.bits = field.UpdateUnchecked(v.bits, value)
+}
+
+func (v *View) SetNullableUIntUnchecked(field NullableUIntBitField, value uint64) {
+	v.bits = field.UpdateUnchecked(v.bits, value)
+}
+
+// ClearOptionalUInt сбрасывает присутствие и обнуляет биты значения
+func (v *View) ClearOptionalUInt(field OptionalUIntBitField) {
+	v.bits = field.Clear(v.bits)
+}
+
+// ClearOptionalInt сбрасывает присутствие и обнуляет биты значения
+func (v *View) ClearOptionalInt(field OptionalIntBitField) {
+	v.bits = field.Clear(v.bits)
+}
+
+// ClearNullableUInt записывает код «не задано»
+func (v *View) ClearNullableUInt(field NullableUIntBitField) {
+	v.bits = field.Clear(v.bits)
+}
+
+// ==================== Set Checked версии ====================
+
+func (v *View) SetUInt(field UIntBitField, value uint64) error {
+	if err := v.validate(field.End, field.label); err != nil {
+		return err
+	}
+	bits, err := field.Update(v.bits, value)
+	return v.apply(bits, err)
+}
+
+func (v *View) SetInt(field IntBitField, value int64) error {
+	if err := v.validate(field.End, field.label); err != nil {
+		return err
+	}
+	bits, err := field.Update(v.bits, value)
+	return v.apply(bits, err)
+}
+
+func (v *View) SetBool(field BoolBitField, value bool) error {
+	if err := v.validate(field.Position, field.label); err != nil {
+		return err
+	}
+	bits, err := field.Update(v.bits, value)
+	return v.apply(bits, err)
+}
+
+func (v *View) SetFixed(field FixedBitField, value float64) error {
+	if err := v.validate(field.Field.End, field.Field.label); err != nil {
+		return err
+	}
+	bits, err := field.Update(v.bits, value)
+	return v.apply(bits, err)
+}
+
+func (v *View) SetOptionalUInt(field OptionalUIntBitField, value uint64) error {
+	if err := v.validate(max(field.Value.End, field.Present.Position), field.Value.label); err != nil {
+		return err
+	}
+	bits, err := field.Update(v.bits, value)
+	return v.apply(bits, err)
+}
+
+func (v *View) SetOptionalInt(field OptionalIntBitField, value int64) error {
+	if err := v.validate(max(field.Value.End, field.Present.Position), field.Value.label); err != nil {
+		return err
+	}
+	bits, err := field.Update(v.bits, value)
+	return v.apply(bits, err)
+}
+
+func (v *View) SetNullableUInt(field NullableUIntBitField, value uint64) error {
+	if err := v.validate(field.Code.End, field.Code.label); err != nil {
+		return err
+	}
+	bits, err := field.Update(v.bits, value)
+	return v.apply(bits, err)
+}
+
+// ------------- Сервисные методы --------------------------------
+
+// validate проверяет, что поле помещается в буфер View
+func (v *View) validate(end BitPosition, label *fieldLabel) error {
+	if int(end) >= 8*len(v.packed) {
+		return v.fail(label.annotate(newFieldOutOfSliceError(end, len(v.packed))))
+	}
+	return nil
+}
+
+// apply принимает результат Update: новое слово или ошибку
+func (v *View) apply(bits BitSet64, err error) error {
+	if err != nil {
+		return v.fail(err)
+	}
+	v.bits = bits
+	return nil
+}
+
+// fail запоминает первую ошибку
+func (v *View) fail(err error) error {
+	if v.err == nil {
+		v.err = err
+	}
+	return err
+}
+
+// Строковое представление для отладки
+func (v *View) String() string {
+	return fmt.Sprintf("View[%d bytes] bits=%#x err=%v", len(v.packed), uint64(v.bits), v.err)
+}
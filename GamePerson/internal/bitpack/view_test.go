@@ -0,0 +1,129 @@
+package bitpack
+
+import (
+	"errors"
+	"testing"
+)
+
+// ============ Тесты для View ============
+
+func TestNewViewErrors(t *testing.T) {
+	tests := []struct {
+		name   string
+		packed []byte
+		want   error
+	}{
+		{"empty", nil, ErrSliceEmpty},
+		{"too large", make([]byte, 9), ErrViewTooLarge},
+		{"one byte", make([]byte, 1), nil},
+		{"eight bytes", make([]byte, 8), nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := NewView(tt.packed)
+			if !errors.Is(err, tt.want) {
+				t.Errorf("err = %v, want %v", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestViewCommitWritesOnce(t *testing.T) {
+	packed := Packed32{0xFF}
+	view := MustNewView(packed[:])
+
+	view.SetUIntUnchecked(SequenceField, 1234)
+	if err := view.SetBool(LastFlag, true); err != nil {
+		t.Fatalf("SetBool: %v", err)
+	}
+	if err := view.SetUInt(PriorityField, 5); err != nil {
+		t.Fatalf("SetUInt: %v", err)
+	}
+
+	// До Commit буфер не меняется, но View видит новые значения
+	if packed != (Packed32{0xFF}) {
+		t.Errorf("buffer changed before Commit: %v", packed)
+	}
+	if got := view.UInt(SequenceField); got != 1234 {
+		t.Errorf("UInt() = %d, want 1234", got)
+	}
+
+	if err := view.Commit(); err != nil {
+		t.Fatalf("Commit: %v", err)
+	}
+	if got := GetUIntFieldAs[uint16](packed[:], SequenceField); got != 1234 {
+		t.Errorf("sequence = %d, want 1234", got)
+	}
+	if got := GetUIntFieldAs[uint8](packed[:], PriorityField); got != 5 {
+		t.Errorf("priority = %d, want 5", got)
+	}
+	if !GetBoolField(packed[:], LastFlag) || !GetBoolField(packed[:], EncryptedFlag) {
+		t.Error("flags must be set (EncryptedFlag is preserved from 0xFF)")
+	}
+}
+
+func TestViewCheckedErrorBlocksCommit(t *testing.T) {
+	var packed Packed16
+	view := MustNewView(packed[:])
+
+	if err := view.SetUInt(VersionField, 16); !errors.Is(err, ErrValueOverflow) {
+		t.Errorf("SetUInt(16) err = %v, want overflow", err)
+	}
+	if err := view.SetBool(LastFlag, true); !errors.Is(err, ErrFieldOutOfSlice) {
+		t.Errorf("SetBool(bit 31) on 16-bit view err = %v, want field out of slice", err)
+	}
+	if err := view.SetUInt(VersionField, 3); err != nil {
+		t.Fatalf("SetUInt(3): %v", err)
+	}
+
+	// Запоминается первая ошибка, буфер не меняется
+	if err := view.Commit(); !errors.Is(err, ErrValueOverflow) {
+		t.Errorf("Commit() err = %v, want first error (overflow)", err)
+	}
+	if packed != (Packed16{}) {
+		t.Errorf("buffer changed after failed Commit: %v", packed)
+	}
+
+	view.Reset()
+	if view.Err() != nil || view.UInt(VersionField) != 0 {
+		t.Error("Reset must drop pending changes and error")
+	}
+	view.SetIntUnchecked(MustNewIntBitField(4, 7, -8, 7), -3)
+	if err := view.Commit(); err != nil {
+		t.Fatalf("Commit after Reset: %v", err)
+	}
+	if got := GetIntFieldAs[int8](packed[:], MustNewIntBitField(4, 7, -8, 7)); got != -3 {
+		t.Errorf("int field = %d, want -3", got)
+	}
+}
+
+func TestViewFixedField(t *testing.T) {
+	var packed Packed16
+	speed := MustNewFixedBitField(0, 7, 0, 20, 0.1, RoundNearest)
+	view := MustNewView(packed[:])
+
+	if err := view.SetFixed(speed, 25); !errors.Is(err, ErrFixedOutOfRange) {
+		t.Errorf("SetFixed(25) err = %v, want out of range", err)
+	}
+	view.Reset()
+	if err := view.SetFixed(speed, 7.5); err != nil {
+		t.Fatalf("SetFixed: %v", err)
+	}
+	if err := view.Commit(); err != nil {
+		t.Fatalf("Commit: %v", err)
+	}
+	if got := GetFixedField(packed[:], speed); got != 7.5 {
+		t.Errorf("speed = %g, want 7.5", got)
+	}
+}
+
+func TestMustNewViewPanics(t *testing.T) {
+	defer func() {
+		if r := recover(); r == nil {
+			t.Error("expected panic but got none")
+		}
+	}()
+	var packed Packed96
+	MustNewView(packed[:])
+}
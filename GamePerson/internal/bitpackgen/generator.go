@@ -0,0 +1,529 @@
+package bitpackgen
+
+import (
+	"GamePerson/internal/bitpack"
+	"bytes"
+	"fmt"
+	"go/format"
+	"text/template"
+)
+
+// Generate строит исходный код пакета доступа к полям по описанию схемы.
+// source — имя файла описания, попадает в заголовок сгенерированного кода.
+func Generate(spec Spec, source string) ([]byte, error) {
+	if err := spec.validate(); err != nil {
+		return nil, err
+	}
+
+	fields, used, err := allocate(spec)
+	if err != nil {
+		return nil, err
+	}
+
+	data := templateData{
+		Spec:     spec,
+		Source:   source,
+		Fields:   fields,
+		Bits:     spec.Bits(),
+		Bytes:    plural(spec.Bits()/8, "байт", "байта", "байт"),
+		BitMap:   bitMap(spec, fields),
+		HasLimit: hasConstLimits(fields),
+		HasView:  spec.Bits() <= 64,
+		HasArith: hasArith(fields),
+		Optional: optionalFields(fields),
+		Reserved: spec.Bits() - used,
+	}
+
+	var buf bytes.Buffer
+	if err := fileTemplate.Execute(&buf, data); err != nil {
+		return nil, fmt.Errorf("failed to render %s: %w", spec.Layout, err)
+	}
+
+	src, err := format.Source(buf.Bytes())
+	if err != nil {
+		return nil, fmt.Errorf("generated code for %s is invalid: %w", spec.Layout, err)
+	}
+	return src, nil
+}
+
+// placedField — поле с позициями, вычисленными bitpack.Layout
+type placedField struct {
+	FieldSpec
+	Start   bitpack.BitPosition
+	End     bitpack.BitPosition
+	Width   uint8
+	Present bitpack.BitPosition // бит присутствия (только для optional)
+}
+
+type templateData struct {
+	Spec     Spec
+	Source   string
+	Fields   []placedField
+	Bits     int
+	Bytes    string
+	BitMap   []string
+	HasLimit bool
+	HasView  bool // View доступен только для буферов до 64 бит
+	HasArith bool
+	Optional []placedField // поля с битом присутствия
+	Reserved int           // свободные биты в конце контейнера
+}
+
+// allocate раскладывает поля тем же Layout, что и сгенерированный код во время работы,
+// и возвращает количество занятых битов
+func allocate(spec Spec) ([]placedField, int, error) {
+	layout := bitpack.NewLayout(spec.Layout, spec.Bits())
+	placed := make([]placedField, 0, len(spec.Fields))
+
+	for _, f := range spec.Fields {
+		p := placedField{FieldSpec: f}
+		switch f.Kind {
+		case KindUInt:
+			if f.Nullable {
+				bf := layout.AddNullableUInt(f.layoutName(), uint64(f.Max))
+				p.Start, p.End, p.Width = bf.Code.Start, bf.Code.End, bf.Code.Width()
+				break
+			}
+			bf := layout.AddBiasedUInt(f.layoutName(), uint64(f.Min), uint64(f.Max))
+			p.Start, p.End, p.Width = bf.Start, bf.End, bf.Width()
+		case KindInt:
+			bf := layout.AddInt(f.layoutName(), f.Min, f.Max)
+			p.Start, p.End, p.Width = bf.Start, bf.End, bf.Width()
+		case KindBool:
+			bf := layout.AddBool(f.layoutName())
+			p.Start, p.End, p.Width = bf.Position, bf.Position, 1
+		}
+		placed = append(placed, p)
+	}
+
+	// Биты присутствия идут после всех полей: добавление optional
+	// не сдвигает позиции уже упакованных данных
+	for i, f := range placed {
+		if f.Optional {
+			placed[i].Present = layout.AddBool(f.presentName()).Position
+		}
+	}
+
+	if err := layout.Err(); err != nil {
+		return nil, 0, fmt.Errorf("schema %s: %w", spec.Layout, err)
+	}
+	return placed, layout.UsedBits(), nil
+}
+
+// bitMap формирует строки карты битов для комментария схемы
+func bitMap(spec Spec, fields []placedField) []string {
+	lines := make([]string, 0, len(fields)+1)
+	used := 0
+	for _, f := range fields {
+		lines = append(lines, fmt.Sprintf("%s: %s (%s)", bitRange(int(f.Start), int(f.End)), f.doc(), f.capacity()))
+		used = int(f.End) + 1
+	}
+	for _, f := range fields {
+		if f.Optional {
+			lines = append(lines, fmt.Sprintf("%s: признак наличия: %s (1 бит)", bitRange(int(f.Present), int(f.Present)), f.doc()))
+			used = int(f.Present) + 1
+		}
+	}
+	if used < spec.Bits() {
+		lines = append(lines, fmt.Sprintf("%s: резерв (всегда 0)", bitRange(used, spec.Bits()-1)))
+	}
+	return lines
+}
+
+func bitRange(start, end int) string {
+	if start == end {
+		return fmt.Sprintf("Бит %d", start)
+	}
+	return fmt.Sprintf("Биты %d-%2d", start, end)
+}
+
+func (f placedField) doc() string {
+	if f.Doc != "" {
+		return f.Doc
+	}
+	return f.layoutName()
+}
+
+// capacity — описание вместимости поля: "10 бит, 0-1023 → покрывает 0-1000"
+func (f placedField) capacity() string {
+	width := pluralBits(int(f.Width))
+	switch f.Kind {
+	case KindUInt:
+		if f.Nullable {
+			return fmt.Sprintf("%s, 0 — не задано, 1-%d → покрывает %d-%d", width, uint64(1)<<f.Width-1, f.Min, f.Max)
+		}
+		return fmt.Sprintf("%s, %d-%d → покрывает %d-%d", width, f.Min, uint64(f.Min)+uint64(1)<<f.Width-1, f.Min, f.Max)
+	case KindInt:
+		lo, hi := -(int64(1) << (f.Width - 1)), int64(1)<<(f.Width-1)-1
+		return fmt.Sprintf("%s, %d..%d → покрывает %d..%d", width, lo, hi, f.Min, f.Max)
+	default:
+		return width
+	}
+}
+
+// pluralBits — "1 бит", "2 бита", "5 бит", "11 бит", "22 бита"
+func pluralBits(n int) string {
+	return plural(n, "бит", "бита", "бит")
+}
+
+// plural согласует число с существительным: one (1, 21), few (2-4, 22-24), many (остальные)
+func plural(n int, one, few, many string) string {
+	switch {
+	case n%100 >= 11 && n%100 <= 14:
+		return fmt.Sprintf("%d %s", n, many)
+	case n%10 == 1:
+		return fmt.Sprintf("%d %s", n, one)
+	case n%10 >= 2 && n%10 <= 4:
+		return fmt.Sprintf("%d %s", n, few)
+	default:
+		return fmt.Sprintf("%d %s", n, many)
+	}
+}
+
+func hasConstLimits(fields []placedField) bool {
+	for _, f := range fields {
+		if f.MinConst != "" || f.MaxConst != "" {
+			return true
+		}
+	}
+	return false
+}
+
+func optionalFields(fields []placedField) []placedField {
+	var optional []placedField
+	for _, f := range fields {
+		if f.Optional {
+			optional = append(optional, f)
+		}
+	}
+	return optional
+}
+
+func hasArith(fields []placedField) bool {
+	for _, f := range fields {
+		if f.Arith {
+			return true
+		}
+	}
+	return false
+}
+
+// Выражения, попадающие в сгенерированный код
+
+func (f placedField) maxExpr() string {
+	if f.MaxConst != "" {
+		return f.MaxConst
+	}
+	return fmt.Sprint(f.Max)
+}
+
+func (f placedField) minExpr() string {
+	if f.MinConst != "" {
+		return f.MinConst
+	}
+	return fmt.Sprint(f.Min)
+}
+
+// AddCall — вызов Layout для объявления поля
+func (f placedField) AddCall() string {
+	switch f.Kind {
+	case KindUInt:
+		if f.Nullable {
+			return fmt.Sprintf("layout.AddNullableUInt(%q, uint64(%s))", f.layoutName(), f.maxExpr())
+		}
+		if f.biased() {
+			return fmt.Sprintf("layout.AddBiasedUInt(%q, uint64(%s), uint64(%s))", f.layoutName(), f.minExpr(), f.maxExpr())
+		}
+		return fmt.Sprintf("layout.AddUInt(%q, uint64(%s))", f.layoutName(), f.maxExpr())
+	case KindInt:
+		return fmt.Sprintf("layout.AddInt(%q, int64(%s), int64(%s))", f.layoutName(), f.minExpr(), f.maxExpr())
+	default:
+		return fmt.Sprintf("layout.AddBool(%q)", f.layoutName())
+	}
+}
+
+// LimitChecks — индексы проверки актуальности лимитов (приём из stringer)
+func (f placedField) LimitChecks() []string {
+	var checks []string
+	if f.MinConst != "" {
+		checks = append(checks, fmt.Sprintf("%s - (%d)", f.MinConst, f.Min))
+	}
+	if f.MaxConst != "" {
+		checks = append(checks, fmt.Sprintf("%s - %d", f.MaxConst, f.Max))
+	}
+	return checks
+}
+
+func (f placedField) VarName() string     { return f.varName() }
+func (f placedField) LayoutName() string  { return f.layoutName() }
+func (f placedField) PresentVar() string  { return f.presentName() + "Field" }
+func (f placedField) OptionalVar() string { return f.layoutName() + "Optional" }
+func (f placedField) GoType() string      { return f.goType() }
+func (f placedField) IsBool() bool        { return f.Kind == KindBool }
+
+// RawType — тип значения в методах bitpack.View для вида поля
+func (f placedField) RawType() string {
+	switch f.Kind {
+	case KindUInt:
+		return "uint64"
+	case KindInt:
+		return "int64"
+	default:
+		return "bool"
+	}
+}
+
+// AccessorSuffix — суффикс функций bitpack для вида поля
+func (f placedField) AccessorSuffix() string {
+	switch {
+	case f.Nullable:
+		return "NullableUInt"
+	case f.Kind == KindUInt:
+		return "UInt"
+	case f.Kind == KindInt:
+		return "Int"
+	default:
+		return "Bool"
+	}
+}
+
+var fileTemplate = template.Must(template.New("bitpack").Parse(`// Code generated by bitpackgen from {{.Source}}; DO NOT EDIT.
+
+package {{.Spec.Package}}
+
+import (
+	"GamePerson/internal/bitpack"
+{{- range .Spec.Imports}}
+	"{{.}}"
+{{- end}}
+)
+
+// ================= Схема битовой упаковки {{.Spec.Title}} ========================
+//  В {{.Bits}} битах ({{.Bytes}}) храним:
+//
+{{- range .BitMap}}
+// {{.}}
+{{- end}}
+
+type {{.Spec.Packed}} = bitpack.{{.Spec.Packed}}
+
+// Порядок полей задан в {{.Source}}: Layout размещает их последовательно
+// и вычисляет ширину по диапазону значений.
+// MustSchema паникует при ошибке конфигурации — это баг схемы, а не runtime ошибка.
+var layout = bitpack.NewLayout({{printf "%q" .Spec.Layout}}, 8*len({{.Spec.Packed}}{}))
+
+var (
+{{- range .Fields}}
+	{{.VarName}} = {{.AddCall}}
+{{- end}}
+)
+{{- if .Optional}}
+
+// Биты присутствия optional полей размещаются после всех полей схемы
+var (
+{{- range .Optional}}
+	{{.OptionalVar}} = layout.Add{{.AccessorSuffix}}Presence({{.VarName}})
+	{{.PresentVar}} = {{.OptionalVar}}.Present
+{{- end}}
+)
+{{- end}}
+
+// schema — описание полей для инструментов, не знающих схему заранее
+var schema *bitpack.Schema
+
+func init() {
+{{- if .Reserved}}
+	// Свободные биты входят в описание схемы: раскладка покрывает весь контейнер
+	layout.Reserve("reserved", {{.Reserved}})
+{{- end}}
+	schema = layout.MustSchema()
+}
+
+// Schema возвращает описание полей схемы и доступ к ним по имени
+func Schema() *bitpack.Schema {
+	return schema
+}
+{{- range .Fields}}{{if .Export}}
+
+// {{.Name}}Field — описание поля {{.LayoutName}} для построения типов поверх схемы
+func {{.Name}}Field() bitpack.{{.AccessorSuffix}}BitField {
+	return {{.VarName}}
+}
+{{- end}}{{end}}
+{{- if .HasLimit}}
+
+// Ошибка компиляции "invalid array index" означает, что лимиты схемы
+// изменились и карта битов устарела. Перезапустите go generate.
+func _() {
+	var x [1]struct{}
+{{- range .Fields}}{{range .LimitChecks}}
+	_ = x[{{.}}]
+{{- end}}{{end}}
+}
+{{- end}}
+
+//  ------------ Геттеры промежуточного слоя из битов ---------------
+{{range .Fields}}
+func Get{{.Name}}(packed *{{$.Spec.Packed}}) {{.GoType}} {
+{{- if .IsBool}}
+	return bitpack.GetBoolField(packed[:], {{.VarName}})
+{{- else if .Nullable}}
+	value, _ := bitpack.GetNullableUIntFieldAs[{{.GoType}}](packed[:], {{.VarName}})
+	return value
+{{- else}}
+	return bitpack.Get{{.AccessorSuffix}}FieldAs[{{.GoType}}](packed[:], {{.VarName}})
+{{- end}}
+}
+{{- if .Optional}}
+
+// Get{{.Name}}Optional возвращает значение и признак наличия (0, false — не задано)
+func Get{{.Name}}Optional(packed *{{$.Spec.Packed}}) ({{.GoType}}, bool) {
+	return bitpack.GetOptional{{.AccessorSuffix}}FieldAs[{{.GoType}}](packed[:], {{.OptionalVar}})
+}
+{{- else if .Nullable}}
+
+// Get{{.Name}}Optional возвращает значение и признак наличия (0, false — не задано)
+func Get{{.Name}}Optional(packed *{{$.Spec.Packed}}) ({{.GoType}}, bool) {
+	return bitpack.GetNullableUIntFieldAs[{{.GoType}}](packed[:], {{.VarName}})
+}
+{{- end}}
+{{end}}
+// --------------- Сеттеры промежуточного слоя из битов (с проверкой и без) -----------------------
+{{range .Fields}}
+func Set{{.Name}}(packed *{{$.Spec.Packed}}, value {{.GoType}}) error {
+{{- if .IsBool}}
+	return bitpack.SetBoolField(packed[:], {{.VarName}}, value)
+{{- else if .Optional}}
+	return bitpack.SetOptional{{.AccessorSuffix}}FieldAs[{{.GoType}}](packed[:], {{.OptionalVar}}, value)
+{{- else}}
+	return bitpack.Set{{.AccessorSuffix}}FieldAs[{{.GoType}}](packed[:], {{.VarName}}, value)
+{{- end}}
+}
+func Set{{.Name}}Unchecked(packed *{{$.Spec.Packed}}, value {{.GoType}}) {
+{{- if .IsBool}}
+	bitpack.SetBoolFieldUnchecked(packed[:], {{.VarName}}, value)
+{{- else if .Optional}}
+	bitpack.SetOptional{{.AccessorSuffix}}FieldUncheckedAs[{{.GoType}}](packed[:], {{.OptionalVar}}, value)
+{{- else}}
+	bitpack.Set{{.AccessorSuffix}}FieldUncheckedAs[{{.GoType}}](packed[:], {{.VarName}}, value)
+{{- end}}
+}
+{{- if .Optional}}
+
+// Clear{{.Name}} сбрасывает признак наличия и обнуляет значение
+func Clear{{.Name}}(packed *{{$.Spec.Packed}}) {
+	bitpack.ClearOptional{{.AccessorSuffix}}Field(packed[:], {{.OptionalVar}})
+}
+{{- else if .Nullable}}
+
+// Clear{{.Name}} записывает код «не задано»
+func Clear{{.Name}}(packed *{{$.Spec.Packed}}) {
+	bitpack.ClearNullableUIntField(packed[:], {{.VarName}})
+}
+{{- end}}
+{{end}}
+{{- if .HasArith}}
+// --------------- Арифметика: checked (ошибка за границами) и Saturating (прижатие к границам) -----------------------
+//  Для optional и nullable полей незаданное значение считается нулём, успешная операция отмечает его наличие
+{{range .Fields}}{{if .Arith}}{{if .Optional}}
+func Add{{.Name}}(packed *{{$.Spec.Packed}}, delta {{.GoType}}) ({{.GoType}}, error) {
+	value, err := bitpack.Add{{.AccessorSuffix}}FieldAs(packed[:], {{.VarName}}, delta)
+	if err == nil {
+		bitpack.SetBoolFieldUnchecked(packed[:], {{.PresentVar}}, true)
+	}
+	return value, err
+}
+func Sub{{.Name}}(packed *{{$.Spec.Packed}}, delta {{.GoType}}) ({{.GoType}}, error) {
+	value, err := bitpack.Sub{{.AccessorSuffix}}FieldAs(packed[:], {{.VarName}}, delta)
+	if err == nil {
+		bitpack.SetBoolFieldUnchecked(packed[:], {{.PresentVar}}, true)
+	}
+	return value, err
+}
+func Add{{.Name}}Saturating(packed *{{$.Spec.Packed}}, delta {{.GoType}}) {{.GoType}} {
+	bitpack.SetBoolFieldUnchecked(packed[:], {{.PresentVar}}, true)
+	return bitpack.Add{{.AccessorSuffix}}FieldSaturatingAs(packed[:], {{.VarName}}, delta)
+}
+func Sub{{.Name}}Saturating(packed *{{$.Spec.Packed}}, delta {{.GoType}}) {{.GoType}} {
+	bitpack.SetBoolFieldUnchecked(packed[:], {{.PresentVar}}, true)
+	return bitpack.Sub{{.AccessorSuffix}}FieldSaturatingAs(packed[:], {{.VarName}}, delta)
+}
+{{else}}
+func Add{{.Name}}(packed *{{$.Spec.Packed}}, delta {{.GoType}}) ({{.GoType}}, error) {
+	return bitpack.Add{{.AccessorSuffix}}FieldAs(packed[:], {{.VarName}}, delta)
+}
+func Sub{{.Name}}(packed *{{$.Spec.Packed}}, delta {{.GoType}}) ({{.GoType}}, error) {
+	return bitpack.Sub{{.AccessorSuffix}}FieldAs(packed[:], {{.VarName}}, delta)
+}
+func Add{{.Name}}Saturating(packed *{{$.Spec.Packed}}, delta {{.GoType}}) {{.GoType}} {
+	return bitpack.Add{{.AccessorSuffix}}FieldSaturatingAs(packed[:], {{.VarName}}, delta)
+}
+func Sub{{.Name}}Saturating(packed *{{$.Spec.Packed}}, delta {{.GoType}}) {{.GoType}} {
+	return bitpack.Sub{{.AccessorSuffix}}FieldSaturatingAs(packed[:], {{.VarName}}, delta)
+}
+{{end}}{{end}}{{end}}
+{{- end}}
+{{- if .HasView}}
+// --------------- Пакетный доступ: одна распаковка, много Get/Set, один Commit -----------------------
+
+// View работает с распакованным словом в регистре. Commit записывает все изменения
+// одной упаковкой; после ошибки checked Set буфер остаётся без изменений.
+type View struct {
+	view bitpack.View
+}
+
+func NewView(packed *{{.Spec.Packed}}) View {
+	return View{view: bitpack.MustNewView(packed[:])}
+}
+
+func (v *View) Commit() error { return v.view.Commit() }
+func (v *View) Err() error    { return v.view.Err() }
+func (v *View) Reset()        { v.view.Reset() }
+{{range .Fields}}
+func (v *View) Get{{.Name}}() {{.GoType}} {
+{{- if .IsBool}}
+	return v.view.Bool({{.VarName}})
+{{- else if .Nullable}}
+	value, _ := v.view.NullableUInt({{.VarName}})
+	return {{.GoType}}(value)
+{{- else}}
+	return {{.GoType}}(v.view.{{.AccessorSuffix}}({{.VarName}}))
+{{- end}}
+}
+func (v *View) Set{{.Name}}(value {{.GoType}}) error {
+{{- if .IsBool}}
+	return v.view.SetBool({{.VarName}}, value)
+{{- else if .Optional}}
+	return v.view.SetOptional{{.AccessorSuffix}}({{.OptionalVar}}, {{.RawType}}(value))
+{{- else}}
+	return v.view.Set{{.AccessorSuffix}}({{.VarName}}, {{.RawType}}(value))
+{{- end}}
+}
+func (v *View) Set{{.Name}}Unchecked(value {{.GoType}}) {
+{{- if .IsBool}}
+	v.view.SetBoolUnchecked({{.VarName}}, value)
+{{- else if .Optional}}
+	v.view.SetOptional{{.AccessorSuffix}}Unchecked({{.OptionalVar}}, {{.RawType}}(value))
+{{- else}}
+	v.view.Set{{.AccessorSuffix}}Unchecked({{.VarName}}, {{.RawType}}(value))
+{{- end}}
+}
+{{- if .Optional}}
+func (v *View) Get{{.Name}}Optional() ({{.GoType}}, bool) {
+	value, ok := v.view.Optional{{.AccessorSuffix}}({{.OptionalVar}})
+	return {{.GoType}}(value), ok
+}
+func (v *View) Clear{{.Name}}() {
+	v.view.ClearOptional{{.AccessorSuffix}}({{.OptionalVar}})
+}
+{{- else if .Nullable}}
+func (v *View) Get{{.Name}}Optional() ({{.GoType}}, bool) {
+	value, ok := v.view.NullableUInt({{.VarName}})
+	return {{.GoType}}(value), ok
+}
+func (v *View) Clear{{.Name}}() {
+	v.view.ClearNullableUInt({{.VarName}})
+}
+{{- end}}
+{{end}}
+{{- end}}`))
@@ -0,0 +1,129 @@
+package bitpackgen
+
+import (
+	"bytes"
+	"errors"
+	"flag"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"GamePerson/internal/bitpack"
+)
+
+var update = flag.Bool("update", false, "перезаписать golden-файлы в testdata")
+
+// TestGenerateGolden сравнивает результат генерации с эталоном testdata/*.golden.
+// Обновление эталонов: go test ./internal/bitpackgen -update
+func TestGenerateGolden(t *testing.T) {
+	specs, err := filepath.Glob(filepath.Join("testdata", "*.yaml"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, path := range specs {
+		t.Run(filepath.Base(path), func(t *testing.T) {
+			data, err := os.ReadFile(path)
+			if err != nil {
+				t.Fatal(err)
+			}
+			spec, err := ParseSpec(data)
+			if err != nil {
+				t.Fatalf("ParseSpec: %v", err)
+			}
+			got, err := Generate(spec, filepath.Base(path))
+			if err != nil {
+				t.Fatalf("Generate: %v", err)
+			}
+
+			golden := strings.TrimSuffix(path, ".yaml") + ".golden"
+			if *update {
+				if err := os.WriteFile(golden, got, 0644); err != nil {
+					t.Fatal(err)
+				}
+			}
+			want, err := os.ReadFile(golden)
+			if err != nil {
+				t.Fatalf("read golden: %v (run with -update)", err)
+			}
+			if !bytes.Equal(got, want) {
+				t.Errorf("generated code differs from %s:\n%s", golden, got)
+			}
+		})
+	}
+}
+
+func TestGenerateBitMap(t *testing.T) {
+	spec := Spec{
+		Package: "p", Layout: "l", Packed: "Packed16",
+		Fields: []FieldSpec{
+			{Name: "Health", Kind: KindUInt, Max: 1000, Doc: "здоровье"},
+			{Name: "Flag", Kind: KindBool, Doc: "флаг"},
+		},
+	}
+	src, err := Generate(spec, "spec.yaml")
+	if err != nil {
+		t.Fatalf("Generate: %v", err)
+	}
+
+	for _, want := range []string{
+		"// Биты 0- 9: здоровье (10 бит, 0-1023 → покрывает 0-1000)",
+		"// Бит 10: флаг (1 бит)",
+		"// Биты 11-15: резерв (всегда 0)",
+		"func GetHealth(packed *Packed16) uint32",
+		"func SetFlagUnchecked(packed *Packed16, value bool)",
+	} {
+		if !strings.Contains(string(src), want) {
+			t.Errorf("generated code does not contain %q", want)
+		}
+	}
+}
+
+func TestGenerateLayoutErrors(t *testing.T) {
+	spec := Spec{
+		Package: "p", Layout: "tiny", Packed: "Packed8",
+		Fields: []FieldSpec{
+			{Name: "Big", Kind: KindUInt, Max: 1000},
+		},
+	}
+	_, err := Generate(spec, "spec.yaml")
+	if !errors.Is(err, bitpack.ErrLayoutOverflow) {
+		t.Errorf("Generate() error = %v, want layout overflow", err)
+	}
+}
+
+func TestParseSpecValidation(t *testing.T) {
+	tests := []struct {
+		name string
+		yaml string
+	}{
+		{"no package", "layout: l\npacked: Packed8\nfields: [{name: A, kind: bool}]"},
+		{"bad packed", "package: p\nlayout: l\npacked: Packed7\nfields: [{name: A, kind: bool}]"},
+		{"no fields", "package: p\nlayout: l\npacked: Packed8"},
+		{"unexported name", "package: p\nlayout: l\npacked: Packed8\nfields: [{name: a, kind: bool}]"},
+		{"unknown kind", "package: p\nlayout: l\npacked: Packed8\nfields: [{name: A, kind: float}]"},
+		{"inverted int", "package: p\nlayout: l\npacked: Packed8\nfields: [{name: A, kind: int, min: 3, max: -3}]"},
+		{"optional bool", "package: p\nlayout: l\npacked: Packed8\nfields: [{name: A, kind: bool, optional: true}]"},
+		{"nullable int", "package: p\nlayout: l\npacked: Packed8\nfields: [{name: A, kind: int, max: 3, nullable: true}]"},
+		{"nullable biased", "package: p\nlayout: l\npacked: Packed8\nfields: [{name: A, kind: uint, min: 1, max: 3, nullable: true}]"},
+		{"optional and nullable", "package: p\nlayout: l\npacked: Packed8\nfields: [{name: A, kind: uint, max: 3, optional: true, nullable: true}]"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := ParseSpec([]byte(tt.yaml)); err == nil {
+				t.Error("expected error but got nil")
+			}
+		})
+	}
+}
+
+func TestPluralBits(t *testing.T) {
+	tests := map[int]string{1: "1 бит", 2: "2 бита", 4: "4 бита", 5: "5 бит", 10: "10 бит", 12: "12 бит", 14: "14 бит", 22: "22 бита", 21: "21 бит"}
+	for n, want := range tests {
+		if got := pluralBits(n); got != want {
+			t.Errorf("pluralBits(%d) = %q, want %q", n, got, want)
+		}
+	}
+}
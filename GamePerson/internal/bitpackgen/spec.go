@@ -0,0 +1,170 @@
+// Package bitpackgen генерирует типизированные пакеты доступа к битовым полям
+// по YAML-описанию схемы.
+//
+// Из одного файла schema.yaml получается:
+//   - комментарий с картой битов (позиции вычисляет bitpack.Layout);
+//   - объявление Layout и полей схемы;
+//   - проверка актуальности лимитов из config на этапе компиляции;
+//   - функции GetX / SetX / SetXUnchecked для каждого поля;
+//   - AddX / SubX (checked и Saturating) для полей с arith: true;
+//   - бит присутствия, GetXOptional и ClearX для полей с optional: true;
+//   - GetXOptional и ClearX без бита присутствия для полей с nullable: true;
+//   - тип View для пакетного доступа (для схем до 64 бит).
+//
+// Генератор вызывается через go generate (см. cmd/bitpackgen).
+package bitpackgen
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"unicode"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Spec — описание схемы упаковки одного типа существ
+type Spec struct {
+	Package string      `yaml:"package"` // имя Go-пакета
+	Layout  string      `yaml:"layout"`  // имя схемы (person, monster ...)
+	Title   string      `yaml:"title"`   // описание для заголовка комментария
+	Packed  string      `yaml:"packed"`  // тип контейнера: Packed8 ... Packed64
+	Imports []string    `yaml:"imports"` // пакеты, нужные для выражений лимитов
+	Fields  []FieldSpec `yaml:"fields"`  // поля в порядке раскладки
+}
+
+// FieldSpec — описание одного поля схемы
+type FieldSpec struct {
+	Name     string `yaml:"name"`      // Go-имя поля: Health → GetHealth/SetHealth
+	Kind     string `yaml:"kind"`      // uint | int | bool
+	Type     string `yaml:"type"`      // Go-тип значения (по умолчанию uint32/int32)
+	Min      int64  `yaml:"min"`       // минимум (для uint хранится value-min)
+	Max      int64  `yaml:"max"`       // максимум (для uint и int)
+	MinConst string `yaml:"min_const"` // Go-выражение минимума, например config.MinDelta
+	MaxConst string `yaml:"max_const"` // Go-выражение максимума, например config.PersonMaxMana
+	Doc      string `yaml:"doc"`       // описание для карты битов
+	Export   bool   `yaml:"export"`    // экспортировать поле через функцию XField()
+	Arith    bool   `yaml:"arith"`     // генерировать AddX/SubX (checked и Saturating)
+	Optional bool   `yaml:"optional"`  // бит присутствия после всех полей схемы: GetXOptional/ClearX
+	Nullable bool   `yaml:"nullable"`  // «не задано» — код 0 в самом поле (только uint с min 0): GetXOptional/ClearX
+}
+
+const (
+	KindUInt = "uint"
+	KindInt  = "int"
+	KindBool = "bool"
+)
+
+var packedNamePattern = regexp.MustCompile(`^Packed(\d+)$`)
+
+// ParseSpec разбирает YAML-описание схемы и проверяет его
+func ParseSpec(data []byte) (Spec, error) {
+	var spec Spec
+	if err := yaml.Unmarshal(data, &spec); err != nil {
+		return Spec{}, fmt.Errorf("failed to parse schema spec: %w", err)
+	}
+	if err := spec.validate(); err != nil {
+		return Spec{}, err
+	}
+	return spec, nil
+}
+
+// Bits — размер контейнера в битах, вычисленный по имени PackedN
+func (s Spec) Bits() int {
+	m := packedNamePattern.FindStringSubmatch(s.Packed)
+	if m == nil {
+		return 0
+	}
+	bits, _ := strconv.Atoi(m[1])
+	return bits
+}
+
+func (s Spec) validate() error {
+	if s.Package == "" {
+		return fmt.Errorf("schema spec: package is required")
+	}
+	if s.Layout == "" {
+		return fmt.Errorf("schema spec: layout name is required")
+	}
+	if bits := s.Bits(); bits == 0 || bits%8 != 0 {
+		return fmt.Errorf("schema spec: packed type %q must be PackedN with N multiple of 8", s.Packed)
+	}
+	if len(s.Fields) == 0 {
+		return fmt.Errorf("schema spec: at least one field is required")
+	}
+
+	for i, f := range s.Fields {
+		if f.Name == "" || !unicode.IsUpper([]rune(f.Name)[0]) {
+			return fmt.Errorf("schema spec: field #%d: name %q must be an exported Go identifier", i, f.Name)
+		}
+		switch f.Kind {
+		case KindUInt:
+			if f.Min < 0 || f.Max < 0 {
+				return fmt.Errorf("schema spec: field %s: min and max must be >= 0", f.Name)
+			}
+			if f.Min > f.Max {
+				return fmt.Errorf("schema spec: field %s: min %d > max %d", f.Name, f.Min, f.Max)
+			}
+			if f.Nullable && f.biased() {
+				return fmt.Errorf("schema spec: field %s: nullable requires min 0", f.Name)
+			}
+		case KindInt:
+			if f.Min > f.Max {
+				return fmt.Errorf("schema spec: field %s: min %d > max %d", f.Name, f.Min, f.Max)
+			}
+		case KindBool:
+			if f.Arith {
+				return fmt.Errorf("schema spec: field %s: arith is not supported for bool", f.Name)
+			}
+			if f.Optional {
+				return fmt.Errorf("schema spec: field %s: optional is not supported for bool", f.Name)
+			}
+		default:
+			return fmt.Errorf("schema spec: field %s: unknown kind %q (want uint, int or bool)", f.Name, f.Kind)
+		}
+		if f.Nullable && f.Kind != KindUInt {
+			return fmt.Errorf("schema spec: field %s: nullable is supported only for uint", f.Name)
+		}
+		if f.Nullable && f.Optional {
+			return fmt.Errorf("schema spec: field %s: optional and nullable are mutually exclusive", f.Name)
+		}
+	}
+	return nil
+}
+
+// biased — беззнаковое поле с ненулевым минимумом (хранит value-min)
+func (f FieldSpec) biased() bool {
+	return f.Kind == KindUInt && (f.Min != 0 || f.MinConst != "")
+}
+
+// goType — Go-тип значения для аксессоров поля
+func (f FieldSpec) goType() string {
+	if f.Type != "" {
+		return f.Type
+	}
+	switch f.Kind {
+	case KindUInt:
+		return "uint32"
+	case KindInt:
+		return "int32"
+	default:
+		return "bool"
+	}
+}
+
+// layoutName — имя поля внутри Layout: NameSize → nameSize
+func (f FieldSpec) layoutName() string {
+	r := []rune(f.Name)
+	r[0] = unicode.ToLower(r[0])
+	return string(r)
+}
+
+// varName — имя переменной поля в сгенерированном пакете: NameSize → nameSizeField
+func (f FieldSpec) varName() string {
+	return f.layoutName() + "Field"
+}
+
+// presentName — имя бита присутствия внутри Layout: Mana → manaPresent
+func (f FieldSpec) presentName() string {
+	return f.layoutName() + "Present"
+}
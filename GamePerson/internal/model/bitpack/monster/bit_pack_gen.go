@@ -0,0 +1,206 @@
+// Code generated by bitpackgen from schema.yaml; DO NOT EDIT.
+
+package monsterbitpack
+
+import (
+	"GamePerson/internal/bitpack"
+	"GamePerson/internal/model/config"
+)
+
+// ================= Схема битовой упаковки для монстра ========================
+//  В 32 битах (4 байта) храним:
+//
+// Биты 0- 5: длина имени в символах (6 бит на символ) (6 бит, 0-63 → покрывает 0-56)
+// Биты 6-15: мана (10 бит, 0 — не задано, 1-1023 → покрывает 0-1000)
+// Биты 16-29: здоровье (14 бит, 0-16383 → покрывает 0-10000)
+// Бит 30: есть дом (1 бит)
+// Бит 31: резерв (всегда 0)
+
+type Packed32 = bitpack.Packed32
+
+// Порядок полей задан в schema.yaml: Layout размещает их последовательно
+// и вычисляет ширину по диапазону значений.
+// MustSchema паникует при ошибке конфигурации — это баг схемы, а не runtime ошибка.
+var layout = bitpack.NewLayout("monster", 8*len(Packed32{}))
+
+var (
+	nameSizeField = layout.AddUInt("nameSize", uint64(config.MaxNameChars))
+	manaField     = layout.AddNullableUInt("mana", uint64(config.MonsterMaxMana))
+	healthField   = layout.AddUInt("health", uint64(config.MonsterMaxHealth))
+	houseField    = layout.AddBool("house")
+)
+
+// schema — описание полей для инструментов, не знающих схему заранее
+var schema *bitpack.Schema
+
+func init() {
+	// Свободные биты входят в описание схемы: раскладка покрывает весь контейнер
+	layout.Reserve("reserved", 1)
+	schema = layout.MustSchema()
+}
+
+// Schema возвращает описание полей схемы и доступ к ним по имени
+func Schema() *bitpack.Schema {
+	return schema
+}
+
+// Ошибка компиляции "invalid array index" означает, что лимиты схемы
+// изменились и карта битов устарела. Перезапустите go generate.
+func _() {
+	var x [1]struct{}
+	_ = x[config.MaxNameChars-56]
+	_ = x[config.MonsterMaxMana-1000]
+	_ = x[config.MonsterMaxHealth-10000]
+}
+
+//  ------------ Геттеры промежуточного слоя из битов ---------------
+
+func GetNameSize(packed *Packed32) uint32 {
+	return bitpack.GetUIntFieldAs[uint32](packed[:], nameSizeField)
+}
+
+func GetMana(packed *Packed32) uint32 {
+	value, _ := bitpack.GetNullableUIntFieldAs[uint32](packed[:], manaField)
+	return value
+}
+
+// GetManaOptional возвращает значение и признак наличия (0, false — не задано)
+func GetManaOptional(packed *Packed32) (uint32, bool) {
+	return bitpack.GetNullableUIntFieldAs[uint32](packed[:], manaField)
+}
+
+func GetHealth(packed *Packed32) uint32 {
+	return bitpack.GetUIntFieldAs[uint32](packed[:], healthField)
+}
+
+func GetHouse(packed *Packed32) bool {
+	return bitpack.GetBoolField(packed[:], houseField)
+}
+
+// --------------- Сеттеры промежуточного слоя из битов (с проверкой и без) -----------------------
+
+func SetNameSize(packed *Packed32, value uint32) error {
+	return bitpack.SetUIntFieldAs[uint32](packed[:], nameSizeField, value)
+}
+func SetNameSizeUnchecked(packed *Packed32, value uint32) {
+	bitpack.SetUIntFieldUncheckedAs[uint32](packed[:], nameSizeField, value)
+}
+
+func SetMana(packed *Packed32, value uint32) error {
+	return bitpack.SetNullableUIntFieldAs[uint32](packed[:], manaField, value)
+}
+func SetManaUnchecked(packed *Packed32, value uint32) {
+	bitpack.SetNullableUIntFieldUncheckedAs[uint32](packed[:], manaField, value)
+}
+
+// ClearMana записывает код «не задано»
+func ClearMana(packed *Packed32) {
+	bitpack.ClearNullableUIntField(packed[:], manaField)
+}
+
+func SetHealth(packed *Packed32, value uint32) error {
+	return bitpack.SetUIntFieldAs[uint32](packed[:], healthField, value)
+}
+func SetHealthUnchecked(packed *Packed32, value uint32) {
+	bitpack.SetUIntFieldUncheckedAs[uint32](packed[:], healthField, value)
+}
+
+func SetHouse(packed *Packed32, value bool) error {
+	return bitpack.SetBoolField(packed[:], houseField, value)
+}
+func SetHouseUnchecked(packed *Packed32, value bool) {
+	bitpack.SetBoolFieldUnchecked(packed[:], houseField, value)
+}
+
+// --------------- Арифметика: checked (ошибка за границами) и Saturating (прижатие к границам) -----------------------
+//  Для optional и nullable полей незаданное значение считается нулём, успешная операция отмечает его наличие
+
+func AddMana(packed *Packed32, delta uint32) (uint32, error) {
+	return bitpack.AddNullableUIntFieldAs(packed[:], manaField, delta)
+}
+func SubMana(packed *Packed32, delta uint32) (uint32, error) {
+	return bitpack.SubNullableUIntFieldAs(packed[:], manaField, delta)
+}
+func AddManaSaturating(packed *Packed32, delta uint32) uint32 {
+	return bitpack.AddNullableUIntFieldSaturatingAs(packed[:], manaField, delta)
+}
+func SubManaSaturating(packed *Packed32, delta uint32) uint32 {
+	return bitpack.SubNullableUIntFieldSaturatingAs(packed[:], manaField, delta)
+}
+
+func AddHealth(packed *Packed32, delta uint32) (uint32, error) {
+	return bitpack.AddUIntFieldAs(packed[:], healthField, delta)
+}
+func SubHealth(packed *Packed32, delta uint32) (uint32, error) {
+	return bitpack.SubUIntFieldAs(packed[:], healthField, delta)
+}
+func AddHealthSaturating(packed *Packed32, delta uint32) uint32 {
+	return bitpack.AddUIntFieldSaturatingAs(packed[:], healthField, delta)
+}
+func SubHealthSaturating(packed *Packed32, delta uint32) uint32 {
+	return bitpack.SubUIntFieldSaturatingAs(packed[:], healthField, delta)
+}
+
+// --------------- Пакетный доступ: одна распаковка, много Get/Set, один Commit -----------------------
+
+// View работает с распакованным словом в регистре. Commit записывает все изменения
+// одной упаковкой; после ошибки checked Set буфер остаётся без изменений.
+type View struct {
+	view bitpack.View
+}
+
+func NewView(packed *Packed32) View {
+	return View{view: bitpack.MustNewView(packed[:])}
+}
+
+func (v *View) Commit() error { return v.view.Commit() }
+func (v *View) Err() error    { return v.view.Err() }
+func (v *View) Reset()        { v.view.Reset() }
+
+func (v *View) GetNameSize() uint32 {
+	return uint32(v.view.UInt(nameSizeField))
+}
+func (v *View) SetNameSize(value uint32) error {
+	return v.view.SetUInt(nameSizeField, uint64(value))
+}
+func (v *View) SetNameSizeUnchecked(value uint32) {
+	v.view.SetUIntUnchecked(nameSizeField, uint64(value))
+}
+
+func (v *View) GetMana() uint32 {
+	value, _ := v.view.NullableUInt(manaField)
+	return uint32(value)
+}
+func (v *View) SetMana(value uint32) error {
+	return v.view.SetNullableUInt(manaField, uint64(value))
+}
+func (v *View) SetManaUnchecked(value uint32) {
+	v.view.SetNullableUIntUnchecked(manaField, uint64(value))
+}
+func (v *View) GetManaOptional() (uint32, bool) {
+	value, ok := v.view.NullableUInt(manaField)
+	return uint32(value), ok
+}
+func (v *View) ClearMana() {
+	v.view.ClearNullableUInt(manaField)
+}
+
+func (v *View) GetHealth() uint32 {
+	return uint32(v.view.UInt(healthField))
+}
+func (v *View) SetHealth(value uint32) error {
+	return v.view.SetUInt(healthField, uint64(value))
+}
+func (v *View) SetHealthUnchecked(value uint32) {
+	v.view.SetUIntUnchecked(healthField, uint64(value))
+}
+
+func (v *View) GetHouse() bool {
+	return v.view.Bool(houseField)
+}
+func (v *View) SetHouse(value bool) error {
+	return v.view.SetBool(houseField, value)
+}
+func (v *View) SetHouseUnchecked(value bool) {
+	v.view.SetBoolUnchecked(houseField, value)
+}
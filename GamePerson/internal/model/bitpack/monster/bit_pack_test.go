@@ -0,0 +1,80 @@
+package monsterbitpack
+
+import (
+	"GamePerson/internal/bitpack"
+	"testing"
+)
+
+// TestLayoutMatchesDocumentedMap фиксирует раскладку 32 бит монстра,
+// вычисленную Layout, и проверяет её совпадение со схемой из комментария.
+func TestLayoutMatchesDocumentedMap(t *testing.T) {
+	tests := []struct {
+		name       string
+		start, end bitpack.BitPosition
+		wantStart  bitpack.BitPosition
+		wantEnd    bitpack.BitPosition
+	}{
+		{"nameSize", nameSizeField.Start, nameSizeField.End, 0, 5},
+		{"mana", manaField.Code.Start, manaField.Code.End, 6, 15},
+		{"health", healthField.Start, healthField.End, 16, 29},
+		{"house", houseField.Position, houseField.Position, 30, 30},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.start != tt.wantStart || tt.end != tt.wantEnd {
+				t.Errorf("%s = [%d:%d], want [%d:%d]", tt.name, tt.start, tt.end, tt.wantStart, tt.wantEnd)
+			}
+		})
+	}
+
+	if err := layout.Err(); err != nil {
+		t.Fatalf("layout error: %v", err)
+	}
+	if got := layout.UsedBits(); got != 32 {
+		t.Errorf("UsedBits() = %d, want 32 (bit 31 — резерв)", got)
+	}
+}
+
+func TestGetSetRoundTrip(t *testing.T) {
+	var packed Packed32
+
+	SetHealthUnchecked(&packed, 10000)
+	SetManaUnchecked(&packed, 1000)
+	SetHouseUnchecked(&packed, true)
+	if err := SetNameSize(&packed, 42); err != nil {
+		t.Fatalf("SetNameSize: %v", err)
+	}
+
+	if got := GetHealth(&packed); got != 10000 {
+		t.Errorf("GetHealth() = %d, want 10000", got)
+	}
+	if got := GetMana(&packed); got != 1000 {
+		t.Errorf("GetMana() = %d, want 1000", got)
+	}
+	if !GetHouse(&packed) {
+		t.Error("GetHouse() = false, want true")
+	}
+	if got := GetNameSize(&packed); got != 42 {
+		t.Errorf("GetNameSize() = %d, want 42", got)
+	}
+	if packed[3]&0x80 != 0 {
+		t.Error("reserved bit 31 must stay 0")
+	}
+
+	ClearMana(&packed)
+	if mana, ok := GetManaOptional(&packed); ok || GetHealth(&packed) != 10000 {
+		t.Errorf("after ClearMana: (%d, %v), health %d, want unset mana and health 10000", mana, ok, GetHealth(&packed))
+	}
+}
+
+// TestBitFieldCoverage проверяет покрытие 32 бит полями схемы без пропусков и пересечений
+func TestBitFieldCoverage(t *testing.T) {
+	cov := Schema().Coverage()
+	if !cov.OK() {
+		t.Fatalf("coverage problems: %s", cov)
+	}
+	if cov.Used != 31 || cov.Reserved != 1 || len(cov.Gaps) != 0 {
+		t.Errorf("coverage = %s, want 31 bits used and bit 31 reserved", cov)
+	}
+}
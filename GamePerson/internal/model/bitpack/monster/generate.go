@@ -0,0 +1,3 @@
+package monsterbitpack
+
+//go:generate go run GamePerson/cmd/bitpackgen -spec schema.yaml -out bit_pack_gen.go
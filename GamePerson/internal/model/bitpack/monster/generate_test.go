@@ -0,0 +1,33 @@
+package monsterbitpack
+
+import (
+	"GamePerson/internal/bitpackgen"
+	"bytes"
+	"os"
+	"testing"
+)
+
+// TestGeneratedCodeIsUpToDate падает, если bit_pack_gen.go не соответствует
+// schema.yaml. Исправление: go generate ./internal/model/bitpack/...
+func TestGeneratedCodeIsUpToDate(t *testing.T) {
+	data, err := os.ReadFile("schema.yaml")
+	if err != nil {
+		t.Fatalf("read schema: %v", err)
+	}
+	spec, err := bitpackgen.ParseSpec(data)
+	if err != nil {
+		t.Fatalf("parse schema: %v", err)
+	}
+	want, err := bitpackgen.Generate(spec, "schema.yaml")
+	if err != nil {
+		t.Fatalf("generate: %v", err)
+	}
+
+	got, err := os.ReadFile("bit_pack_gen.go")
+	if err != nil {
+		t.Fatalf("read generated file: %v", err)
+	}
+	if !bytes.Equal(got, want) {
+		t.Fatal("bit_pack_gen.go is stale: run go generate ./internal/model/bitpack/...")
+	}
+}
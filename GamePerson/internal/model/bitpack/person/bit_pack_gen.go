@@ -0,0 +1,437 @@
+// Code generated by bitpackgen from schema.yaml; DO NOT EDIT.
+
+package personbitpack
+
+import (
+	"GamePerson/internal/bitpack"
+	"GamePerson/internal/model/config"
+)
+
+// ================= Схема битовой упаковки для персонажа ========================
+//  В 48 битах (6 байт) храним:
+//
+// Биты 0- 5: длина имени в символах (6 бит на символ) (6 бит, 0-63 → покрывает 0-56)
+// Биты 6- 9: уважение (4 бита, 0-15 → покрывает 0-10)
+// Биты 10-13: сила (4 бита, 0-15 → покрывает 0-10)
+// Биты 14-17: опыт (4 бита, 0-15 → покрывает 0-10)
+// Биты 18-21: уровень (4 бита, 1-16 → покрывает 1-10)
+// Биты 22-23: тип игрока (2 бита, 0-3 → покрывает 0-3)
+// Бит 24: есть дом (1 бит)
+// Бит 25: есть оружие (1 бит)
+// Бит 26: есть семья (1 бит)
+// Биты 27-36: мана (10 бит, 0 — не задано, 1-1023 → покрывает 0-1000)
+// Биты 37-46: здоровье (10 бит, 0-1023 → покрывает 0-1000)
+// Бит 47: бит чётности записи (см. entity.RecordParity) (1 бит)
+
+type Packed48 = bitpack.Packed48
+
+// Порядок полей задан в schema.yaml: Layout размещает их последовательно
+// и вычисляет ширину по диапазону значений.
+// MustSchema паникует при ошибке конфигурации — это баг схемы, а не runtime ошибка.
+var layout = bitpack.NewLayout("person", 8*len(Packed48{}))
+
+var (
+	nameSizeField   = layout.AddUInt("nameSize", uint64(config.MaxNameChars))
+	respectField    = layout.AddUInt("respect", uint64(config.PersonMaxRespect))
+	strengthField   = layout.AddUInt("strength", uint64(config.PersonMaxStrength))
+	experienceField = layout.AddUInt("experience", uint64(config.PersonMaxExperience))
+	levelField      = layout.AddBiasedUInt("level", uint64(config.PersonMinLevel), uint64(config.PersonMaxLevel))
+	typeField       = layout.AddUInt("type", uint64(config.PersonMaxTypeIndex))
+	houseField      = layout.AddBool("house")
+	weaponField     = layout.AddBool("weapon")
+	familyField     = layout.AddBool("family")
+	manaField       = layout.AddNullableUInt("mana", uint64(config.PersonMaxMana))
+	healthField     = layout.AddUInt("health", uint64(config.PersonMaxHealth))
+	parityField     = layout.AddBool("parity")
+)
+
+// schema — описание полей для инструментов, не знающих схему заранее
+var schema *bitpack.Schema
+
+func init() {
+	schema = layout.MustSchema()
+}
+
+// Schema возвращает описание полей схемы и доступ к ним по имени
+func Schema() *bitpack.Schema {
+	return schema
+}
+
+// TypeField — описание поля type для построения типов поверх схемы
+func TypeField() bitpack.UIntBitField {
+	return typeField
+}
+
+// Ошибка компиляции "invalid array index" означает, что лимиты схемы
+// изменились и карта битов устарела. Перезапустите go generate.
+func _() {
+	var x [1]struct{}
+	_ = x[config.MaxNameChars-56]
+	_ = x[config.PersonMaxRespect-10]
+	_ = x[config.PersonMaxStrength-10]
+	_ = x[config.PersonMaxExperience-10]
+	_ = x[config.PersonMinLevel-(1)]
+	_ = x[config.PersonMaxLevel-10]
+	_ = x[config.PersonMaxTypeIndex-3]
+	_ = x[config.PersonMaxMana-1000]
+	_ = x[config.PersonMaxHealth-1000]
+}
+
+//  ------------ Геттеры промежуточного слоя из битов ---------------
+
+func GetNameSize(packed *Packed48) uint32 {
+	return bitpack.GetUIntFieldAs[uint32](packed[:], nameSizeField)
+}
+
+func GetRespect(packed *Packed48) uint32 {
+	return bitpack.GetUIntFieldAs[uint32](packed[:], respectField)
+}
+
+func GetStrength(packed *Packed48) uint32 {
+	return bitpack.GetUIntFieldAs[uint32](packed[:], strengthField)
+}
+
+func GetExperience(packed *Packed48) uint32 {
+	return bitpack.GetUIntFieldAs[uint32](packed[:], experienceField)
+}
+
+func GetLevel(packed *Packed48) uint32 {
+	return bitpack.GetUIntFieldAs[uint32](packed[:], levelField)
+}
+
+func GetType(packed *Packed48) uint32 {
+	return bitpack.GetUIntFieldAs[uint32](packed[:], typeField)
+}
+
+func GetHouse(packed *Packed48) bool {
+	return bitpack.GetBoolField(packed[:], houseField)
+}
+
+func GetWeapon(packed *Packed48) bool {
+	return bitpack.GetBoolField(packed[:], weaponField)
+}
+
+func GetFamily(packed *Packed48) bool {
+	return bitpack.GetBoolField(packed[:], familyField)
+}
+
+func GetMana(packed *Packed48) uint32 {
+	value, _ := bitpack.GetNullableUIntFieldAs[uint32](packed[:], manaField)
+	return value
+}
+
+// GetManaOptional возвращает значение и признак наличия (0, false — не задано)
+func GetManaOptional(packed *Packed48) (uint32, bool) {
+	return bitpack.GetNullableUIntFieldAs[uint32](packed[:], manaField)
+}
+
+func GetHealth(packed *Packed48) uint32 {
+	return bitpack.GetUIntFieldAs[uint32](packed[:], healthField)
+}
+
+func GetParity(packed *Packed48) bool {
+	return bitpack.GetBoolField(packed[:], parityField)
+}
+
+// --------------- Сеттеры промежуточного слоя из битов (с проверкой и без) -----------------------
+
+func SetNameSize(packed *Packed48, value uint32) error {
+	return bitpack.SetUIntFieldAs[uint32](packed[:], nameSizeField, value)
+}
+func SetNameSizeUnchecked(packed *Packed48, value uint32) {
+	bitpack.SetUIntFieldUncheckedAs[uint32](packed[:], nameSizeField, value)
+}
+
+func SetRespect(packed *Packed48, value uint32) error {
+	return bitpack.SetUIntFieldAs[uint32](packed[:], respectField, value)
+}
+func SetRespectUnchecked(packed *Packed48, value uint32) {
+	bitpack.SetUIntFieldUncheckedAs[uint32](packed[:], respectField, value)
+}
+
+func SetStrength(packed *Packed48, value uint32) error {
+	return bitpack.SetUIntFieldAs[uint32](packed[:], strengthField, value)
+}
+func SetStrengthUnchecked(packed *Packed48, value uint32) {
+	bitpack.SetUIntFieldUncheckedAs[uint32](packed[:], strengthField, value)
+}
+
+func SetExperience(packed *Packed48, value uint32) error {
+	return bitpack.SetUIntFieldAs[uint32](packed[:], experienceField, value)
+}
+func SetExperienceUnchecked(packed *Packed48, value uint32) {
+	bitpack.SetUIntFieldUncheckedAs[uint32](packed[:], experienceField, value)
+}
+
+func SetLevel(packed *Packed48, value uint32) error {
+	return bitpack.SetUIntFieldAs[uint32](packed[:], levelField, value)
+}
+func SetLevelUnchecked(packed *Packed48, value uint32) {
+	bitpack.SetUIntFieldUncheckedAs[uint32](packed[:], levelField, value)
+}
+
+func SetType(packed *Packed48, value uint32) error {
+	return bitpack.SetUIntFieldAs[uint32](packed[:], typeField, value)
+}
+func SetTypeUnchecked(packed *Packed48, value uint32) {
+	bitpack.SetUIntFieldUncheckedAs[uint32](packed[:], typeField, value)
+}
+
+func SetHouse(packed *Packed48, value bool) error {
+	return bitpack.SetBoolField(packed[:], houseField, value)
+}
+func SetHouseUnchecked(packed *Packed48, value bool) {
+	bitpack.SetBoolFieldUnchecked(packed[:], houseField, value)
+}
+
+func SetWeapon(packed *Packed48, value bool) error {
+	return bitpack.SetBoolField(packed[:], weaponField, value)
+}
+func SetWeaponUnchecked(packed *Packed48, value bool) {
+	bitpack.SetBoolFieldUnchecked(packed[:], weaponField, value)
+}
+
+func SetFamily(packed *Packed48, value bool) error {
+	return bitpack.SetBoolField(packed[:], familyField, value)
+}
+func SetFamilyUnchecked(packed *Packed48, value bool) {
+	bitpack.SetBoolFieldUnchecked(packed[:], familyField, value)
+}
+
+func SetMana(packed *Packed48, value uint32) error {
+	return bitpack.SetNullableUIntFieldAs[uint32](packed[:], manaField, value)
+}
+func SetManaUnchecked(packed *Packed48, value uint32) {
+	bitpack.SetNullableUIntFieldUncheckedAs[uint32](packed[:], manaField, value)
+}
+
+// ClearMana записывает код «не задано»
+func ClearMana(packed *Packed48) {
+	bitpack.ClearNullableUIntField(packed[:], manaField)
+}
+
+func SetHealth(packed *Packed48, value uint32) error {
+	return bitpack.SetUIntFieldAs[uint32](packed[:], healthField, value)
+}
+func SetHealthUnchecked(packed *Packed48, value uint32) {
+	bitpack.SetUIntFieldUncheckedAs[uint32](packed[:], healthField, value)
+}
+
+func SetParity(packed *Packed48, value bool) error {
+	return bitpack.SetBoolField(packed[:], parityField, value)
+}
+func SetParityUnchecked(packed *Packed48, value bool) {
+	bitpack.SetBoolFieldUnchecked(packed[:], parityField, value)
+}
+
+// --------------- Арифметика: checked (ошибка за границами) и Saturating (прижатие к границам) -----------------------
+//  Для optional и nullable полей незаданное значение считается нулём, успешная операция отмечает его наличие
+
+func AddRespect(packed *Packed48, delta uint32) (uint32, error) {
+	return bitpack.AddUIntFieldAs(packed[:], respectField, delta)
+}
+func SubRespect(packed *Packed48, delta uint32) (uint32, error) {
+	return bitpack.SubUIntFieldAs(packed[:], respectField, delta)
+}
+func AddRespectSaturating(packed *Packed48, delta uint32) uint32 {
+	return bitpack.AddUIntFieldSaturatingAs(packed[:], respectField, delta)
+}
+func SubRespectSaturating(packed *Packed48, delta uint32) uint32 {
+	return bitpack.SubUIntFieldSaturatingAs(packed[:], respectField, delta)
+}
+
+func AddStrength(packed *Packed48, delta uint32) (uint32, error) {
+	return bitpack.AddUIntFieldAs(packed[:], strengthField, delta)
+}
+func SubStrength(packed *Packed48, delta uint32) (uint32, error) {
+	return bitpack.SubUIntFieldAs(packed[:], strengthField, delta)
+}
+func AddStrengthSaturating(packed *Packed48, delta uint32) uint32 {
+	return bitpack.AddUIntFieldSaturatingAs(packed[:], strengthField, delta)
+}
+func SubStrengthSaturating(packed *Packed48, delta uint32) uint32 {
+	return bitpack.SubUIntFieldSaturatingAs(packed[:], strengthField, delta)
+}
+
+func AddExperience(packed *Packed48, delta uint32) (uint32, error) {
+	return bitpack.AddUIntFieldAs(packed[:], experienceField, delta)
+}
+func SubExperience(packed *Packed48, delta uint32) (uint32, error) {
+	return bitpack.SubUIntFieldAs(packed[:], experienceField, delta)
+}
+func AddExperienceSaturating(packed *Packed48, delta uint32) uint32 {
+	return bitpack.AddUIntFieldSaturatingAs(packed[:], experienceField, delta)
+}
+func SubExperienceSaturating(packed *Packed48, delta uint32) uint32 {
+	return bitpack.SubUIntFieldSaturatingAs(packed[:], experienceField, delta)
+}
+
+func AddMana(packed *Packed48, delta uint32) (uint32, error) {
+	return bitpack.AddNullableUIntFieldAs(packed[:], manaField, delta)
+}
+func SubMana(packed *Packed48, delta uint32) (uint32, error) {
+	return bitpack.SubNullableUIntFieldAs(packed[:], manaField, delta)
+}
+func AddManaSaturating(packed *Packed48, delta uint32) uint32 {
+	return bitpack.AddNullableUIntFieldSaturatingAs(packed[:], manaField, delta)
+}
+func SubManaSaturating(packed *Packed48, delta uint32) uint32 {
+	return bitpack.SubNullableUIntFieldSaturatingAs(packed[:], manaField, delta)
+}
+
+func AddHealth(packed *Packed48, delta uint32) (uint32, error) {
+	return bitpack.AddUIntFieldAs(packed[:], healthField, delta)
+}
+func SubHealth(packed *Packed48, delta uint32) (uint32, error) {
+	return bitpack.SubUIntFieldAs(packed[:], healthField, delta)
+}
+func AddHealthSaturating(packed *Packed48, delta uint32) uint32 {
+	return bitpack.AddUIntFieldSaturatingAs(packed[:], healthField, delta)
+}
+func SubHealthSaturating(packed *Packed48, delta uint32) uint32 {
+	return bitpack.SubUIntFieldSaturatingAs(packed[:], healthField, delta)
+}
+
+// --------------- Пакетный доступ: одна распаковка, много Get/Set, один Commit -----------------------
+
+// View работает с распакованным словом в регистре. Commit записывает все изменения
+// одной упаковкой; после ошибки checked Set буфер остаётся без изменений.
+type View struct {
+	view bitpack.View
+}
+
+func NewView(packed *Packed48) View {
+	return View{view: bitpack.MustNewView(packed[:])}
+}
+
+func (v *View) Commit() error { return v.view.Commit() }
+func (v *View) Err() error    { return v.view.Err() }
+func (v *View) Reset()        { v.view.Reset() }
+
+func (v *View) GetNameSize() uint32 {
+	return uint32(v.view.UInt(nameSizeField))
+}
+func (v *View) SetNameSize(value uint32) error {
+	return v.view.SetUInt(nameSizeField, uint64(value))
+}
+func (v *View) SetNameSizeUnchecked(value uint32) {
+	v.view.SetUIntUnchecked(nameSizeField, uint64(value))
+}
+
+func (v *View) GetRespect() uint32 {
+	return uint32(v.view.UInt(respectField))
+}
+func (v *View) SetRespect(value uint32) error {
+	return v.view.SetUInt(respectField, uint64(value))
+}
+func (v *View) SetRespectUnchecked(value uint32) {
+	v.view.SetUIntUnchecked(respectField, uint64(value))
+}
+
+func (v *View) GetStrength() uint32 {
+	return uint32(v.view.UInt(strengthField))
+}
+func (v *View) SetStrength(value uint32) error {
+	return v.view.SetUInt(strengthField, uint64(value))
+}
+func (v *View) SetStrengthUnchecked(value uint32) {
+	v.view.SetUIntUnchecked(strengthField, uint64(value))
+}
+
+func (v *View) GetExperience() uint32 {
+	return uint32(v.view.UInt(experienceField))
+}
+func (v *View) SetExperience(value uint32) error {
+	return v.view.SetUInt(experienceField, uint64(value))
+}
+func (v *View) SetExperienceUnchecked(value uint32) {
+	v.view.SetUIntUnchecked(experienceField, uint64(value))
+}
+
+func (v *View) GetLevel() uint32 {
+	return uint32(v.view.UInt(levelField))
+}
+func (v *View) SetLevel(value uint32) error {
+	return v.view.SetUInt(levelField, uint64(value))
+}
+func (v *View) SetLevelUnchecked(value uint32) {
+	v.view.SetUIntUnchecked(levelField, uint64(value))
+}
+
+func (v *View) GetType() uint32 {
+	return uint32(v.view.UInt(typeField))
+}
+func (v *View) SetType(value uint32) error {
+	return v.view.SetUInt(typeField, uint64(value))
+}
+func (v *View) SetTypeUnchecked(value uint32) {
+	v.view.SetUIntUnchecked(typeField, uint64(value))
+}
+
+func (v *View) GetHouse() bool {
+	return v.view.Bool(houseField)
+}
+func (v *View) SetHouse(value bool) error {
+	return v.view.SetBool(houseField, value)
+}
+func (v *View) SetHouseUnchecked(value bool) {
+	v.view.SetBoolUnchecked(houseField, value)
+}
+
+func (v *View) GetWeapon() bool {
+	return v.view.Bool(weaponField)
+}
+func (v *View) SetWeapon(value bool) error {
+	return v.view.SetBool(weaponField, value)
+}
+func (v *View) SetWeaponUnchecked(value bool) {
+	v.view.SetBoolUnchecked(weaponField, value)
+}
+
+func (v *View) GetFamily() bool {
+	return v.view.Bool(familyField)
+}
+func (v *View) SetFamily(value bool) error {
+	return v.view.SetBool(familyField, value)
+}
+func (v *View) SetFamilyUnchecked(value bool) {
+	v.view.SetBoolUnchecked(familyField, value)
+}
+
+func (v *View) GetMana() uint32 {
+	value, _ := v.view.NullableUInt(manaField)
+	return uint32(value)
+}
+func (v *View) SetMana(value uint32) error {
+	return v.view.SetNullableUInt(manaField, uint64(value))
+}
+func (v *View) SetManaUnchecked(value uint32) {
+	v.view.SetNullableUIntUnchecked(manaField, uint64(value))
+}
+func (v *View) GetManaOptional() (uint32, bool) {
+	value, ok := v.view.NullableUInt(manaField)
+	return uint32(value), ok
+}
+func (v *View) ClearMana() {
+	v.view.ClearNullableUInt(manaField)
+}
+
+func (v *View) GetHealth() uint32 {
+	return uint32(v.view.UInt(healthField))
+}
+func (v *View) SetHealth(value uint32) error {
+	return v.view.SetUInt(healthField, uint64(value))
+}
+func (v *View) SetHealthUnchecked(value uint32) {
+	v.view.SetUIntUnchecked(healthField, uint64(value))
+}
+
+func (v *View) GetParity() bool {
+	return v.view.Bool(parityField)
+}
+func (v *View) SetParity(value bool) error {
+	return v.view.SetBool(parityField, value)
+}
+func (v *View) SetParityUnchecked(value bool) {
+	v.view.SetBoolUnchecked(parityField, value)
+}
@@ -13,9 +13,9 @@ func TestBitFieldConfiguration(t *testing.T) {
 		max         uint64
 		expectPanic bool
 	}{
-		{"nameSizeField", bitNameStart, bitNameEnd, uint64(config.MaxNameLength), false},
-		{"respectField", bitRespectStart, bitRespectEnd, uint64(config.PersonMaxRespect), false},
-		{"manaField", bitManaStart, bitManaEnd, uint64(config.PersonMaxMana), false},
+		{"nameSizeField", nameSizeField.Start, nameSizeField.End, uint64(config.MaxNameChars), false},
+		{"respectField", respectField.Start, respectField.End, uint64(config.PersonMaxRespect), false},
+		{"manaField", manaField.Code.Start, manaField.Code.End, uint64(config.PersonMaxMana) + 1, false}, // код nullable поля
 		{"invalidField", 10, 5, 100, true}, // Start > End — должно паниковать
 	}
 
@@ -49,17 +49,18 @@ func TestBitFieldCoverage(t *testing.T) {
 		start bitpack.BitPosition
 		end   bitpack.BitPosition
 	}{
-		{"nameSize", bitNameStart, bitNameEnd},
-		{"respect", bitRespectStart, bitRespectEnd},
-		{"strength", bitStrengthStart, bitStrengthEnd},
-		{"experience", bitExperienceStart, bitExperienceEnd},
-		{"level", bitLevelStart, bitLevelEnd},
-		{"type", bitTypeStart, bitTypeEnd},
-		{"house", bitHousePos, bitHousePos},
-		{"weapon", bitWeaponPos, bitWeaponPos},
-		{"family", bitFamilyPos, bitFamilyPos},
-		{"mana", bitManaStart, bitManaEnd},
-		{"health", bitHealthStart, bitHealthEnd},
+		{"nameSize", nameSizeField.Start, nameSizeField.End},
+		{"respect", respectField.Start, respectField.End},
+		{"strength", strengthField.Start, strengthField.End},
+		{"experience", experienceField.Start, experienceField.End},
+		{"level", levelField.Start, levelField.End},
+		{"type", typeField.Start, typeField.End},
+		{"house", houseField.Position, houseField.Position},
+		{"weapon", weaponField.Position, weaponField.Position},
+		{"family", familyField.Position, familyField.Position},
+		{"mana", manaField.Code.Start, manaField.Code.End},
+		{"health", healthField.Start, healthField.End},
+		{"parity", parityField.Position, parityField.Position},
 	}
 
 	for _, f := range fields {
@@ -75,8 +76,8 @@ func TestBitFieldCoverage(t *testing.T) {
 		}
 	}
 
-	// Проверяем, что все биты 0-46 использованы (бит 47 — резерв)
-	for i := 0; i < 47; i++ {
+	// Проверяем, что все 48 бит использованы
+	for i := 0; i < 48; i++ {
 		if !used[i] {
 			t.Logf("Warning: bit %d is unused (reserved or gap)", i)
 		}
@@ -88,22 +89,23 @@ func TestBitFieldCoverage(t *testing.T) {
 func TestBitFieldCoverage2(t *testing.T) {
 	type bitRange struct {
 		name  string
-		start int
-		end   int
+		start bitpack.BitPosition
+		end   bitpack.BitPosition
 	}
 
 	ranges := []bitRange{
-		{"nameSize", bitNameStart, bitNameEnd},
-		{"respect", bitRespectStart, bitRespectEnd},
-		{"strength", bitStrengthStart, bitStrengthEnd},
-		{"experience", bitExperienceStart, bitExperienceEnd},
-		{"level", bitLevelStart, bitLevelEnd},
-		{"type", bitTypeStart, bitTypeEnd},
-		{"house", bitHousePos, bitHousePos},
-		{"weapon", bitWeaponPos, bitWeaponPos},
-		{"family", bitFamilyPos, bitFamilyPos},
-		{"mana", bitManaStart, bitManaEnd},
-		{"health", bitHealthStart, bitHealthEnd},
+		{"nameSize", nameSizeField.Start, nameSizeField.End},
+		{"respect", respectField.Start, respectField.End},
+		{"strength", strengthField.Start, strengthField.End},
+		{"experience", experienceField.Start, experienceField.End},
+		{"level", levelField.Start, levelField.End},
+		{"type", typeField.Start, typeField.End},
+		{"house", houseField.Position, houseField.Position},
+		{"weapon", weaponField.Position, weaponField.Position},
+		{"family", familyField.Position, familyField.Position},
+		{"mana", manaField.Code.Start, manaField.Code.End},
+		{"health", healthField.Start, healthField.End},
+		{"parity", parityField.Position, parityField.Position},
 	}
 
 	// Проверяем отсутствие пересечений
@@ -124,7 +126,7 @@ func TestBitFieldCoverage2(t *testing.T) {
 
 	// Проверяем, что все диапазоны в пределах 48 бит
 	for _, r := range ranges {
-		if r.start < 0 || r.end >= 48 {
+		if r.end >= 48 {
 			t.Errorf("Bit range %s[%d:%d] is out of 48-bit bounds",
 				r.name, r.start, r.end)
 		}
@@ -156,7 +158,7 @@ func TestSchemaInitialization(t *testing.T) {
 		{"experience", experienceField, 4},
 		{"level", levelField, 4},
 		{"type", typeField, 2},
-		{"mana", manaField, 10},
+		{"mana", manaField.Code, 10},
 		{"health", healthField, 10},
 	}
 
@@ -168,3 +170,189 @@ func TestSchemaInitialization(t *testing.T) {
 		})
 	}
 }
+
+// TestLayoutMatchesDocumentedMap фиксирует раскладку, вычисленную Layout.
+// Изменение позиций ломает совместимость с уже упакованными данными,
+// поэтому любое расхождение со схемой из комментария — ошибка.
+func TestLayoutMatchesDocumentedMap(t *testing.T) {
+	tests := []struct {
+		name       string
+		start, end bitpack.BitPosition
+		wantStart  bitpack.BitPosition
+		wantEnd    bitpack.BitPosition
+	}{
+		{"nameSize", nameSizeField.Start, nameSizeField.End, 0, 5},
+		{"respect", respectField.Start, respectField.End, 6, 9},
+		{"strength", strengthField.Start, strengthField.End, 10, 13},
+		{"experience", experienceField.Start, experienceField.End, 14, 17},
+		{"level", levelField.Start, levelField.End, 18, 21},
+		{"type", typeField.Start, typeField.End, 22, 23},
+		{"house", houseField.Position, houseField.Position, 24, 24},
+		{"weapon", weaponField.Position, weaponField.Position, 25, 25},
+		{"family", familyField.Position, familyField.Position, 26, 26},
+		{"mana", manaField.Code.Start, manaField.Code.End, 27, 36},
+		{"health", healthField.Start, healthField.End, 37, 46},
+		{"parity", parityField.Position, parityField.Position, 47, 47},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.start != tt.wantStart || tt.end != tt.wantEnd {
+				t.Errorf("%s = [%d:%d], want [%d:%d]", tt.name, tt.start, tt.end, tt.wantStart, tt.wantEnd)
+			}
+		})
+	}
+
+	if err := layout.Err(); err != nil {
+		t.Fatalf("layout error: %v", err)
+	}
+	if got := layout.UsedBits(); got != 48 {
+		t.Errorf("UsedBits() = %d, want 48", got)
+	}
+}
+
+func TestOptionalMana(t *testing.T) {
+	var packed Packed48
+	if _, ok := GetManaOptional(&packed); ok {
+		t.Error("GetManaOptional() on zero buffer: ok = true, want false")
+	}
+
+	if err := SetMana(&packed, 0); err != nil {
+		t.Fatalf("SetMana: %v", err)
+	}
+	if mana, ok := GetManaOptional(&packed); !ok || mana != 0 {
+		t.Errorf("GetManaOptional() = (%d, %v), want (0, true)", mana, ok)
+	}
+
+	SetHealthUnchecked(&packed, 1000)
+	AddManaSaturating(&packed, 50)
+	ClearMana(&packed)
+	if mana, ok := GetManaOptional(&packed); ok || bitpack.GetUIntFieldAs[uint32](packed[:], manaField.Code) != 0 {
+		t.Errorf("after ClearMana: (%d, %v), want unset and zero code bits", mana, ok)
+	}
+	if got := GetHealth(&packed); got != 1000 {
+		t.Errorf("ClearMana changed health: %d", got)
+	}
+}
+
+func TestSchemaRegistry(t *testing.T) {
+	schema := Schema()
+	if schema.Name() != "person" || schema.Bits() != 48 || schema.UsedBits() != 48 {
+		t.Fatalf("Schema() = %v, want person 48/48 bits", schema)
+	}
+
+	want := []string{"nameSize", "respect", "strength", "experience", "level", "type",
+		"house", "weapon", "family", "mana", "health", "parity"}
+	fields := schema.Fields()
+	if len(fields) != len(want) {
+		t.Fatalf("Fields() = %d fields, want %d", len(fields), len(want))
+	}
+	for i, name := range want {
+		if fields[i].Name != name {
+			t.Errorf("Fields()[%d] = %q, want %q", i, fields[i].Name, name)
+		}
+	}
+
+	var packed Packed48
+	if err := schema.Set(packed[:], "health", 750); err != nil {
+		t.Fatalf("Set(health): %v", err)
+	}
+	if got := GetHealth(&packed); got != 750 {
+		t.Errorf("GetHealth() = %d after Schema().Set, want 750", got)
+	}
+	SetLevelUnchecked(&packed, 7)
+	if got, err := schema.Get(packed[:], "level"); err != nil || got != 7 {
+		t.Errorf("Get(level) = %d, %v, want 7", got, err)
+	}
+	if err := schema.Validate(packed[:]); err != nil {
+		t.Errorf("Validate() = %v", err)
+	}
+}
+
+// taggedPerson повторяет схему person тегами bitpack
+type taggedPerson struct {
+	NameSize   uint8  `bitpack:"0:5,max=56"`
+	Respect    uint8  `bitpack:"6:9,max=10"`
+	Strength   uint8  `bitpack:"10:13,max=10"`
+	Experience uint8  `bitpack:"14:17,max=10"`
+	Level      uint8  `bitpack:"18:21,min=1,max=10"`
+	Type       uint8  `bitpack:"22:23,enum=0|1|2"`
+	House      bool   `bitpack:"24"`
+	Weapon     bool   `bitpack:"25"`
+	Family     bool   `bitpack:"26"`
+	Mana       uint16 `bitpack:"27:36,max=1001"` // код nullable маны: значение+1, 0 — не задана
+	Health     uint16 `bitpack:"37:46,max=1000"`
+}
+
+// TestMarshalMatchesGeneratedLayout проверяет, что Marshal по тегам
+// даёт те же байты, что и сгенерированные сеттеры
+func TestMarshalMatchesGeneratedLayout(t *testing.T) {
+	v := taggedPerson{NameSize: 3, Respect: 7, Strength: 10, Experience: 2, Level: 9, Type: 2,
+		Weapon: true, Family: true, Mana: 1000, Health: 1000}
+
+	var tagged Packed48
+	if err := bitpack.Marshal(&v, tagged[:]); err != nil {
+		t.Fatalf("Marshal: %v", err)
+	}
+
+	var generated Packed48
+	SetNameSizeUnchecked(&generated, 3)
+	SetRespectUnchecked(&generated, 7)
+	SetStrengthUnchecked(&generated, 10)
+	SetExperienceUnchecked(&generated, 2)
+	SetLevelUnchecked(&generated, 9)
+	SetTypeUnchecked(&generated, 2)
+	SetWeaponUnchecked(&generated, true)
+	SetFamilyUnchecked(&generated, true)
+	SetManaUnchecked(&generated, 999)
+	SetHealthUnchecked(&generated, 1000)
+	if tagged != generated {
+		t.Errorf("Marshal() = %x, generated setters = %x", tagged, generated)
+	}
+
+	var back taggedPerson
+	if err := bitpack.Unmarshal(generated[:], &back); err != nil || back != v {
+		t.Errorf("Unmarshal() = %+v, %v, want %+v", back, err, v)
+	}
+}
+
+// TestStreamPersonsFieldByField пишет несколько записей person подряд в битовый поток
+func TestStreamPersonsFieldByField(t *testing.T) {
+	records := make([]Packed48, 3)
+	for i := range records {
+		SetNameSizeUnchecked(&records[i], uint32(5+i))
+		SetLevelUnchecked(&records[i], uint32(1+i))
+		SetHealthUnchecked(&records[i], uint32(100*i))
+		SetManaUnchecked(&records[i], uint32(10*i))
+		SetFamilyUnchecked(&records[i], i%2 == 0)
+	}
+
+	w := bitpack.NewWriter(0)
+	_ = w.WriteUVarint(uint64(len(records)))
+	for i := range records {
+		if err := w.WriteSchema(Schema(), records[i][:]); err != nil {
+			t.Fatalf("WriteSchema(%d): %v", i, err)
+		}
+	}
+
+	r := bitpack.NewReader(w.Bytes())
+	count, err := r.ReadUVarint()
+	if err != nil || count != uint64(len(records)) {
+		t.Fatalf("ReadUVarint() = %d, %v", count, err)
+	}
+	for i := range records {
+		var got Packed48
+		if err := r.ReadSchema(Schema(), got[:]); err != nil {
+			t.Fatalf("ReadSchema(%d): %v", i, err)
+		}
+		if got != records[i] {
+			t.Errorf("record %d = %x, want %x", i, got, records[i])
+		}
+	}
+
+	var bad Packed48
+	SetLevelUnchecked(&bad, 15) // уровень вне [1, 10]
+	if err := bitpack.NewWriter(0).WriteSchema(Schema(), bad[:]); err == nil {
+		t.Error("WriteSchema() accepted out-of-range level")
+	}
+}
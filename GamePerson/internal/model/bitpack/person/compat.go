@@ -0,0 +1,12 @@
+package personbitpack
+
+// Аксессоры, переименованные при переходе на bitpackgen. Генератор строит
+// имена по полю схемы (NameSize → SetNameSize), прежние имена оставлены
+// обёртками, чтобы не ломать внешний код.
+
+// SetSizeName записывает длину имени.
+//
+// Deprecated: используйте SetNameSize.
+func SetSizeName(packed *Packed48, value uint32) error {
+	return SetNameSize(packed, value)
+}
@@ -9,7 +9,8 @@ const (
 )
 
 const (
-	MaxNameLength = 42
+	MaxNameLength = 42                    // Размер буфера имени в байтах
+	MaxNameChars  = MaxNameLength * 8 / 6 // 56 символов по 6 бит (см. entity.PackName)
 )
 
 // Лимиты персонажей
@@ -20,6 +21,7 @@ const (
 	PersonMaxRespect    uint32 = 10
 	PersonMaxStrength   uint32 = 10
 	PersonMaxExperience uint32 = 10
+	PersonMinLevel      uint32 = 1
 	PersonMaxLevel      uint32 = 10
 	PersonMaxTypeIndex  uint32 = 3
 )
@@ -0,0 +1,76 @@
+package entity
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math/bits"
+)
+
+// ================  Код целостности записи ===================================
+//
+// 64-байтовые записи существ хранят код целостности в битах, которые не
+// несут данных: у person — бит parity упаковки (бит 47 packed), у monster —
+// бывший паддинг из 2 байт. Код считается по всей записи без самого кода
+// и пересчитывается каждым сеттером, а Validate и IntegrityChecker
+// сообщают о расхождении ошибкой ErrChecksumMismatch.
+//
+//   - RecordParity — бит чётности: обнаруживает любое нечётное число изменённых битов
+//   - RecordCRC16  — CRC-16/CCITT-FALSE: обнаруживает любые ошибки до 3 битов
+//     и пакеты ошибок длиной до 16 битов
+
+// ErrChecksumMismatch — код целостности записи не совпадает с её содержимым
+var ErrChecksumMismatch = errors.New("record checksum mismatch")
+
+// NewChecksumError описывает расхождение сохранённого и вычисленного кода
+func NewChecksumError(stored, computed uint16) error {
+	return fmt.Errorf("%w: stored %#x, computed %#x", ErrChecksumMismatch, stored, computed)
+}
+
+// RecordTail — золото и координаты записи в little-endian для подсчёта кода
+func RecordTail(gold uint32, x, y, z int32) [16]byte {
+	var tail [16]byte
+	binary.LittleEndian.PutUint32(tail[0:], gold)
+	binary.LittleEndian.PutUint32(tail[4:], uint32(x))
+	binary.LittleEndian.PutUint32(tail[8:], uint32(y))
+	binary.LittleEndian.PutUint32(tail[12:], uint32(z))
+	return tail
+}
+
+// ParseRecordTail — обратное к RecordTail: золото и координаты из 16 байтов little-endian
+func ParseRecordTail(tail []byte) (gold uint32, x, y, z int32) {
+	gold = binary.LittleEndian.Uint32(tail[0:])
+	x = int32(binary.LittleEndian.Uint32(tail[4:]))
+	y = int32(binary.LittleEndian.Uint32(tail[8:]))
+	z = int32(binary.LittleEndian.Uint32(tail[12:]))
+	return gold, x, y, z
+}
+
+// RecordParity — чётность числа единичных битов во всех частях записи (0 или 1)
+func RecordParity(parts ...[]byte) uint8 {
+	var acc byte
+	for _, part := range parts {
+		for _, b := range part {
+			acc ^= b
+		}
+	}
+	return uint8(bits.OnesCount8(acc) & 1)
+}
+
+// RecordCRC16 — CRC-16/CCITT-FALSE (полином 0x1021, начальное значение 0xFFFF) по всем частям записи
+func RecordCRC16(parts ...[]byte) uint16 {
+	crc := uint16(0xFFFF)
+	for _, part := range parts {
+		for _, b := range part {
+			crc ^= uint16(b) << 8
+			for i := 0; i < 8; i++ {
+				if crc&0x8000 != 0 {
+					crc = crc<<1 ^ 0x1021
+				} else {
+					crc <<= 1
+				}
+			}
+		}
+	}
+	return crc
+}
@@ -14,6 +14,14 @@ func (ic *IntegrityChecker) Check(obj any, sourceFormat string) error {
 		Validate() error
 	}
 
+	// Код целостности проверяется первым: при повреждённой записи
+	// ошибки Validate по отдельным полям лишь следствие
+	if c, ok := obj.(Checksummed); ok {
+		if err := c.VerifyChecksum(); err != nil {
+			return fmt.Errorf("%s deserialized object failed checksum: %w", sourceFormat, err)
+		}
+	}
+
 	if v, ok := obj.(validatable); ok {
 		if err := v.Validate(); err != nil {
 			return fmt.Errorf("%s deserialized object failed integrity check: %w", sourceFormat, err)
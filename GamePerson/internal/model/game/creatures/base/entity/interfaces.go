@@ -28,16 +28,23 @@ type Wealthy interface {
 type Living interface {
 	Health() uint32
 	SetHealth(uint32) error
+	AddHealth(uint32) uint32  // лечение с насыщением до максимума, возвращает новое здоровье
+	TakeDamage(uint32) uint32 // урон с насыщением до нуля, возвращает новое здоровье
 }
 
 type Magical interface {
 	Mana() uint32
 	SetMana(uint32) error
+	AddMana(uint32) uint32        // с насыщением до максимума, возвращает новую ману
+	DrainMana(uint32) error       // ошибка, если маны не хватает (мана не меняется)
+	ManaOptional() (uint32, bool) // false — мана не задана (существо не владеет магией)
+	ClearMana()                   // сброс маны в «не задана»
 }
 
 type Experienced interface {
 	Experience() uint32
 	SetExperience(uint32) error
+	AddExperience(uint32) uint32 // с насыщением до максимума, возвращает новый опыт
 	Level() uint32
 	SetLevel(uint32) error
 }
@@ -97,3 +104,8 @@ type Combatant interface {
 type Validatable interface {
 	Validate() error
 }
+
+// Checksummed — запись с кодом целостности, который проверяет IntegrityChecker
+type Checksummed interface {
+	VerifyChecksum() error
+}
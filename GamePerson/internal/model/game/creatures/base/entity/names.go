@@ -0,0 +1,124 @@
+package entity
+
+import (
+	"GamePerson/internal/bitpack"
+	"errors"
+	"fmt"
+	"strings"
+	"unicode"
+)
+
+// ================  Упаковка имени по 6 бит на символ ========================
+//
+// Допустимые символы имени (буквы, цифры, пробел, '_' и '-') — 65 символов.
+// 63 самых частых кодируются 6 битами, код nameEscape и следующие 6 бит
+// задают редкий символ из nameEscaped. Так 42-байтовый буфер вмещает
+// до 56 символов (config.MaxNameChars), а длина имени в символах хранится
+// в битовом поле nameSize.
+//
+// Биты буфера после имени нулевые — UnpackName проверяет это как защиту
+// от мусора в буфере.
+
+const (
+	nameCodeBits = 6
+	nameEscape   = 1<<nameCodeBits - 1 // код 63: следующий код — индекс в nameEscaped
+)
+
+// nameAlphabet — символы с прямыми кодами 0..62
+const nameAlphabet = "ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz0123456789 "
+
+// nameEscaped — редкие символы, кодируемые nameEscape + индекс
+const nameEscaped = "_-"
+
+// PackName валидирует имя и упаковывает его в dst по 6 бит на символ.
+// Имя, не помещающееся в буфер, обрезается по последнему целому символу.
+// Возвращает валидированное (возможно обрезанное) имя.
+func PackName(dst []byte, name string) (string, error) {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return "", fmt.Errorf("name cannot be empty")
+	}
+
+	// КРИТИЧНО: обрезаем по вместимости буфера, валидируем только попавшие символы
+	w := bitpack.NewWriter(len(dst))
+	for i, r := range name {
+		code, escaped, err := nameCode(r)
+		if err != nil {
+			return "", err
+		}
+		width := nameCodeBits
+		if escaped {
+			width *= 2
+		}
+		if w.Len()+width > 8*len(dst) {
+			name = name[:i]
+			break
+		}
+		if escaped {
+			_ = w.WriteBits(nameEscape, nameCodeBits)
+		}
+		_ = w.WriteBits(uint64(code), nameCodeBits)
+	}
+
+	// Очищаем буфер и копируем
+	for i := range dst {
+		dst[i] = 0
+	}
+	copy(dst, w.Bytes())
+
+	return name, nil
+}
+
+// UnpackName распаковывает length символов из буфера, записанного PackName.
+// Ошибка — неизвестный код, имя не помещается в буфер или после имени есть ненулевые биты.
+func UnpackName(src []byte, length int) (string, error) {
+	r := bitpack.NewReader(src)
+	var b strings.Builder
+	b.Grow(length)
+	for i := 0; i < length; i++ {
+		code, err := r.ReadBits(nameCodeBits)
+		if err == nil && code == nameEscape {
+			code, err = r.ReadBits(nameCodeBits)
+			if err == nil && int(code) >= len(nameEscaped) {
+				err = fmt.Errorf("unknown escaped name code %d", code)
+			}
+			code += nameEscape
+		}
+		if err != nil {
+			return b.String(), fmt.Errorf("name character %d: %w", i, err)
+		}
+		b.WriteByte(nameChar(code))
+	}
+
+	// Остаток буфера после имени должен быть нулевым
+	for r.Remaining() > 0 {
+		if bits, _ := r.ReadBits(uint8(min(r.Remaining(), 64))); bits != 0 {
+			return b.String(), errors.New("name buffer contains garbage after name")
+		}
+	}
+	return b.String(), nil
+}
+
+// nameCode — код символа и признак кодирования через nameEscape
+func nameCode(r rune) (code int, escaped bool, err error) {
+	if r <= unicode.MaxASCII {
+		if i := strings.IndexByte(nameAlphabet, byte(r)); i >= 0 {
+			return i, false, nil
+		}
+		if i := strings.IndexByte(nameEscaped, byte(r)); i >= 0 {
+			return i, true, nil
+		}
+	}
+	return 0, false, fmt.Errorf(
+		"name must contain only ASCII letters (A-Z, a-z), digits, space, underscore or dash; got %q",
+		r,
+	)
+}
+
+// nameChar — символ по коду: 0..62 — nameAlphabet, 63+ — nameEscaped
+func nameChar(code uint64) byte {
+	if code < nameEscape {
+		return nameAlphabet[code]
+	}
+	return nameEscaped[code-nameEscape]
+}
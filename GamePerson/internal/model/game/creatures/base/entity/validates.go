@@ -3,55 +3,40 @@ package entity
 import (
 	"GamePerson/internal/model/config"
 	"fmt"
-	"strings"
-	"unicode"
 )
 
-// ValidateAndCopyName валидирует имя и копирует его в буфер.
-// Возвращает валидированное имя и ошибку
-func ValidateAndCopyName(dst []byte, name string) (string, error) {
-	name = strings.TrimSpace(name)
-	if name == "" {
-		return "", fmt.Errorf("name cannot be empty")
-	}
-
-	// КРИТИЧНО: обрезаем СРАЗУ, до валидации
-	maxLen := len(dst)
-	if len(name) > maxLen {
-		name = name[:maxLen]
+// ValidateCoordinate проверяет координату на выход за глобальные границы
+func ValidateCoordinate(axis string, value int32) error {
+	if value < config.MinCoord || value > config.MaxCoord {
+		return fmt.Errorf("%s coordinate %d out of range [%d, %d]",
+			axis, value, config.MinCoord, config.MaxCoord)
 	}
+	return nil
+}
 
-	// Теперь валидируем только обрезанную часть
-	for _, r := range name {
-		if r > unicode.MaxASCII || !isValidNameChar(r) {
-			return "", fmt.Errorf(
-				"name must contain only ASCII letters (A-Z, a-z), digits, space, underscore or dash; got %q",
-				r,
-			)
-		}
-	}
+// FieldError привязывает ошибку значения к полю сущности (имя как в DTO:
+// "name", "gold", "x" ...). Текст ошибки не меняется; имя поля нужно
+// построчным импортам, чтобы указать колонку (как bitpack.Error.Field
+// для битовых полей).
+type FieldError struct {
+	Name string
+	Err  error
+}
 
-	// Очищаем буфер и копируем
-	for i := range dst {
-		dst[i] = 0
-	}
-	copy(dst, name)
+// NewFieldError оборачивает err в FieldError поля name
+func NewFieldError(name string, err error) error {
+	return &FieldError{Name: name, Err: err}
+}
 
-	return name, nil
+func (e *FieldError) Error() string {
+	return e.Err.Error()
 }
 
-func isValidNameChar(r rune) bool {
-	return (r >= 'A' && r <= 'Z') ||
-		(r >= 'a' && r <= 'z') ||
-		(r >= '0' && r <= '9') ||
-		r == ' ' || r == '_' || r == '-'
+func (e *FieldError) Unwrap() error {
+	return e.Err
 }
 
-// ValidateCoordinate проверяет координату на выход за глобальные границы
-func ValidateCoordinate(axis string, value int32) error {
-	if value < config.MinCoord || value > config.MaxCoord {
-		return fmt.Errorf("%s coordinate %d out of range [%d, %d]",
-			axis, value, config.MinCoord, config.MaxCoord)
-	}
-	return nil
+// Field — имя поля, к которому относится ошибка
+func (e *FieldError) Field() string {
+	return e.Name
 }
@@ -0,0 +1,73 @@
+package record
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+)
+
+// ================  Двоичный формат записи ===================================
+//
+// Запись хранится байт в байт, как в памяти, после короткого заголовка:
+//
+//	4 байта  Magic "GPRC"
+//	1 байт   длина вида записи, затем байты вида ("person", "monster")
+//	4 байта  версия раскладки (Layout.Version), little-endian
+//	64 байта запись
+//
+// Персонаж занимает 79 байтов против ~270 байтов JSON. Заголовок не даёт
+// прочитать запись монстра как персонажа или запись старой схемы как новую.
+// Значения полей не проверяются — это задача Validate/IntegrityChecker.
+
+// Magic — сигнатура двоичной записи существа
+const Magic = "GPRC"
+
+var (
+	ErrBinaryMagic   = errors.New("not a creature record: bad magic")
+	ErrBinaryKind    = errors.New("binary record is for another creature kind")
+	ErrBinaryVersion = errors.New("binary record is for another layout version")
+	ErrBinarySize    = errors.New("binary record has wrong size")
+)
+
+// BinarySize — размер двоичной записи с заголовком
+func (l *Layout) BinarySize() int {
+	return len(Magic) + 1 + len(l.kind) + 4 + Size
+}
+
+// AppendBinary дописывает к dst заголовок и запись
+func (l *Layout) AppendBinary(dst []byte, rec *[Size]byte) []byte {
+	dst = append(dst, Magic...)
+	dst = append(dst, byte(len(l.kind)))
+	dst = append(dst, l.kind...)
+	dst = binary.LittleEndian.AppendUint32(dst, l.version)
+	return append(dst, rec[:]...)
+}
+
+// ParseBinary проверяет заголовок и возвращает запись
+func (l *Layout) ParseBinary(data []byte) ([Size]byte, error) {
+	var rec [Size]byte
+	if len(data) < len(Magic)+1 || string(data[:len(Magic)]) != Magic {
+		return rec, ErrBinaryMagic
+	}
+	data = data[len(Magic):]
+
+	kindLen := int(data[0])
+	if len(data) < 1+kindLen+4 {
+		return rec, fmt.Errorf("%w: truncated header", ErrBinarySize)
+	}
+	if kind := string(data[1 : 1+kindLen]); kind != l.kind {
+		return rec, fmt.Errorf("%w: got %q, want %q", ErrBinaryKind, kind, l.kind)
+	}
+	data = data[1+kindLen:]
+
+	if version := binary.LittleEndian.Uint32(data); version != l.version {
+		return rec, fmt.Errorf("%w: got %08x, want %08x", ErrBinaryVersion, version, l.version)
+	}
+	data = data[4:]
+
+	if len(data) != Size {
+		return rec, fmt.Errorf("%w: %d record bytes, want %d", ErrBinarySize, len(data), Size)
+	}
+	copy(rec[:], data)
+	return rec, nil
+}
@@ -0,0 +1,218 @@
+package record
+
+import (
+	"GamePerson/internal/bitpack"
+	"GamePerson/internal/model/game/creatures/base/entity"
+	"errors"
+	"fmt"
+)
+
+// ================  XOR-дельта между двумя записями ==========================
+//
+// Delta переносит только изменённые поля записи: маска Changed отмечает
+// поля раскладки, Values хранит XOR старых и новых битов этих полей подряд,
+// без выравнивания (битовый поток bitpack.Writer). Изменение здоровья
+// персонажа занимает 10 битов значения плюс заголовок.
+//
+// XOR применим только к той записи, от которой построена дельта, поэтому
+// дельта хранит CRC-16 исходной записи (Base), а также вид и версию
+// раскладки: Patch отвергает дельту для другой записи, другого вида
+// существ или другой версии схемы.
+//
+//	d := layout.Diff(&before, &after)
+//	data, _ := d.MarshalBinary()   // передаём по сети
+//	...
+//	var got record.Delta
+//	_ = got.UnmarshalBinary(data)
+//	patched, err := layout.Patch(before, got) // patched == after
+
+var (
+	ErrDeltaKind    = errors.New("delta is for another record kind")
+	ErrDeltaVersion = errors.New("delta is for another record layout version")
+	ErrDeltaBase    = errors.New("delta base does not match record")
+	ErrDeltaCorrupt = errors.New("delta is corrupt")
+)
+
+// Delta — разница двух записей одного вида
+type Delta struct {
+	Kind    string // Вид записи (Layout.Kind)
+	Version uint32 // Версия раскладки (Layout.Version)
+	Base    uint16 // CRC-16 записи, к которой применяется дельта
+	Changed uint64 // Бит i — изменилось i-е поле раскладки (в порядке битов записи)
+	Values  []byte // XOR старых и новых битов изменённых полей подряд
+}
+
+// Empty сообщает, что записи совпадают
+func (d Delta) Empty() bool {
+	return d.Changed == 0
+}
+
+// Diff строит дельту, переводящую запись from в запись to
+func (l *Layout) Diff(from, to *[Size]byte) Delta {
+	var diff [Size]byte
+	for i := range diff {
+		diff[i] = from[i] ^ to[i]
+	}
+
+	d := Delta{Kind: l.kind, Version: l.version, Base: entity.RecordCRC16(from[:])}
+	r := bitpack.NewReader(diff[:])
+	w := bitpack.NewWriter(Size)
+	chunks := make([]uint64, 0, Size/8)
+	for i, f := range l.fields {
+		chunks = chunks[:0]
+		changed := false
+		forChunks(f.Width, func(width uint8) {
+			v, _ := r.ReadBits(width)
+			chunks = append(chunks, v)
+			changed = changed || v != 0
+		})
+		if !changed {
+			continue
+		}
+		d.Changed |= 1 << i
+		forChunks(f.Width, func(width uint8) {
+			_ = w.WriteBits(chunks[0], width)
+			chunks = chunks[1:]
+		})
+	}
+	d.Values = append([]byte(nil), w.Bytes()...)
+	return d
+}
+
+// Patch применяет дельту к записи и возвращает новую запись; rec не меняется.
+// Проверка значений полей — забота вызывающего (Validate существа).
+func (l *Layout) Patch(rec [Size]byte, d Delta) ([Size]byte, error) {
+	if err := l.check(d); err != nil {
+		return rec, err
+	}
+	if base := entity.RecordCRC16(rec[:]); base != d.Base {
+		return rec, fmt.Errorf("%w: delta base %#04x, record %#04x", ErrDeltaBase, d.Base, base)
+	}
+
+	r := bitpack.NewReader(d.Values)
+	w := bitpack.NewWriter(Size)
+	for i, f := range l.fields {
+		changed := d.Changed&(1<<i) != 0
+		forChunks(f.Width, func(width uint8) {
+			var v uint64
+			if changed {
+				v, _ = r.ReadBits(width)
+			}
+			_ = w.WriteBits(v, width)
+		})
+	}
+	if err := r.Err(); err != nil {
+		return rec, fmt.Errorf("%w: %w", ErrDeltaCorrupt, err)
+	}
+	// Остаток Values — только нулевое дополнение последнего байта
+	if rest := r.Remaining(); rest >= 8 {
+		return rec, fmt.Errorf("%w: %d trailing bits", ErrDeltaCorrupt, rest)
+	} else if rest > 0 {
+		if v, _ := r.ReadBits(uint8(rest)); v != 0 {
+			return rec, fmt.Errorf("%w: non-zero padding", ErrDeltaCorrupt)
+		}
+	}
+
+	for i, b := range w.Bytes() {
+		rec[i] ^= b
+	}
+	return rec, nil
+}
+
+// ChangedFields — имена изменённых полей дельты в порядке битов записи
+func (l *Layout) ChangedFields(d Delta) ([]string, error) {
+	if err := l.check(d); err != nil {
+		return nil, err
+	}
+	var names []string
+	for i, f := range l.fields {
+		if d.Changed&(1<<i) != 0 {
+			names = append(names, f.Name)
+		}
+	}
+	return names, nil
+}
+
+// check — дельта построена для этой раскладки
+func (l *Layout) check(d Delta) error {
+	if d.Kind != l.kind {
+		return fmt.Errorf("%w: got %q, want %q", ErrDeltaKind, d.Kind, l.kind)
+	}
+	if d.Version != l.version {
+		return fmt.Errorf("%w: got %08x, want %08x", ErrDeltaVersion, d.Version, l.version)
+	}
+	if extra := d.Changed >> len(l.fields); len(l.fields) < maxFields && extra != 0 {
+		return fmt.Errorf("%w: changed mask %#x has bits beyond %d fields", ErrDeltaCorrupt, d.Changed, len(l.fields))
+	}
+	return nil
+}
+
+// forChunks делит поле шириной width на куски до 64 битов
+func forChunks(width int, fn func(width uint8)) {
+	for ; width > 0; width -= 64 {
+		fn(uint8(min(width, 64)))
+	}
+}
+
+// ==================== Двоичный формат ====================
+//
+//	uvarint  длина Kind, затем байты Kind
+//	32 бита  Version
+//	16 бит   Base
+//	uvarint  Changed
+//	uvarint  длина Values в байтах
+//	         выравнивание до байта, затем байты Values
+
+// MarshalBinary кодирует дельту для передачи
+func (d Delta) MarshalBinary() ([]byte, error) {
+	w := bitpack.NewWriter(len(d.Kind) + len(d.Values) + 16)
+	_ = w.WriteUVarint(uint64(len(d.Kind)))
+	for i := 0; i < len(d.Kind); i++ {
+		_ = w.WriteBits(uint64(d.Kind[i]), 8)
+	}
+	_ = w.WriteBits(uint64(d.Version), 32)
+	_ = w.WriteBits(uint64(d.Base), 16)
+	_ = w.WriteUVarint(d.Changed)
+	_ = w.WriteUVarint(uint64(len(d.Values)))
+	w.Align()
+	if err := w.Err(); err != nil {
+		return nil, fmt.Errorf("failed to marshal delta: %w", err)
+	}
+	return append(w.Bytes(), d.Values...), nil
+}
+
+// UnmarshalBinary разбирает дельту, закодированную MarshalBinary
+func (d *Delta) UnmarshalBinary(data []byte) error {
+	r := bitpack.NewReader(data)
+	kindLen, _ := r.ReadUVarint()
+	if kindLen > uint64(len(data)) {
+		return fmt.Errorf("%w: kind length %d exceeds data", ErrDeltaCorrupt, kindLen)
+	}
+	kind := make([]byte, kindLen)
+	for i := range kind {
+		b, _ := r.ReadBits(8)
+		kind[i] = byte(b)
+	}
+	version, _ := r.ReadBits(32)
+	base, _ := r.ReadBits(16)
+	changed, _ := r.ReadUVarint()
+	valuesLen, _ := r.ReadUVarint()
+	r.Align()
+	if err := r.Err(); err != nil {
+		return fmt.Errorf("%w: %w", ErrDeltaCorrupt, err)
+	}
+
+	values := data[r.Offset()/8:]
+	if uint64(len(values)) != valuesLen {
+		return fmt.Errorf("%w: values length %d, header says %d", ErrDeltaCorrupt, len(values), valuesLen)
+	}
+
+	*d = Delta{
+		Kind:    string(kind),
+		Version: uint32(version),
+		Base:    uint16(base),
+		Changed: changed,
+		Values:  append([]byte(nil), values...),
+	}
+	return nil
+}
@@ -0,0 +1,125 @@
+package record
+
+import (
+	"GamePerson/internal/bitpack"
+	"errors"
+	"fmt"
+	"hash/crc32"
+	"slices"
+)
+
+// ================  Раскладка 64-байтовой записи существа ====================
+//
+// Запись существа — 64 байта в порядке полей структуры: имя, битовая
+// упаковка (и код целостности), золото и координаты в little-endian.
+// Layout описывает запись как набор именованных диапазонов битов: поля
+// вне упаковки задаются вручную, поля упаковки берутся из дескрипторов
+// схемы bitpack (см. SchemaFields). Раскладка обязана покрывать все
+// 512 битов ровно один раз — тогда Diff и Patch переносят запись без потерь.
+
+// Size — размер записи существа в байтах
+const Size = 64
+
+// Bits — размер записи существа в битах
+const Bits = 8 * Size
+
+// maxFields — число полей, помещающееся в маску изменений Delta.Changed
+const maxFields = 64
+
+// Field — именованный диапазон битов записи
+type Field struct {
+	Name  string
+	Start int    // Первый бит поля в записи
+	Width int    // Количество битов поля
+	Spec  string // Описание значения (диапазон, вид) — входит в версию раскладки
+}
+
+// SchemaFields — поля упаковки схемы s, сдвинутые на offset битов записи
+func SchemaFields(offset int, s *bitpack.Schema) []Field {
+	descriptors := s.Fields()
+	fields := make([]Field, 0, len(descriptors))
+	for _, d := range descriptors {
+		fields = append(fields, Field{
+			Name:  d.Name,
+			Start: offset + int(d.Start),
+			Width: d.Width,
+			Spec:  d.String(),
+		})
+	}
+	return fields
+}
+
+// Layout — проверенная раскладка записи одного вида существ
+type Layout struct {
+	kind    string
+	fields  []Field // Отсортированы по Start
+	version uint32
+}
+
+// NewLayout проверяет, что поля покрывают запись без пропусков и пересечений,
+// и вычисляет версию раскладки
+func NewLayout(kind string, fields ...Field) (*Layout, error) {
+	if kind == "" {
+		return nil, errors.New("record kind cannot be empty")
+	}
+	if len(fields) > maxFields {
+		return nil, fmt.Errorf("record %s: %d fields exceed maximum %d", kind, len(fields), maxFields)
+	}
+
+	sorted := slices.Clone(fields)
+	slices.SortFunc(sorted, func(a, b Field) int { return a.Start - b.Start })
+
+	next := 0
+	for _, f := range sorted {
+		if f.Width <= 0 {
+			return nil, fmt.Errorf("record %s: field %q has non-positive width %d", kind, f.Name, f.Width)
+		}
+		if f.Start != next {
+			return nil, fmt.Errorf("record %s: field %q starts at bit %d, want %d (gap or overlap)", kind, f.Name, f.Start, next)
+		}
+		next = f.Start + f.Width
+	}
+	if next != Bits {
+		return nil, fmt.Errorf("record %s: fields cover %d bits, want %d", kind, next, Bits)
+	}
+
+	return &Layout{kind: kind, fields: sorted, version: layoutVersion(kind, sorted)}, nil
+}
+
+// MustNewLayout — NewLayout, паникующий при ошибке
+func MustNewLayout(kind string, fields ...Field) *Layout {
+	l, err := NewLayout(kind, fields...)
+	if err != nil {
+		panic(fmt.Sprintf("FATAL: %v", err))
+	}
+	return l
+}
+
+// Kind — вид записи ("person", "monster")
+func (l *Layout) Kind() string {
+	return l.kind
+}
+
+// Version — CRC-32 вида и описаний всех полей: любая смена раскладки меняет версию
+func (l *Layout) Version() uint32 {
+	return l.version
+}
+
+// Fields возвращает копию полей в порядке битов записи
+func (l *Layout) Fields() []Field {
+	return slices.Clone(l.fields)
+}
+
+// Строковое представление для отладки
+func (l *Layout) String() string {
+	return fmt.Sprintf("Layout{%s v%08x, %d fields}", l.kind, l.version, len(l.fields))
+}
+
+func layoutVersion(kind string, fields []Field) uint32 {
+	h := crc32.NewIEEE()
+	fmt.Fprintf(h, "%s\n", kind)
+	for _, f := range fields {
+		fmt.Fprintf(h, "%s %d %d %s\n", f.Name, f.Start, f.Width, f.Spec)
+	}
+	return h.Sum32()
+}
@@ -0,0 +1,306 @@
+package serializer
+
+import (
+	"bytes"
+	"encoding/json"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"sync"
+
+	"gopkg.in/yaml.v3"
+)
+
+// ================  Кодеки форматов сериализации ============================
+//
+// Codec превращает DTO в байты и обратно. Serializer ищет кодек по имени
+// формата в реестре (Registry), поэтому новый формат — это новый кодек,
+// а не новые методы у Serializer и новые конструкторы у каждого существа.
+//
+// JSON, XML, YAML, NDJSON и CSV — встроенные кодеки реестра по умолчанию (Default).
+// Свой кодек регистрируется в Default (Register) или в отдельном реестре,
+// который передаётся сериализатору через WithCodecs.
+
+// Codec — формат сериализации DTO
+type Codec interface {
+	Name() string         // Имя формата: "json", "xml", "yaml" (без учёта регистра)
+	MIME() string         // MIME-тип: "application/json"
+	Extensions() []string // Расширения файлов с точкой: ".yaml", ".yml"
+	Marshal(v any) ([]byte, error)
+	Unmarshal(data []byte, v any) error
+}
+
+// Sniffer — необязательное расширение Codec: распознавание формата по содержимому
+type Sniffer interface {
+	Sniff(data []byte) bool
+}
+
+// StreamCodec — необязательное расширение Codec: последовательная запись
+// и чтение значений через io.Writer/io.Reader (см. Serializer.EncodeAll)
+type StreamCodec interface {
+	NewEncoder(w io.Writer) Encoder
+	NewDecoder(r io.Reader) Decoder
+}
+
+// Encoder пишет значения в поток одно за другим.
+// Если Encoder реализует io.Closer, Close дописывает конец потока.
+type Encoder interface {
+	Encode(v any) error
+}
+
+// Decoder читает значения из потока одно за другим; в конце потока — io.EOF
+type Decoder interface {
+	Decode(v any) error
+}
+
+var (
+	ErrUnknownFormat = errors.New("unknown serialization format")
+	ErrCodecExists   = errors.New("codec is already registered")
+)
+
+// ==================== Registry ====================
+
+// Registry — набор кодеков с поиском по имени, MIME-типу, расширению и содержимому.
+// Безопасен для конкурентного использования.
+type Registry struct {
+	mu     sync.RWMutex
+	codecs []Codec // В порядке регистрации — порядок перебора при Sniff
+	byKey  map[string]Codec
+}
+
+// NewRegistry создаёт реестр с указанными кодеками
+func NewRegistry(codecs ...Codec) (*Registry, error) {
+	r := &Registry{byKey: make(map[string]Codec)}
+	for _, c := range codecs {
+		if err := r.Register(c); err != nil {
+			return nil, err
+		}
+	}
+	return r, nil
+}
+
+// MustNewRegistry — NewRegistry, паникующий при ошибке
+func MustNewRegistry(codecs ...Codec) *Registry {
+	r, err := NewRegistry(codecs...)
+	if err != nil {
+		panic(fmt.Sprintf("FATAL: %v", err))
+	}
+	return r
+}
+
+// Default — реестр по умолчанию со встроенными кодеками JSON, XML, YAML, NDJSON и CSV
+var Default = MustNewRegistry(JSON, XML, YAML, NDJSON, CSV)
+
+// Register добавляет кодек в реестр по умолчанию
+func Register(c Codec) error {
+	return Default.Register(c)
+}
+
+// Register добавляет кодек. Имя, MIME-тип и расширения не должны
+// совпадать с уже зарегистрированными.
+func (r *Registry) Register(c Codec) error {
+	if c == nil || c.Name() == "" {
+		return errors.New("codec must have a name")
+	}
+	keys := codecKeys(c)
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, key := range keys {
+		if prev, ok := r.byKey[key]; ok {
+			return fmt.Errorf("%w: %q is taken by %s", ErrCodecExists, key, prev.Name())
+		}
+	}
+	for _, key := range keys {
+		r.byKey[key] = c
+	}
+	r.codecs = append(r.codecs, c)
+	return nil
+}
+
+// Lookup ищет кодек по имени формата, MIME-типу или расширению (".yml")
+func (r *Registry) Lookup(format string) (Codec, error) {
+	r.mu.RLock()
+	c, ok := r.byKey[strings.ToLower(strings.TrimSpace(format))]
+	r.mu.RUnlock()
+	if !ok {
+		return nil, fmt.Errorf("%w: %q", ErrUnknownFormat, format)
+	}
+	return c, nil
+}
+
+// ByExtension ищет кодек по расширению файла path
+func (r *Registry) ByExtension(path string) (Codec, error) {
+	ext := filepath.Ext(path)
+	if ext == "" {
+		return nil, fmt.Errorf("%w: file %q has no extension", ErrUnknownFormat, path)
+	}
+	return r.Lookup(ext)
+}
+
+// Sniff распознаёт формат по содержимому: первый в порядке регистрации
+// кодек-Sniffer, узнавший данные
+func (r *Registry) Sniff(data []byte) (Codec, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	for _, c := range r.codecs {
+		if s, ok := c.(Sniffer); ok && s.Sniff(data) {
+			return c, nil
+		}
+	}
+	return nil, fmt.Errorf("%w: content is not recognized", ErrUnknownFormat)
+}
+
+// Detect определяет формат по расширению path, а если его нет или оно
+// неизвестно — по содержимому data
+func (r *Registry) Detect(path string, data []byte) (Codec, error) {
+	if path != "" {
+		if c, err := r.ByExtension(path); err == nil {
+			return c, nil
+		}
+	}
+	return r.Sniff(data)
+}
+
+// Codecs возвращает кодеки в порядке регистрации
+func (r *Registry) Codecs() []Codec {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return append([]Codec(nil), r.codecs...)
+}
+
+// codecKeys — ключи поиска кодека: имя, MIME-тип и расширения в нижнем регистре
+func codecKeys(c Codec) []string {
+	keys := []string{strings.ToLower(c.Name())}
+	if mime := c.MIME(); mime != "" {
+		keys = append(keys, strings.ToLower(mime))
+	}
+	for _, ext := range c.Extensions() {
+		if !strings.HasPrefix(ext, ".") {
+			ext = "." + ext
+		}
+		keys = append(keys, strings.ToLower(ext))
+	}
+	return keys
+}
+
+// ==================== Встроенные кодеки ====================
+
+var (
+	JSON Codec = jsonCodec{}
+	XML  Codec = xmlCodec{}
+	YAML Codec = yamlCodec{}
+)
+
+type jsonCodec struct{}
+
+func (jsonCodec) Name() string                       { return "json" }
+func (jsonCodec) MIME() string                       { return "application/json" }
+func (jsonCodec) Extensions() []string               { return []string{".json"} }
+func (jsonCodec) Marshal(v any) ([]byte, error)      { return json.MarshalIndent(v, "", "  ") }
+func (jsonCodec) Unmarshal(data []byte, v any) error { return json.Unmarshal(data, v) }
+
+// Поток JSON — по одному компактному значению на строку
+func (jsonCodec) NewEncoder(w io.Writer) Encoder { return json.NewEncoder(w) }
+func (jsonCodec) NewDecoder(r io.Reader) Decoder { return json.NewDecoder(r) }
+
+// Sniff: документ-объект или массив
+func (jsonCodec) Sniff(data []byte) bool {
+	first, ok := firstByte(data)
+	return ok && (first == '{' || first == '[')
+}
+
+type xmlCodec struct{}
+
+func (xmlCodec) Name() string                       { return "xml" }
+func (xmlCodec) MIME() string                       { return "application/xml" }
+func (xmlCodec) Extensions() []string               { return []string{".xml"} }
+func (xmlCodec) Unmarshal(data []byte, v any) error { return xml.Unmarshal(data, v) }
+
+func (xmlCodec) Marshal(v any) ([]byte, error) {
+	data, err := xml.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	return append([]byte(xml.Header), data...), nil
+}
+
+// Поток XML — пролог и элементы верхнего уровня подряд
+func (xmlCodec) NewEncoder(w io.Writer) Encoder {
+	enc := xml.NewEncoder(w)
+	enc.Indent("", "  ")
+	return &xmlEncoder{w: w, enc: enc}
+}
+
+func (xmlCodec) NewDecoder(r io.Reader) Decoder { return xml.NewDecoder(r) }
+
+type xmlEncoder struct {
+	w       io.Writer
+	enc     *xml.Encoder
+	started bool
+}
+
+func (e *xmlEncoder) Encode(v any) error {
+	if !e.started {
+		e.started = true
+		if _, err := io.WriteString(e.w, xml.Header); err != nil {
+			return err
+		}
+	}
+	return e.enc.Encode(v)
+}
+
+// Close завершает последний элемент переводом строки
+func (e *xmlEncoder) Close() error {
+	if err := e.enc.Close(); err != nil || !e.started {
+		return err
+	}
+	_, err := io.WriteString(e.w, "\n")
+	return err
+}
+
+// Sniff: пролог или корневой элемент
+func (xmlCodec) Sniff(data []byte) bool {
+	first, ok := firstByte(data)
+	return ok && first == '<'
+}
+
+type yamlCodec struct{}
+
+func (yamlCodec) Name() string                       { return "yaml" }
+func (yamlCodec) MIME() string                       { return "application/yaml" }
+func (yamlCodec) Extensions() []string               { return []string{".yaml", ".yml"} }
+func (yamlCodec) Marshal(v any) ([]byte, error)      { return yaml.Marshal(v) }
+func (yamlCodec) Unmarshal(data []byte, v any) error { return yaml.Unmarshal(data, v) }
+
+// Поток YAML — документы, разделённые "---"; Close завершает поток
+func (yamlCodec) NewEncoder(w io.Writer) Encoder { return yaml.NewEncoder(w) }
+func (yamlCodec) NewDecoder(r io.Reader) Decoder { return yaml.NewDecoder(r) }
+
+// yamlMappingLine — строка «ключ: значение» в начале документа YAML
+var yamlMappingLine = regexp.MustCompile(`^[A-Za-z_][\w-]*\s*:(\s|$)`)
+
+// Sniff: маркер документа "---" или отображение «ключ: значение» в первой значимой строке
+func (yamlCodec) Sniff(data []byte) bool {
+	for _, line := range bytes.Split(data, []byte("\n")) {
+		line = bytes.TrimSpace(line)
+		if len(line) == 0 || line[0] == '#' {
+			continue
+		}
+		return bytes.HasPrefix(line, []byte("---")) || yamlMappingLine.Match(line)
+	}
+	return false
+}
+
+// firstByte — первый непробельный байт после необязательного UTF-8 BOM
+func firstByte(data []byte) (byte, bool) {
+	data = bytes.TrimLeft(bytes.TrimPrefix(data, []byte("\xEF\xBB\xBF")), " \t\r\n")
+	if len(data) == 0 {
+		return 0, false
+	}
+	return data[0], true
+}
@@ -0,0 +1,375 @@
+package serializer
+
+import (
+	"bytes"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"iter"
+	"reflect"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// ================  CSV ======================================================
+//
+// Таблица с заголовком: одна строка — одно DTO. Колонки берутся из полей
+// DTO (тег `csv:"name"`, без тега — имя поля; `csv:"-"` — пропустить).
+// При чтении колонки сопоставляются по заголовку без учёта регистра
+// и порядка; отсутствующая колонка и пустая ячейка дают нулевое значение,
+// а для указателя — nil (например, незаданная мана).
+//
+// Поддерживаются строки, bool, целые и дробные числа и указатели на них.
+// Тип со своим представлением в таблице реализует CSVMarshaler и
+// CSVUnmarshaler (так PersonType пишется именем и читается именем или числом);
+// пустая ячейка и для него даёт нулевое значение, UnmarshalCSV не вызывается.
+//
+// Кодек CSV с запятой зарегистрирован в Default (".csv"); другой
+// разделитель — NewCSVCodec(';') и Serializer.WithCodec.
+
+// CSVMarshaler — тип со своим представлением в ячейке CSV
+type CSVMarshaler interface {
+	MarshalCSV() (string, error)
+}
+
+// CSVUnmarshaler — тип, разбирающий своё представление из ячейки CSV
+type CSVUnmarshaler interface {
+	UnmarshalCSV(cell string) error
+}
+
+// CSVCodec — CSV-кодек DTO с настраиваемым разделителем
+type CSVCodec struct {
+	comma rune
+}
+
+// CSV — кодек CSV с разделителем ','
+var CSV Codec = MustNewCSVCodec(',')
+
+// NewCSVCodec создаёт CSV-кодек с разделителем comma
+func NewCSVCodec(comma rune) (*CSVCodec, error) {
+	if comma == 0 || comma == '"' || comma == '\r' || comma == '\n' || comma == utf8.RuneError || !utf8.ValidRune(comma) {
+		return nil, fmt.Errorf("invalid CSV delimiter %q", comma)
+	}
+	return &CSVCodec{comma: comma}, nil
+}
+
+// MustNewCSVCodec — NewCSVCodec, паникующий при ошибке
+func MustNewCSVCodec(comma rune) *CSVCodec {
+	c, err := NewCSVCodec(comma)
+	if err != nil {
+		panic(fmt.Sprintf("FATAL: %v", err))
+	}
+	return c
+}
+
+func (c *CSVCodec) Name() string         { return "csv" }
+func (c *CSVCodec) MIME() string         { return "text/csv" }
+func (c *CSVCodec) Extensions() []string { return []string{".csv"} }
+
+// Comma — разделитель колонок
+func (c *CSVCodec) Comma() rune {
+	return c.comma
+}
+
+// Marshal пишет заголовок и одну строку
+func (c *CSVCodec) Marshal(v any) ([]byte, error) {
+	var buf bytes.Buffer
+	enc := c.NewEncoder(&buf).(*csvEncoder)
+	if err := enc.Encode(v); err != nil {
+		return nil, err
+	}
+	if err := enc.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// Unmarshal читает заголовок и ровно одну строку
+func (c *CSVCodec) Unmarshal(data []byte, v any) error {
+	dec := c.NewDecoder(bytes.NewReader(data))
+	if err := dec.Decode(v); err == io.EOF {
+		return errors.New("csv: no data rows")
+	} else if err != nil {
+		return err
+	}
+	extra := reflect.New(reflect.TypeOf(v).Elem()).Interface()
+	if err := dec.Decode(extra); err != io.EOF {
+		return errors.New("csv: more than one data row")
+	}
+	return nil
+}
+
+func (c *CSVCodec) NewEncoder(w io.Writer) Encoder {
+	cw := csv.NewWriter(w)
+	cw.Comma = c.comma
+	return &csvEncoder{w: cw}
+}
+
+func (c *CSVCodec) NewDecoder(r io.Reader) Decoder {
+	cr := csv.NewReader(r)
+	cr.Comma = c.comma
+	return &csvDecoder{r: cr}
+}
+
+// ==================== Колонки ====================
+
+type csvColumn struct {
+	name  string
+	index int // Индекс поля в структуре DTO
+}
+
+// csvColumns — колонки структуры t в порядке полей
+func csvColumns(t reflect.Type) ([]csvColumn, error) {
+	if t.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("csv: %s is not a struct", t)
+	}
+	var columns []csvColumn
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		tag := f.Tag.Get("csv")
+		if !f.IsExported() || tag == "-" {
+			continue
+		}
+		if tag == "" {
+			tag = f.Name
+		}
+		columns = append(columns, csvColumn{name: tag, index: i})
+	}
+	return columns, nil
+}
+
+// structValue — структура за указателем v (или само значение для записи)
+func structValue(v any) (reflect.Value, error) {
+	rv := reflect.ValueOf(v)
+	for rv.Kind() == reflect.Pointer && !rv.IsNil() {
+		rv = rv.Elem()
+	}
+	if rv.Kind() != reflect.Struct {
+		return reflect.Value{}, fmt.Errorf("csv: %T is not a struct or pointer to struct", v)
+	}
+	return rv, nil
+}
+
+// ==================== Encoder ====================
+
+type csvEncoder struct {
+	w       *csv.Writer
+	columns []csvColumn // Заполняются по первому значению вместе с заголовком
+}
+
+func (e *csvEncoder) Encode(v any) error {
+	rv, err := structValue(v)
+	if err != nil {
+		return err
+	}
+	if e.columns == nil {
+		if e.columns, err = csvColumns(rv.Type()); err != nil {
+			return err
+		}
+		header := make([]string, len(e.columns))
+		for i, col := range e.columns {
+			header[i] = col.name
+		}
+		if err := e.w.Write(header); err != nil {
+			return err
+		}
+	}
+
+	row := make([]string, len(e.columns))
+	for i, col := range e.columns {
+		if row[i], err = formatCell(rv.Field(col.index)); err != nil {
+			return fmt.Errorf("column %q: %w", col.name, err)
+		}
+	}
+	return e.w.Write(row)
+}
+
+// Close дописывает буферизованные строки
+func (e *csvEncoder) Close() error {
+	e.w.Flush()
+	return e.w.Error()
+}
+
+func formatCell(v reflect.Value) (string, error) {
+	if v.Kind() == reflect.Pointer {
+		if v.IsNil() {
+			return "", nil
+		}
+		v = v.Elem()
+	}
+	if m, ok := v.Interface().(CSVMarshaler); ok {
+		return m.MarshalCSV()
+	}
+	switch v.Kind() {
+	case reflect.String:
+		return v.String(), nil
+	case reflect.Bool:
+		return strconv.FormatBool(v.Bool()), nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return strconv.FormatInt(v.Int(), 10), nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return strconv.FormatUint(v.Uint(), 10), nil
+	case reflect.Float32, reflect.Float64:
+		return strconv.FormatFloat(v.Float(), 'g', -1, v.Type().Bits()), nil
+	}
+	return "", fmt.Errorf("unsupported type %s", v.Type())
+}
+
+// ==================== Decoder ====================
+
+type csvDecoder struct {
+	r      *csv.Reader
+	fields []csvColumn // Поле DTO для каждой колонки файла
+	line   int         // Строка последней прочитанной записи
+}
+
+// Decode читает следующую строку в DTO. Ошибки строки и ячеек —
+// *LineError с номером строки файла и именем колонки из тега DTO
+// (как в ошибках проверки), а не из заголовка файла.
+func (d *csvDecoder) Decode(v any) error {
+	rv := reflect.ValueOf(v)
+	if rv.Kind() != reflect.Pointer || rv.IsNil() || rv.Elem().Kind() != reflect.Struct {
+		return fmt.Errorf("csv: decode target %T is not a pointer to struct", v)
+	}
+	target := rv.Elem()
+	if d.fields == nil {
+		if err := d.readHeader(target.Type()); err != nil {
+			return err
+		}
+	}
+
+	row, err := d.r.Read()
+	if err != nil {
+		var parseErr *csv.ParseError
+		if errors.As(err, &parseErr) {
+			d.line = parseErr.StartLine
+			return &LineError{Line: parseErr.StartLine, Err: parseErr.Err}
+		}
+		return err // io.EOF или ошибка чтения
+	}
+	d.line, _ = d.r.FieldPos(0)
+
+	target.SetZero()
+	for i, cell := range row {
+		if err := parseCell(target.Field(d.fields[i].index), cell); err != nil {
+			line, _ := d.r.FieldPos(i)
+			return &LineError{Line: line, Column: d.fields[i].name, Err: err}
+		}
+	}
+	return nil
+}
+
+// Line — номер строки файла последней прочитанной записи
+func (d *csvDecoder) Line() int {
+	return d.line
+}
+
+// readHeader сопоставляет колонки заголовка с полями DTO. Ошибка заголовка —
+// не *LineError: без заголовка строки прочитать нельзя.
+func (d *csvDecoder) readHeader(t reflect.Type) error {
+	header, err := d.r.Read()
+	if err != nil {
+		return err // io.EOF — пустой файл
+	}
+	columns, err := csvColumns(t)
+	if err != nil {
+		return err
+	}
+
+	fields := make([]csvColumn, len(header))
+	seen := make(map[int]bool, len(header))
+	for i, name := range header {
+		name = strings.TrimSpace(strings.TrimPrefix(name, "\uFEFF"))
+		found := false
+		for _, col := range columns {
+			if strings.EqualFold(col.name, name) {
+				fields[i], found = col, true
+				break
+			}
+		}
+		if !found {
+			return fmt.Errorf("csv header: unknown column %q", name)
+		}
+		if seen[fields[i].index] {
+			return fmt.Errorf("csv header: duplicate column %q", name)
+		}
+		seen[fields[i].index] = true
+	}
+	d.fields = fields
+	return nil
+}
+
+// parseCell разбирает ячейку в поле v. Строки берутся как есть,
+// у остальных значений пробелы по краям отбрасываются.
+func parseCell(v reflect.Value, cell string) error {
+	if v.Kind() == reflect.String {
+		v.SetString(cell)
+		return nil
+	}
+	cell = strings.TrimSpace(cell)
+	if v.Kind() == reflect.Pointer {
+		if cell == "" {
+			v.SetZero()
+			return nil
+		}
+		p := reflect.New(v.Type().Elem())
+		if err := parseCell(p.Elem(), cell); err != nil {
+			return err
+		}
+		v.Set(p)
+		return nil
+	}
+	if cell == "" {
+		v.SetZero()
+		return nil
+	}
+	if u, ok := v.Addr().Interface().(CSVUnmarshaler); ok {
+		return u.UnmarshalCSV(cell)
+	}
+
+	switch v.Kind() {
+	case reflect.Bool:
+		b, err := strconv.ParseBool(cell)
+		if err != nil {
+			return fmt.Errorf("invalid bool %q", cell)
+		}
+		v.SetBool(b)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		n, err := strconv.ParseInt(cell, 10, v.Type().Bits())
+		if err != nil {
+			return fmt.Errorf("invalid integer %q: %w", cell, errors.Unwrap(err))
+		}
+		v.SetInt(n)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		n, err := strconv.ParseUint(cell, 10, v.Type().Bits())
+		if err != nil {
+			return fmt.Errorf("invalid unsigned integer %q: %w", cell, errors.Unwrap(err))
+		}
+		v.SetUint(n)
+	case reflect.Float32, reflect.Float64:
+		f, err := strconv.ParseFloat(cell, v.Type().Bits())
+		if err != nil {
+			return fmt.Errorf("invalid number %q: %w", cell, errors.Unwrap(err))
+		}
+		v.SetFloat(f)
+	default:
+		return fmt.Errorf("unsupported type %s", v.Type())
+	}
+	return nil
+}
+
+// ==================== Импорт и экспорт ====================
+
+// ExportCSV пишет сущности seq таблицей с заголовком и разделителем ','
+// (другой разделитель — WithCodec(MustNewCSVCodec(';')).EncodeAll)
+func (s *Serializer[E, D]) ExportCSV(w io.Writer, seq iter.Seq[E]) error {
+	return s.WithCodec(CSV).EncodeAll(w, seq)
+}
+
+// ImportCSV читает сущности из CSV с разделителем ',' (см. Import);
+// ошибки строк содержат номер строки файла и имя колонки
+func (s *Serializer[E, D]) ImportCSV(r io.Reader, policy ImportPolicy) ([]E, *ImportReport, error) {
+	return s.WithCodec(CSV).Import(r, policy)
+}
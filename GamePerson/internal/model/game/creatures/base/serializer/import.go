@@ -0,0 +1,153 @@
+package serializer
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"iter"
+)
+
+// ================  Построчный импорт =========================================
+//
+// Построчные форматы (NDJSON, CSV) позволяют пропустить испорченную запись
+// и читать дальше. Их декодеры реализуют LineDecoder: ошибка одной записи
+// возвращается как *LineError с номером строки (и колонкой, если известна).
+// Import добавляет к этому FromDTO и IntegrityChecker для каждой записи
+// и политику обработки ошибок.
+
+// LineDecoder — Decoder построчного формата
+type LineDecoder interface {
+	Decoder
+	Line() int // Номер строки последней прочитанной записи, с единицы
+}
+
+// ImportPolicy — реакция Import на невалидную запись
+type ImportPolicy uint8
+
+const (
+	StopOnError ImportPolicy = iota // Остановиться на первой невалидной записи
+	SkipInvalid                     // Пропустить запись и записать её в отчёт
+)
+
+// LineError — ошибка записи построчного формата с её номером строки
+type LineError struct {
+	Line   int    // Номер строки, с единицы
+	Column string // Колонка или поле; пусто, если ошибка относится ко всей записи
+	Err    error
+}
+
+func (e *LineError) Error() string {
+	if e.Column != "" {
+		return fmt.Sprintf("line %d, column %q: %v", e.Line, e.Column, e.Err)
+	}
+	return fmt.Sprintf("line %d: %v", e.Line, e.Err)
+}
+
+func (e *LineError) Unwrap() error {
+	return e.Err
+}
+
+// ImportReport — итог импорта
+type ImportReport struct {
+	Imported int         // Принято записей
+	Invalid  []LineError // Отвергнутые записи: ошибки разбора, FromDTO и Validate
+}
+
+// Err объединяет ошибки отвергнутых записей; nil, если их нет
+func (r *ImportReport) Err() error {
+	errs := make([]error, len(r.Invalid))
+	for i := range r.Invalid {
+		errs[i] = &r.Invalid[i]
+	}
+	return errors.Join(errs...)
+}
+
+// Import читает сущности из r в формате сериализатора, который должен
+// создавать LineDecoder. Каждая запись проходит разбор, FromDTO и
+// IntegrityChecker. При StopOnError первая невалидная запись возвращается
+// ошибкой *LineError вместе с уже принятыми сущностями. При SkipInvalid
+// такие записи попадают в отчёт, а ошибка возвращается только при сбое
+// чтения r.
+func (s *Serializer[E, D]) Import(r io.Reader, policy ImportPolicy) ([]E, *ImportReport, error) {
+	var entities []E
+	report := &ImportReport{}
+	for e, err := range s.importLines(r) {
+		if err == nil {
+			entities = append(entities, e)
+			report.Imported++
+			continue
+		}
+		var lineErr *LineError
+		if !errors.As(err, &lineErr) {
+			return entities, report, err
+		}
+		report.Invalid = append(report.Invalid, *lineErr)
+		if policy == StopOnError {
+			return entities, report, err
+		}
+	}
+	return entities, report, nil
+}
+
+// importLines — сущности потока по записям; ошибки записей — *LineError,
+// ошибка чтения r — последняя
+func (s *Serializer[E, D]) importLines(r io.Reader) iter.Seq2[E, error] {
+	return func(yield func(E, error) bool) {
+		var zero E
+		name := formatName(s.codec)
+		dec, ok := s.lineDecoder(r)
+		if !ok {
+			yield(zero, fmt.Errorf("%w: %s has no line decoder", ErrNotStreamable, name))
+			return
+		}
+
+		for {
+			var dto D
+			err := dec.Decode(&dto)
+			if err == io.EOF {
+				return
+			}
+
+			var e E
+			var lineErr *LineError
+			switch {
+			case errors.As(err, &lineErr):
+				err = &LineError{
+					Line:   lineErr.Line,
+					Column: lineErr.Column,
+					Err:    fmt.Errorf("failed to unmarshal from %s: %w", name, lineErr.Err),
+				}
+			case err != nil:
+				yield(zero, fmt.Errorf("failed to read %s: %w", name, err))
+				return
+			default:
+				if e, err = s.build(dto, name); err != nil {
+					err = &LineError{Line: dec.Line(), Column: fieldOf(err), Err: err}
+				}
+			}
+
+			if !yield(e, err) {
+				return
+			}
+		}
+	}
+}
+
+// lineDecoder — построчный декодер формата сериализатора
+func (s *Serializer[E, D]) lineDecoder(r io.Reader) (LineDecoder, bool) {
+	sc, ok := s.codec.(StreamCodec)
+	if !ok {
+		return nil, false
+	}
+	dec, ok := sc.NewDecoder(r).(LineDecoder)
+	return dec, ok
+}
+
+// fieldOf — имя поля из ошибки валидации (bitpack.Error, entity.FieldError)
+func fieldOf(err error) string {
+	var fe interface{ Field() string }
+	if errors.As(err, &fe) {
+		return fe.Field()
+	}
+	return ""
+}
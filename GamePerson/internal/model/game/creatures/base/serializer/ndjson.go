@@ -0,0 +1,99 @@
+package serializer
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"io"
+	"iter"
+)
+
+// ================  NDJSON (JSON Lines) ======================================
+//
+// Один компактный JSON-объект на строку — формат пакетного переноса
+// существ между окружениями. Кодек NDJSON зарегистрирован в Default
+// (".ndjson", ".jsonl"); его декодер читает поток построчно, поэтому
+// испорченная строка не мешает читать следующие.
+//
+// ImportNDJSON импортирует поток с политикой обработки ошибок:
+//
+//	persons, report, err := ser.ImportNDJSON(file, serializer.SkipInvalid)
+//	for _, bad := range report.Invalid {
+//		log.Printf("строка %d: %v", bad.Line, bad.Err)
+//	}
+
+var NDJSON Codec = ndjsonCodec{}
+
+type ndjsonCodec struct{}
+
+func (ndjsonCodec) Name() string                       { return "ndjson" }
+func (ndjsonCodec) MIME() string                       { return "application/x-ndjson" }
+func (ndjsonCodec) Extensions() []string               { return []string{".ndjson", ".jsonl"} }
+func (ndjsonCodec) Unmarshal(data []byte, v any) error { return json.Unmarshal(data, v) }
+
+func (ndjsonCodec) Marshal(v any) ([]byte, error) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	return append(data, '\n'), nil
+}
+
+func (ndjsonCodec) NewEncoder(w io.Writer) Encoder { return json.NewEncoder(w) }
+func (ndjsonCodec) NewDecoder(r io.Reader) Decoder { return newNDJSONDecoder(r) }
+
+// ndjsonDecoder читает по одному объекту из каждой непустой строки
+type ndjsonDecoder struct {
+	r    *bufio.Reader
+	line int // Номер последней прочитанной строки, с единицы
+}
+
+func newNDJSONDecoder(r io.Reader) *ndjsonDecoder {
+	return &ndjsonDecoder{r: bufio.NewReader(r)}
+}
+
+// Decode разбирает следующую непустую строку. Ошибка разбора — *LineError
+// и относится только к этой строке: следующий вызов читает следующую.
+func (d *ndjsonDecoder) Decode(v any) error {
+	line, err := d.next()
+	if err != nil {
+		return err
+	}
+	if err := json.Unmarshal(line, v); err != nil {
+		return &LineError{Line: d.line, Err: err}
+	}
+	return nil
+}
+
+// Line — номер строки последнего прочитанного объекта
+func (d *ndjsonDecoder) Line() int {
+	return d.line
+}
+
+// next возвращает следующую непустую строку без пробелов по краям;
+// в конце потока — io.EOF
+func (d *ndjsonDecoder) next() ([]byte, error) {
+	for {
+		line, err := d.r.ReadBytes('\n')
+		if len(line) == 0 && err != nil {
+			return nil, err
+		}
+		d.line++
+		if line = bytes.TrimSpace(line); len(line) > 0 {
+			return line, nil
+		}
+	}
+}
+
+// ==================== Импорт и экспорт ====================
+
+// ExportNDJSON пишет сущности seq по одной на строку
+// (для среза — slices.Values(items))
+func (s *Serializer[E, D]) ExportNDJSON(w io.Writer, seq iter.Seq[E]) error {
+	return s.WithCodec(NDJSON).EncodeAll(w, seq)
+}
+
+// ImportNDJSON читает сущности из NDJSON (см. Import)
+func (s *Serializer[E, D]) ImportNDJSON(r io.Reader, policy ImportPolicy) ([]E, *ImportReport, error) {
+	return s.WithCodec(NDJSON).Import(r, policy)
+}
@@ -2,11 +2,9 @@ package serializer
 
 import (
 	"GamePerson/internal/model/game/creatures/base/entity"
-	"encoding/json"
-	"encoding/xml"
+	"encoding"
 	"fmt"
-
-	"gopkg.in/yaml.v3"
+	"strings"
 )
 
 // Converter — минимальный интерфейс преобразования сущность ↔ DTO
@@ -15,39 +13,125 @@ type Converter[E any, D any] interface {
 	FromDTO(dto D) (E, error)
 }
 
+// EntityFactory — необязательное расширение Converter для двоичного формата:
+// пустая сущность, в которую FromBinary декодирует запись
+type EntityFactory[E any] interface {
+	NewEntity() E
+}
+
 // Serializer — обобщённый сериализатор для ЛЮБОЙ сущности
 type Serializer[E any, D any] struct {
 	converter Converter[E, D]
 	integrity *entity.IntegrityChecker
+	codecs    *Registry
+	codec     Codec // Формат потоковых методов (EncodeTo, DecodeAll ...)
 }
 
 func New[E any, D any](converter Converter[E, D], integrity *entity.IntegrityChecker) *Serializer[E, D] {
 	if integrity == nil {
 		integrity = entity.NewIntegrityChecker()
 	}
-	return &Serializer[E, D]{converter: converter, integrity: integrity}
+	return &Serializer[E, D]{converter: converter, integrity: integrity, codecs: Default, codec: JSON}
+}
+
+// WithCodecs возвращает копию сериализатора, ищущую форматы в реестре codecs
+func (s *Serializer[E, D]) WithCodecs(codecs *Registry) *Serializer[E, D] {
+	c := *s
+	c.codecs = codecs
+	return &c
+}
+
+// Codecs — реестр форматов сериализатора
+func (s *Serializer[E, D]) Codecs() *Registry {
+	return s.codecs
+}
+
+// WithFormat возвращает копию сериализатора, потоковые методы которой
+// используют формат format (имя, MIME-тип или расширение). По умолчанию — JSON.
+func (s *Serializer[E, D]) WithFormat(format string) (*Serializer[E, D], error) {
+	codec, err := s.codecs.Lookup(format)
+	if err != nil {
+		return nil, err
+	}
+	c := *s
+	c.codec = codec
+	return &c, nil
+}
+
+// WithCodec возвращает копию сериализатора, потоковые методы которой
+// используют кодек codec напрямую, без реестра (например, CSV с другим разделителем)
+func (s *Serializer[E, D]) WithCodec(codec Codec) *Serializer[E, D] {
+	c := *s
+	c.codec = codec
+	return &c
+}
+
+// Format — кодек потоковых методов
+func (s *Serializer[E, D]) Format() Codec {
+	return s.codec
+}
+
+// Encode сериализует сущность в формат format (имя, MIME-тип или расширение)
+func (s *Serializer[E, D]) Encode(format string, entity E) ([]byte, error) {
+	codec, err := s.codecs.Lookup(format)
+	if err != nil {
+		return nil, err
+	}
+	return s.encode(codec, entity)
+}
+
+// Decode десериализует сущность из формата format с проверкой целостности
+func (s *Serializer[E, D]) Decode(format string, data []byte) (E, error) {
+	codec, err := s.codecs.Lookup(format)
+	if err != nil {
+		var zero E
+		return zero, err
+	}
+	return s.decode(codec, data)
+}
+
+// DecodeAuto определяет формат по расширению path или по содержимому
+// (см. Registry.Detect) и десериализует сущность
+func (s *Serializer[E, D]) DecodeAuto(path string, data []byte) (E, error) {
+	codec, err := s.codecs.Detect(path, data)
+	if err != nil {
+		var zero E
+		return zero, err
+	}
+	return s.decode(codec, data)
+}
+
+// Вспомогательная функция сериализации (без дублирования)
+func (s *Serializer[E, D]) encode(codec Codec, entity E) ([]byte, error) {
+	dto := s.converter.ToDTO(entity)
+	data, err := codec.Marshal(dto)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal to %s: %w", formatName(codec), err)
+	}
+	return data, nil
 }
 
 // Вспомогательная функция десериализации (без дублирования)
-func (s *Serializer[E, D]) deserialize(
-	data []byte,
-	unmarshal func([]byte, interface{}) error,
-	formatName string,
-) (E, error) {
+func (s *Serializer[E, D]) decode(codec Codec, data []byte) (E, error) {
+	name := formatName(codec)
 	var dto D
-	if err := unmarshal(data, &dto); err != nil {
+	if err := codec.Unmarshal(data, &dto); err != nil {
 		var zero E
-		return zero, fmt.Errorf("failed to unmarshal from %s: %w", formatName, err)
+		return zero, fmt.Errorf("failed to unmarshal from %s: %w", name, err)
 	}
+	return s.build(dto, name)
+}
 
+// build создаёт сущность из DTO и проверяет её целостность
+func (s *Serializer[E, D]) build(dto D, name string) (E, error) {
 	e, err := s.converter.FromDTO(dto)
 	if err != nil {
 		var zero E
-		return zero, fmt.Errorf("%s deserialization failed: %w", formatName, err)
+		return zero, fmt.Errorf("%s deserialization failed: %w", name, err)
 	}
 
 	// Единая точка валидации для ВСЕХ сущностей
-	if err := s.integrity.Check(e, formatName); err != nil {
+	if err := s.integrity.Check(e, name); err != nil {
 		var zero E
 		return zero, err
 	}
@@ -55,43 +139,69 @@ func (s *Serializer[E, D]) deserialize(
 	return e, nil
 }
 
-// Публичные методы — без дублирования логики
+// formatName — имя формата для сообщений об ошибках ("JSON", "YAML")
+func formatName(codec Codec) string {
+	return strings.ToUpper(codec.Name())
+}
+
+// Публичные методы встроенных форматов — обёртки над кодеками
 
 func (s *Serializer[E, D]) ToJSON(entity E) ([]byte, error) {
-	dto := s.converter.ToDTO(entity)
-	data, err := json.MarshalIndent(dto, "", "  ")
-	if err != nil {
-		return nil, fmt.Errorf("failed to marshal to JSON: %w", err)
-	}
-	return data, nil
+	return s.encode(JSON, entity)
 }
 
 func (s *Serializer[E, D]) FromJSON(data []byte) (E, error) {
-	return s.deserialize(data, json.Unmarshal, "JSON")
+	return s.decode(JSON, data)
 }
 
 func (s *Serializer[E, D]) ToXML(entity E) ([]byte, error) {
-	dto := s.converter.ToDTO(entity)
-	data, err := xml.MarshalIndent(dto, "", "  ")
-	if err != nil {
-		return nil, fmt.Errorf("failed to marshal to XML: %w", err)
-	}
-	return append([]byte(xml.Header), data...), nil
+	return s.encode(XML, entity)
 }
 
 func (s *Serializer[E, D]) FromXML(data []byte) (E, error) {
-	return s.deserialize(data, xml.Unmarshal, "XML")
+	return s.decode(XML, data)
 }
 
 func (s *Serializer[E, D]) ToYAML(entity E) ([]byte, error) {
-	dto := s.converter.ToDTO(entity)
-	data, err := yaml.Marshal(dto)
+	return s.encode(YAML, entity)
+}
+
+func (s *Serializer[E, D]) FromYAML(data []byte) (E, error) {
+	return s.decode(YAML, data)
+}
+
+// Двоичный формат: сущность сама кодирует себя (encoding.BinaryMarshaler),
+// без DTO. После декодирования — та же проверка целостности, что и для текста.
+
+func (s *Serializer[E, D]) ToBinary(entity E) ([]byte, error) {
+	m, ok := any(entity).(encoding.BinaryMarshaler)
+	if !ok {
+		return nil, fmt.Errorf("failed to marshal to Binary: %T does not implement encoding.BinaryMarshaler", entity)
+	}
+	data, err := m.MarshalBinary()
 	if err != nil {
-		return nil, fmt.Errorf("failed to marshal to YAML: %w", err)
+		return nil, fmt.Errorf("failed to marshal to Binary: %w", err)
 	}
 	return data, nil
 }
 
-func (s *Serializer[E, D]) FromYAML(data []byte) (E, error) {
-	return s.deserialize(data, yaml.Unmarshal, "YAML")
+func (s *Serializer[E, D]) FromBinary(data []byte) (E, error) {
+	var zero E
+	factory, ok := s.converter.(EntityFactory[E])
+	if !ok {
+		return zero, fmt.Errorf("failed to unmarshal from Binary: converter %T does not implement EntityFactory", s.converter)
+	}
+	e := factory.NewEntity()
+	u, ok := any(e).(encoding.BinaryUnmarshaler)
+	if !ok {
+		return zero, fmt.Errorf("failed to unmarshal from Binary: %T does not implement encoding.BinaryUnmarshaler", e)
+	}
+	if err := u.UnmarshalBinary(data); err != nil {
+		return zero, fmt.Errorf("failed to unmarshal from Binary: %w", err)
+	}
+
+	if err := s.integrity.Check(e, "Binary"); err != nil {
+		return zero, err
+	}
+	return e, nil
 }
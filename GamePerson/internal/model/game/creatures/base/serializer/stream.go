@@ -0,0 +1,153 @@
+package serializer
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"iter"
+)
+
+// ================  Потоковая сериализация ====================================
+//
+// EncodeTo/DecodeFrom пишут и читают одну сущность через io.Writer/io.Reader,
+// EncodeAll/DecodeAll — коллекцию, не держа весь файл в памяти: в памяти
+// только текущая запись. Формат задаётся WithFormat (по умолчанию JSON).
+//
+// Каждая прочитанная запись проходит FromDTO и IntegrityChecker. Ошибки
+// коллекций оборачиваются в *RecordError с номером записи (с нуля):
+//
+//	for p, err := range ser.DecodeAll(file) {
+//		var recErr *serializer.RecordError
+//		if errors.As(err, &recErr) { log.Printf("запись %d: %v", recErr.Index, recErr.Err) }
+//		...
+//	}
+
+// ErrNotStreamable — кодек не реализует StreamCodec
+var ErrNotStreamable = errors.New("codec does not support streaming")
+
+// RecordError — ошибка записи коллекции с её номером
+type RecordError struct {
+	Index int // Номер записи в потоке, с нуля
+	Err   error
+}
+
+func (e *RecordError) Error() string {
+	return fmt.Sprintf("record %d: %v", e.Index, e.Err)
+}
+
+func (e *RecordError) Unwrap() error {
+	return e.Err
+}
+
+// EncodeTo пишет сущность в w в формате сериализатора
+func (s *Serializer[E, D]) EncodeTo(w io.Writer, entity E) error {
+	sc, ok := s.codec.(StreamCodec)
+	if !ok {
+		data, err := s.encode(s.codec, entity)
+		if err != nil {
+			return err
+		}
+		_, err = w.Write(data)
+		return err
+	}
+
+	enc := sc.NewEncoder(w)
+	if err := enc.Encode(s.converter.ToDTO(entity)); err != nil {
+		return fmt.Errorf("failed to marshal to %s: %w", formatName(s.codec), err)
+	}
+	return s.finish(enc)
+}
+
+// DecodeFrom читает из r одну сущность с проверкой целостности
+func (s *Serializer[E, D]) DecodeFrom(r io.Reader) (E, error) {
+	sc, ok := s.codec.(StreamCodec)
+	if !ok {
+		data, err := io.ReadAll(r)
+		if err != nil {
+			var zero E
+			return zero, err
+		}
+		return s.decode(s.codec, data)
+	}
+
+	var dto D
+	if err := sc.NewDecoder(r).Decode(&dto); err != nil {
+		var zero E
+		return zero, fmt.Errorf("failed to unmarshal from %s: %w", formatName(s.codec), err)
+	}
+	return s.build(dto, formatName(s.codec))
+}
+
+// EncodeAll пишет сущности seq в w одну за другой. Останавливается на первой
+// ошибке и возвращает её как *RecordError.
+func (s *Serializer[E, D]) EncodeAll(w io.Writer, seq iter.Seq[E]) error {
+	sc, ok := s.codec.(StreamCodec)
+	if !ok {
+		return fmt.Errorf("%w: %s", ErrNotStreamable, formatName(s.codec))
+	}
+
+	enc := sc.NewEncoder(w)
+	index := 0
+	for entity := range seq {
+		if err := enc.Encode(s.converter.ToDTO(entity)); err != nil {
+			return &RecordError{Index: index, Err: fmt.Errorf("failed to marshal to %s: %w", formatName(s.codec), err)}
+		}
+		index++
+	}
+	return s.finish(enc)
+}
+
+// finish завершает поток, если кодеку это нужно (io.Closer)
+func (s *Serializer[E, D]) finish(enc Encoder) error {
+	if c, ok := enc.(io.Closer); ok {
+		if err := c.Close(); err != nil {
+			return fmt.Errorf("failed to finish %s stream: %w", formatName(s.codec), err)
+		}
+	}
+	return nil
+}
+
+// DecodeAll читает сущности из r по одной. Ошибка FromDTO или проверки
+// целостности относится к одной записи: она возвращается как *RecordError,
+// и чтение продолжается со следующей записи. Так же и с ошибкой разбора
+// строки построчного формата (*LineError). Прочие ошибки разбора потока
+// возвращаются последними — после них поток не читается.
+func (s *Serializer[E, D]) DecodeAll(r io.Reader) iter.Seq2[E, error] {
+	return func(yield func(E, error) bool) {
+		var zero E
+		sc, ok := s.codec.(StreamCodec)
+		if !ok {
+			yield(zero, fmt.Errorf("%w: %s", ErrNotStreamable, formatName(s.codec)))
+			return
+		}
+
+		name := formatName(s.codec)
+		dec := sc.NewDecoder(r)
+		for index := 0; ; index++ {
+			var dto D
+			if err := dec.Decode(&dto); err == io.EOF {
+				return
+			} else if err != nil {
+				// Ошибка строки построчного формата не мешает читать дальше
+				var lineErr *LineError
+				recoverable := errors.As(err, &lineErr)
+				err = &RecordError{Index: index, Err: fmt.Errorf("failed to unmarshal from %s: %w", name, err)}
+				if !yield(zero, err) || !recoverable {
+					return
+				}
+				continue
+			}
+
+			e, err := s.build(dto, name)
+			if err != nil {
+				if !yield(zero, &RecordError{Index: index, Err: err}) {
+					return
+				}
+				continue
+			}
+			if !yield(e, nil) {
+				return
+			}
+		}
+	}
+}
@@ -10,8 +10,9 @@ import (
 // -----------------   Геттеры --------------------------------------
 
 func (m *monster) Name() string {
-	length := monsterbitpack.GetNameSize(&m.packed)
-	return string(m.name[:length])
+	// Повреждённый буфер даёт прочитанную часть имени, ошибку сообщает Validate
+	name, _ := entity.UnpackName(m.name[:], int(monsterbitpack.GetNameSize(&m.packed)))
+	return name
 }
 
 func (m *monster) Gold() uint32                       { return m.gold }
@@ -24,9 +25,13 @@ func (m *monster) Mana() uint32   { return monsterbitpack.GetMana(&m.packed) }
 func (m *monster) Health() uint32 { return monsterbitpack.GetHealth(&m.packed) }
 func (m *monster) HasHouse() bool { return monsterbitpack.GetHouse(&m.packed) }
 
+func (m *monster) ManaOptional() (uint32, bool) { return monsterbitpack.GetManaOptional(&m.packed) }
+
 // ------------- Сеттеры для простых полей --------------------
+// Каждый сеттер пересчитывает код целостности записи (defer m.seal())
 
 func (m *monster) SetX(x int32) error {
+	defer m.seal()
 	if err := entity.ValidateCoordinate("X", x); err != nil {
 		return err
 	}
@@ -35,6 +40,7 @@ func (m *monster) SetX(x int32) error {
 }
 
 func (m *monster) SetY(y int32) error {
+	defer m.seal()
 	if err := entity.ValidateCoordinate("Y", y); err != nil {
 		return err
 	}
@@ -43,6 +49,7 @@ func (m *monster) SetY(y int32) error {
 }
 
 func (m *monster) SetZ(z int32) error {
+	defer m.seal()
 	if err := entity.ValidateCoordinate("Z", z); err != nil {
 		return err
 	}
@@ -51,6 +58,7 @@ func (m *monster) SetZ(z int32) error {
 }
 
 func (m *monster) SetGold(gold uint32) error {
+	defer m.seal()
 	if gold > config.MonsterMaxGold {
 		return fmt.Errorf("gold %d exceeds maximum %d", gold, config.MonsterMaxGold)
 	}
@@ -61,12 +69,13 @@ func (m *monster) SetGold(gold uint32) error {
 // Сеттеры для строкового поля (с сохранением в битовой карте длины)
 
 func (m *monster) SetName(name string) error {
-	validated, err := entity.ValidateAndCopyName(m.name[:], name)
+	defer m.seal()
+	validated, err := entity.PackName(m.name[:], name)
 	if err != nil {
 		return err
 	}
 	// Обновляем длину в битовом поле
-	if err = monsterbitpack.SetSizeName(&m.packed, uint32(len(validated))); err != nil {
+	if err = monsterbitpack.SetNameSize(&m.packed, uint32(len(validated))); err != nil {
 		return fmt.Errorf("failed to update name length: %w", err)
 	}
 	return nil
@@ -75,6 +84,7 @@ func (m *monster) SetName(name string) error {
 // ------------- Сеттеры для битово-упакованных полей целых --------------------
 
 func (m *monster) SetMana(mana uint32) error {
+	defer m.seal()
 	// Проверка на логическую корректность
 	if mana > config.MonsterMaxMana {
 		return fmt.Errorf("mana %d exceeds maximum %d", mana, config.MonsterMaxMana)
@@ -84,7 +94,14 @@ func (m *monster) SetMana(mana uint32) error {
 	return nil
 }
 
+// ClearMana переводит ману в состояние «не задана» (SetMana задаёт её снова)
+func (m *monster) ClearMana() {
+	defer m.seal()
+	monsterbitpack.ClearMana(&m.packed)
+}
+
 func (m *monster) SetHealth(health uint32) error {
+	defer m.seal()
 	if health > config.MonsterMaxHealth {
 		return fmt.Errorf("health %d exceeds maximum %d", health, config.MonsterMaxHealth)
 	}
@@ -93,4 +110,34 @@ func (m *monster) SetHealth(health uint32) error {
 	return nil
 }
 
-func (m *monster) SetHouse(has bool) error { return monsterbitpack.SetHouse(&m.packed, has) }
+func (m *monster) SetHouse(has bool) error {
+	defer m.seal()
+	return monsterbitpack.SetHouse(&m.packed, has)
+}
+
+// ------------- Арифметика над битово-упакованными полями --------------------
+//  Лимиты полей схемы совпадают с лимитами config: Saturating прижимает
+//  результат к границам, checked версии возвращают ошибку и не меняют поле
+
+func (m *monster) AddHealth(delta uint32) uint32 {
+	defer m.seal()
+	return monsterbitpack.AddHealthSaturating(&m.packed, delta)
+}
+
+func (m *monster) TakeDamage(damage uint32) uint32 {
+	defer m.seal()
+	return monsterbitpack.SubHealthSaturating(&m.packed, damage)
+}
+
+func (m *monster) AddMana(delta uint32) uint32 {
+	defer m.seal()
+	return monsterbitpack.AddManaSaturating(&m.packed, delta)
+}
+
+func (m *monster) DrainMana(amount uint32) error {
+	defer m.seal()
+	if _, err := monsterbitpack.SubMana(&m.packed, amount); err != nil {
+		return fmt.Errorf("not enough mana to drain %d: %w", amount, err)
+	}
+	return nil
+}
@@ -0,0 +1,55 @@
+package monster
+
+import (
+	"GamePerson/internal/model/game/creatures/base/entity"
+	"GamePerson/internal/model/game/creatures/base/record"
+	"encoding"
+	"fmt"
+)
+
+// ================  Двоичный формат monster ======================================
+//
+// MarshalBinary пишет 64-байтовую запись как есть после заголовка
+// record (magic, вид существа, версия раскладки — см. record.Magic).
+// UnmarshalBinary проверяет только заголовок и размер: значения полей
+// и код целостности проверяет IntegrityChecker (см. Serializer.FromBinary).
+
+var (
+	_ encoding.BinaryMarshaler   = (*monster)(nil)
+	_ encoding.BinaryUnmarshaler = (*monster)(nil)
+)
+
+// MarshalBinary кодирует запись с заголовком
+func (m *monster) MarshalBinary() ([]byte, error) {
+	rec := m.record()
+	return recordLayout.AppendBinary(make([]byte, 0, recordLayout.BinarySize()), &rec), nil
+}
+
+// UnmarshalBinary заменяет запись декодированной; при ошибке запись не меняется
+func (m *monster) UnmarshalBinary(data []byte) error {
+	rec, err := recordLayout.ParseBinary(data)
+	if err != nil {
+		return fmt.Errorf("failed to unmarshal monster: %w", err)
+	}
+	m.loadRecord(rec)
+	return nil
+}
+
+// record — 64 байта записи: имя, CRC-16, упаковка, золото и координаты в little-endian
+func (m *monster) record() [record.Size]byte {
+	var rec [record.Size]byte
+	n := copy(rec[:], m.name[:])
+	n += copy(rec[n:], m.check[:])
+	n += copy(rec[n:], m.packed[:])
+	tail := entity.RecordTail(m.gold, m.x, m.y, m.z)
+	copy(rec[n:], tail[:])
+	return rec
+}
+
+// loadRecord — обратное к record, без проверок
+func (m *monster) loadRecord(rec [record.Size]byte) {
+	n := copy(m.name[:], rec[:])
+	n += copy(m.check[:], rec[n:])
+	n += copy(m.packed[:], rec[n:])
+	m.gold, m.x, m.y, m.z = entity.ParseRecordTail(rec[n:])
+}
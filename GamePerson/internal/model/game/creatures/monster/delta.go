@@ -0,0 +1,80 @@
+package monster
+
+import (
+	monsterbitpack "GamePerson/internal/model/bitpack/monster"
+	"GamePerson/internal/model/config"
+	"GamePerson/internal/model/game/creatures/base/record"
+	"fmt"
+)
+
+// ================  Дельты записей monster ====================================
+//
+// Как у person: Diff строит record.Delta между двумя монстрами, Apply
+// проверяет её и патчит запись. CRC-16 записи (check) — отдельное поле
+// раскладки и переносится дельтой вместе с изменёнными полями.
+
+const (
+	nameBits   = 8 * config.MaxNameLength
+	checkBits  = 16
+	packedBits = 8 * len(monsterbitpack.Packed32{})
+	goldBit    = nameBits + checkBits + packedBits
+)
+
+// recordLayout — раскладка 64-байтовой записи monster (порядок полей структуры)
+var recordLayout = record.MustNewLayout("monster", append(
+	record.SchemaFields(nameBits+checkBits, monsterbitpack.Schema()),
+	record.Field{Name: "name", Start: 0, Width: nameBits, Spec: "6-bit chars"},
+	record.Field{Name: "check", Start: nameBits, Width: checkBits, Spec: "CRC-16 LE"},
+	record.Field{Name: "gold", Start: goldBit, Width: 32, Spec: "uint32 LE"},
+	record.Field{Name: "x", Start: goldBit + 32, Width: 32, Spec: "int32 LE"},
+	record.Field{Name: "y", Start: goldBit + 64, Width: 32, Spec: "int32 LE"},
+	record.Field{Name: "z", Start: goldBit + 96, Width: 32, Spec: "int32 LE"},
+)...)
+
+// RecordLayout — раскладка записи monster для дельт (вид, версия, поля)
+func RecordLayout() *record.Layout {
+	return recordLayout
+}
+
+// Diff строит дельту, переводящую монстра from в to
+func Diff(from, to Monster) (record.Delta, error) {
+	a, err := asMonster(from)
+	if err != nil {
+		return record.Delta{}, err
+	}
+	b, err := asMonster(to)
+	if err != nil {
+		return record.Delta{}, err
+	}
+	before, after := a.record(), b.record()
+	return recordLayout.Diff(&before, &after), nil
+}
+
+// Apply применяет дельту к монстру. Пропатченная запись проходит Validate
+// (включая CRC-16); при любой ошибке m не меняется.
+func Apply(m Monster, d record.Delta) error {
+	target, err := asMonster(m)
+	if err != nil {
+		return err
+	}
+	patched, err := recordLayout.Patch(target.record(), d)
+	if err != nil {
+		return fmt.Errorf("failed to apply delta: %w", err)
+	}
+
+	var next monster
+	next.loadRecord(patched)
+	if err := next.Validate(); err != nil {
+		return fmt.Errorf("delta produces invalid monster: %w", err)
+	}
+	*target = next
+	return nil
+}
+
+func asMonster(m Monster) (*monster, error) {
+	impl, ok := m.(*monster)
+	if !ok || impl == nil {
+		return nil, fmt.Errorf("unsupported Monster implementation %T", m)
+	}
+	return impl, nil
+}
@@ -0,0 +1,136 @@
+package monster
+
+import (
+	"GamePerson/internal/model/config"
+	"GamePerson/internal/model/game/creatures/base/record"
+	"math/rand"
+	"testing"
+	"unsafe"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMonsterRecordMatchesMemory(t *testing.T) {
+	created, err := NewMonster(WithName("Ghost"), WithGold(77), WithCoordinates(1, -2, 3))
+	require.NoError(t, err)
+	m := created.(*monster)
+
+	rec := m.record()
+	raw := unsafe.Slice((*byte)(unsafe.Pointer(m)), unsafe.Sizeof(*m))
+	assert.Equal(t, raw, rec[:], "record must mirror struct layout")
+
+	var restored monster
+	restored.loadRecord(rec)
+	assert.Equal(t, *m, restored)
+}
+
+func TestMonsterDeltaRoundTrip(t *testing.T) {
+	rng := rand.New(rand.NewSource(20))
+	for i := 0; i < 300; i++ {
+		from := randomMonster(t, rng)
+		to := cloneMonster(from)
+		mutateMonster(t, rng, to)
+
+		d, err := Diff(from, to)
+		require.NoError(t, err)
+
+		data, err := d.MarshalBinary()
+		require.NoError(t, err)
+		var got record.Delta
+		require.NoError(t, got.UnmarshalBinary(data))
+		require.Equal(t, d, got)
+
+		patched := cloneMonster(from)
+		require.NoError(t, Apply(patched, got), "iteration %d: %v -> %v", i, from, to)
+		require.Equal(t, to.record(), patched.record(), "iteration %d", i)
+	}
+}
+
+func TestMonsterDeltaRejects(t *testing.T) {
+	rng := rand.New(rand.NewSource(21))
+	from := randomMonster(t, rng)
+	to := cloneMonster(from)
+	require.NoError(t, to.SetHealth(from.Health()/2))
+
+	d, err := Diff(from, to)
+	require.NoError(t, err)
+	fields, err := RecordLayout().ChangedFields(d)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"check", "health"}, fields)
+
+	// Дельта персонажа не применима к монстру
+	foreign := d
+	foreign.Kind = "person"
+	assert.ErrorIs(t, Apply(cloneMonster(from), foreign), record.ErrDeltaKind)
+
+	stale := d
+	stale.Version++
+	assert.ErrorIs(t, Apply(cloneMonster(from), stale), record.ErrDeltaVersion)
+
+	target := cloneMonster(to)
+	assert.ErrorIs(t, Apply(target, d), record.ErrDeltaBase)
+	assert.Equal(t, to.record(), target.record(), "record must not change on error")
+
+	// Запись с неверным CRC-16 дельта не восстановит: Apply её отвергает
+	broken := cloneMonster(to)
+	broken.check[0] ^= 0xFF
+	d, err = Diff(from, broken)
+	require.NoError(t, err)
+	target = cloneMonster(from)
+	assert.ErrorContains(t, Apply(target, d), "record checksum mismatch")
+	assert.Equal(t, from.record(), target.record())
+}
+
+// ============ Вспомогательные функции ============
+
+const deltaNameChars = "ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz0123456789_-"
+
+func randomName(rng *rand.Rand) string {
+	b := make([]byte, 1+rng.Intn(config.MaxNameChars))
+	for i := range b {
+		b[i] = deltaNameChars[rng.Intn(len(deltaNameChars))]
+	}
+	return string(b)
+}
+
+func randomCoord(rng *rand.Rand) int32 {
+	return config.MinCoord + int32(rng.Int63n(int64(config.MaxCoord)-int64(config.MinCoord)+1))
+}
+
+func randomMonster(t *testing.T, rng *rand.Rand) *monster {
+	t.Helper()
+	created, err := NewMonster(
+		WithName(randomName(rng)),
+		WithHealth(uint32(rng.Intn(int(config.MonsterMaxHealth)+1))),
+		WithMana(uint32(rng.Intn(int(config.MonsterMaxMana)+1))),
+		WithGold(uint32(rng.Intn(int(config.MonsterMaxGold)+1))),
+		WithCoordinates(randomCoord(rng), randomCoord(rng), randomCoord(rng)),
+		WithHouse(rng.Intn(2) == 1),
+	)
+	require.NoError(t, err)
+	return created.(*monster)
+}
+
+// mutateMonster меняет случайное подмножество полей (возможно, пустое)
+func mutateMonster(t *testing.T, rng *rand.Rand, m *monster) {
+	t.Helper()
+	steps := []func() error{
+		func() error { return m.SetName(randomName(rng)) },
+		func() error { return m.SetHealth(uint32(rng.Intn(int(config.MonsterMaxHealth) + 1))) },
+		func() error { m.ClearMana(); return nil },
+		func() error { return m.SetGold(uint32(rng.Intn(int(config.MonsterMaxGold) + 1))) },
+		func() error { return m.SetHouse(rng.Intn(2) == 1) },
+		func() error { return m.SetY(randomCoord(rng)) },
+	}
+	for _, step := range steps {
+		if rng.Intn(3) == 0 {
+			require.NoError(t, step())
+		}
+	}
+}
+
+func cloneMonster(m *monster) *monster {
+	c := *m
+	return &c
+}
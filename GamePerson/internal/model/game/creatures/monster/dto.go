@@ -1,15 +1,19 @@
 package monster
 
+import (
+	monsterbitpack "GamePerson/internal/model/bitpack/monster"
+)
+
 // MonsterDTO - Data Transfer Object для сериализации/десериализации Monster
 type MonsterDTO struct {
-	Name     string `json:"name" xml:"Name" yaml:"name"`
-	Health   uint32 `json:"health" xml:"Health" yaml:"health"`
-	Mana     uint32 `json:"mana" xml:"Mana" yaml:"mana"`
-	Gold     uint32 `json:"gold" xml:"Gold" yaml:"gold"`
-	HasHouse bool   `json:"has_house" xml:"HasHouse" yaml:"has_house"`
-	X        int32  `json:"x" xml:"X" yaml:"x"`
-	Y        int32  `json:"y" xml:"Y" yaml:"y"`
-	Z        int32  `json:"z" xml:"Z" yaml:"z"`
+	Name     string  `json:"name" xml:"Name" yaml:"name" csv:"name"`
+	Health   uint32  `json:"health" xml:"Health" yaml:"health" csv:"health"`
+	Mana     *uint32 `json:"mana,omitempty" xml:"Mana,omitempty" yaml:"mana,omitempty" csv:"mana"` // nil — мана не задана
+	Gold     uint32  `json:"gold" xml:"Gold" yaml:"gold" csv:"gold"`
+	HasHouse bool    `json:"has_house" xml:"HasHouse" yaml:"has_house" csv:"has_house"`
+	X        int32   `json:"x" xml:"X" yaml:"x" csv:"x"`
+	Y        int32   `json:"y" xml:"Y" yaml:"y" csv:"y"`
+	Z        int32   `json:"z" xml:"Z" yaml:"z" csv:"z"`
 }
 
 // ToDTO преобразует Monster в MonsterDTO
@@ -17,7 +21,7 @@ func ToDTO(m Monster) MonsterDTO {
 	return MonsterDTO{
 		Name:     m.Name(),
 		Health:   m.Health(),
-		Mana:     m.Mana(),
+		Mana:     manaOf(m),
 		Gold:     m.Gold(),
 		HasHouse: m.HasHouse(),
 		X:        m.X(),
@@ -26,14 +30,39 @@ func ToDTO(m Monster) MonsterDTO {
 	}
 }
 
-// FromDTO создает Monster из MonsterDTO
+// manaOf возвращает ману для DTO: nil, если она не задана
+func manaOf(m Monster) *uint32 {
+	if mana, ok := m.ManaOptional(); ok {
+		return &mana
+	}
+	return nil
+}
+
+// FromDTO создает Monster из MonsterDTO.
+// Отсутствующая в DTO мана (nil) остаётся незаданной, а не превращается в 0.
 func FromDTO(dto MonsterDTO) (Monster, error) {
 	return NewMonster(
 		WithName(dto.Name),
-		WithHealth(dto.Health),
-		WithMana(dto.Mana),
 		WithGold(dto.Gold),
-		WithHouse(dto.HasHouse),
 		WithCoordinates(dto.X, dto.Y, dto.Z),
+		withPackedFields(dto),
 	)
 }
+
+// withPackedFields применяет битово-упакованные поля DTO через View:
+// одна распаковка буфера и одна запись в Commit.
+// Лимиты полей схемы совпадают с лимитами config (см. schema.yaml).
+func withPackedFields(dto MonsterDTO) Option {
+	return func(m *monster) error {
+		view := monsterbitpack.NewView(&m.packed)
+		view.SetHouseUnchecked(dto.HasHouse)
+
+		// Ошибки содержат имя поля (monster.health ...), Commit возвращает первую
+		_ = view.SetHealth(dto.Health)
+		view.ClearMana()
+		if dto.Mana != nil {
+			_ = view.SetMana(*dto.Mana)
+		}
+		return view.Commit()
+	}
+}
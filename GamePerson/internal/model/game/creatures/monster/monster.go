@@ -4,14 +4,16 @@ import (
 	monsterbitpack "GamePerson/internal/model/bitpack/monster"
 	"GamePerson/internal/model/config"
 	"GamePerson/internal/model/game/creatures/base/entity"
+	"encoding"
+	"encoding/binary"
 	"errors"
 	"fmt"
 )
 
 // GameMonster Схема битовой упаковки в 32 битах (4 байт) описана в schema
 type monster struct {
-	name   [config.MaxNameLength]byte // 42 байта: символы имени латиницей (без указателей!)
-	_      [2]byte                    // ← явный паддинг для выравнивания
+	name   [config.MaxNameLength]byte // 42 байта: имя по 6 бит на символ, до 56 символов (без указателей!)
+	check  [2]byte                    // 2 байта: CRC-16 записи (бывший паддинг для выравнивания)
 	packed monsterbitpack.Packed32    // 4 байт: битовая упаковка мелких полей (см. ниже)
 	gold   uint32                     // 4 байта: золото [0…2_000_000_000]
 	x      int32                      // 4 байта: координата X [-2_000_000_000…2_000_000_000]
@@ -28,6 +30,8 @@ type Monster interface {
 	entity.Wealthy
 	entity.Magical
 	entity.PropertyOwner
+	encoding.BinaryMarshaler   // 64-байтовая запись с заголовком (см. binary.go)
+	encoding.BinaryUnmarshaler // без проверки значений — её выполняет Serializer.FromBinary
 }
 
 // ------------- Конструктор -----------------------------------
@@ -48,9 +52,34 @@ func NewMonster(options ...Option) (Monster, error) {
 			return nil, fmt.Errorf("failed to apply option: %w", err)
 		}
 	}
+	// Опции вроде withPackedFields пишут буфер напрямую, минуя сеттеры
+	m.seal()
 	return m, nil
 }
 
+// ------------- Код целостности записи -----------------------------------
+//  Бывший паддинг check хранит CRC-16 всей записи (см. entity.RecordCRC16)
+
+// checksum — CRC-16 записи без поля check
+func (m *monster) checksum() uint16 {
+	tail := entity.RecordTail(m.gold, m.x, m.y, m.z)
+	return entity.RecordCRC16(m.name[:], m.packed[:], tail[:])
+}
+
+// seal пересчитывает CRC-16 после изменения записи
+func (m *monster) seal() {
+	binary.LittleEndian.PutUint16(m.check[:], m.checksum())
+}
+
+// VerifyChecksum сообщает entity.ErrChecksumMismatch, если CRC-16 не совпадает с записью
+func (m *monster) VerifyChecksum() error {
+	stored, computed := binary.LittleEndian.Uint16(m.check[:]), m.checksum()
+	if stored != computed {
+		return entity.NewChecksumError(stored, computed)
+	}
+	return nil
+}
+
 func mustSetDefaults(m *monster) {
 	mdh := config.MonsterDefaultHealth
 	if err := m.SetHealth(mdh); err != nil {
@@ -85,18 +114,13 @@ func (m *monster) String() string {
 func (m *monster) Validate() error {
 	// Собираем ВСЕ ошибки, а не останавливаемся на первой
 	var errs []error
-	// Инвариант 1: длина имени соответствует буферу
+	// Инвариант 1: длина имени в символах не превышает вместимость буфера
 	nameLen := monsterbitpack.GetNameSize(&m.packed)
-	if nameLen > config.MaxNameLength {
-		errs = append(errs, fmt.Errorf("name length %d exceeds maximum %d", nameLen, config.MaxNameLength))
-	}
-
-	// Инвариант 2: байты после имени — нулевые (защита от мусора в буфере)
-	for i := nameLen; i < config.MaxNameLength; i++ {
-		if m.name[i] != 0 {
-			errs = append(errs, fmt.Errorf("name buffer contains non-zero garbage at position %d", i))
-			break
-		}
+	if nameLen > config.MaxNameChars {
+		errs = append(errs, fmt.Errorf("name length %d exceeds maximum %d", nameLen, config.MaxNameChars))
+	} else if _, err := entity.UnpackName(m.name[:], int(nameLen)); err != nil {
+		// Инвариант 2: коды символов допустимы, биты после имени — нулевые (защита от мусора в буфере)
+		errs = append(errs, fmt.Errorf("invalid name buffer: %w", err))
 	}
 
 	// Инвариант 3: координаты в допустимом диапазоне
@@ -119,6 +143,10 @@ func (m *monster) Validate() error {
 	if gold := m.Gold(); gold > config.MonsterMaxGold {
 		errs = append(errs, fmt.Errorf("gold %d exceeds maximum %d", gold, config.MonsterMaxGold))
 	}
+	// Инвариант 5: код целостности совпадает с содержимым записи
+	if err := m.VerifyChecksum(); err != nil {
+		errs = append(errs, err)
+	}
 	if len(errs) > 0 {
 		return errors.Join(errs...)
 	}
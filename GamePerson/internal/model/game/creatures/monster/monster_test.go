@@ -1,8 +1,13 @@
 package monster
 
 import (
+	"GamePerson/internal/bitpack"
+	"GamePerson/internal/model/config"
+	"GamePerson/internal/model/game/creatures/base/entity"
 	"testing"
 	"unsafe"
+
+	"github.com/stretchr/testify/assert"
 )
 
 func TestGameMonsterSize(t *testing.T) {
@@ -13,3 +18,82 @@ func TestGameMonsterSize(t *testing.T) {
 			"Check field order and alignment!", expectedSize, actual)
 	}
 }
+
+func TestMonsterArithmetic(t *testing.T) {
+	m, err := NewMonster(WithHealth(9990), WithMana(50))
+	assert.NoError(t, err)
+
+	assert.Equal(t, config.MonsterMaxHealth, m.AddHealth(100))
+	assert.Equal(t, uint32(9000), m.TakeDamage(1000))
+	assert.Equal(t, uint32(0), m.TakeDamage(20000))
+
+	assert.ErrorIs(t, m.DrainMana(51), bitpack.ErrValueUnderflow)
+	assert.NoError(t, m.DrainMana(50))
+	assert.Equal(t, uint32(0), m.Mana())
+	assert.Equal(t, uint32(10), m.AddMana(10))
+}
+
+func TestMonsterOptionalMana(t *testing.T) {
+	m, err := NewMonster(WithName("Ghost"))
+	assert.NoError(t, err)
+	m.ClearMana()
+
+	data, err := NewSerializer(nil).ToJSON(m)
+	assert.NoError(t, err)
+	assert.NotContains(t, string(data), `"mana"`)
+
+	restored, err := NewFromJSON(data)
+	assert.NoError(t, err)
+	_, ok := restored.ManaOptional()
+	assert.False(t, ok, "missing mana must stay unset")
+
+	assert.Equal(t, uint32(5), restored.AddMana(5))
+	mana, ok := restored.ManaOptional()
+	assert.True(t, ok, "arithmetic marks mana as set")
+	assert.Equal(t, uint32(5), mana)
+}
+
+func TestMonsterChecksum(t *testing.T) {
+	created, err := NewMonster(WithName("Ghost"), WithHealth(500), WithGold(77), WithCoordinates(1, -2, 3))
+	assert.NoError(t, err)
+	m := created.(*monster)
+	assert.NoError(t, m.VerifyChecksum())
+
+	// Каждый сеттер пересчитывает код
+	steps := map[string]func(){
+		"SetName":    func() { _ = m.SetName("Goblin King") },
+		"SetGold":    func() { _ = m.SetGold(9999) },
+		"SetX":       func() { _ = m.SetX(-100) },
+		"SetMana":    func() { _ = m.SetMana(700) },
+		"ClearMana":  func() { m.ClearMana() },
+		"SetHealth":  func() { _ = m.SetHealth(42) },
+		"SetHouse":   func() { _ = m.SetHouse(true) },
+		"AddHealth":  func() { m.AddHealth(5) },
+		"TakeDamage": func() { m.TakeDamage(3) },
+		"AddMana":    func() { m.AddMana(10) },
+		"DrainMana":  func() { _ = m.DrainMana(1) },
+	}
+	for name, step := range steps {
+		step()
+		assert.NoError(t, m.VerifyChecksum(), name)
+	}
+	assert.NoError(t, m.Validate())
+
+	// CRC-16 обнаруживает изменение любого бита 64-байтовой записи
+	raw := unsafe.Slice((*byte)(unsafe.Pointer(m)), unsafe.Sizeof(*m))
+	checker := entity.NewIntegrityChecker()
+	for bit := 0; bit < 8*len(raw); bit++ {
+		raw[bit/8] ^= 1 << (bit % 8)
+		err := checker.Check(m, "raw")
+		raw[bit/8] ^= 1 << (bit % 8)
+		if !assert.ErrorIs(t, err, entity.ErrChecksumMismatch, "flipped bit %d", bit) {
+			break
+		}
+	}
+	assert.NoError(t, checker.Check(m, "raw"))
+
+	// Две ошибки в разных байтах тоже видны
+	m.name[0] ^= 0x01
+	m.gold ^= 0x80
+	assert.ErrorIs(t, m.Validate(), entity.ErrChecksumMismatch)
+}
@@ -1,6 +1,9 @@
 package monster
 
-import "fmt"
+import (
+	"GamePerson/internal/model/game/creatures/base/entity"
+	"fmt"
+)
 
 // ------------------ Для конструктора---------------------------------
 // ----------------- Опции свойств -------------------------------------
@@ -8,7 +11,7 @@ import "fmt"
 func WithName(name string) Option {
 	return func(p *monster) error {
 		if err := p.SetName(name); err != nil {
-			return fmt.Errorf("failed to apply option WithName: %w", err)
+			return fmt.Errorf("failed to apply option WithName: %w", entity.NewFieldError("name", err))
 		}
 		return nil
 	}
@@ -17,13 +20,13 @@ func WithName(name string) Option {
 func WithCoordinates(x, y, z int32) Option {
 	return func(m *monster) error {
 		if err := m.SetX(x); err != nil {
-			return fmt.Errorf("failed to apply option WithCoordinates (X): %w", err)
+			return fmt.Errorf("failed to apply option WithCoordinates (X): %w", entity.NewFieldError("x", err))
 		}
 		if err := m.SetY(y); err != nil {
-			return fmt.Errorf("failed to apply option WithCoordinates (Y): %w", err)
+			return fmt.Errorf("failed to apply option WithCoordinates (Y): %w", entity.NewFieldError("y", err))
 		}
 		if err := m.SetZ(z); err != nil {
-			return fmt.Errorf("failed to apply option WithCoordinates (Z): %w", err)
+			return fmt.Errorf("failed to apply option WithCoordinates (Z): %w", entity.NewFieldError("z", err))
 		}
 		return nil
 	}
@@ -32,7 +35,7 @@ func WithCoordinates(x, y, z int32) Option {
 func WithGold(gold uint32) Option {
 	return func(m *monster) error {
 		if err := m.SetGold(gold); err != nil {
-			return fmt.Errorf("WithGold: %w", err)
+			return fmt.Errorf("WithGold: %w", entity.NewFieldError("gold", err))
 		}
 		return nil
 	}
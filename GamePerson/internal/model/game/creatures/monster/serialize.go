@@ -3,6 +3,9 @@ package monster
 import (
 	"GamePerson/internal/model/game/creatures/base/entity"
 	"GamePerson/internal/model/game/creatures/base/serializer"
+	"io"
+	"iter"
+	"os"
 )
 
 // ===== Основные конструкторы Person из внешних представлений (99% случаев — без параметров) ============
@@ -22,6 +25,49 @@ func NewFromYAML(data []byte) (Monster, error) {
 	return newFromYAML(data, nil)
 }
 
+// NewFromBinary создаёт персонажа из двоичной записи (MarshalBinary) с проверкой целостности по умолчанию
+func NewFromBinary(data []byte) (Monster, error) {
+	return newFromBinary(data, nil)
+}
+
+// NewFrom создаёт монстра из данных формата format — имени, MIME-типа или
+// расширения кодека из serializer.Default ("json", "application/yaml", ".yml")
+func NewFrom(format string, data []byte) (Monster, error) {
+	return NewSerializer(nil).Decode(format, data)
+}
+
+// NewFromFile читает монстра из файла path; формат определяется
+// по расширению, а без него — по содержимому
+func NewFromFile(path string) (Monster, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	return NewSerializer(nil).DecodeAuto(path, data)
+}
+
+// ExportNDJSON пишет монстров в w по одному JSON-объекту на строку
+func ExportNDJSON(w io.Writer, items iter.Seq[Monster]) error {
+	return NewSerializer(nil).ExportNDJSON(w, items)
+}
+
+// ImportNDJSON читает монстров из NDJSON с проверкой целостности каждой строки
+// (см. serializer.ImportPolicy и serializer.ImportReport)
+func ImportNDJSON(r io.Reader, policy serializer.ImportPolicy) ([]Monster, *serializer.ImportReport, error) {
+	return NewSerializer(nil).ImportNDJSON(r, policy)
+}
+
+// ExportCSV пишет монстров в w таблицей CSV с заголовком из полей DTO
+func ExportCSV(w io.Writer, items iter.Seq[Monster]) error {
+	return NewSerializer(nil).ExportCSV(w, items)
+}
+
+// ImportCSV читает монстров из CSV с проверкой целостности каждой строки;
+// ошибки содержат номер строки и колонку (см. serializer.LineError)
+func ImportCSV(r io.Reader, policy serializer.ImportPolicy) ([]Monster, *serializer.ImportReport, error) {
+	return NewSerializer(nil).ImportCSV(r, policy)
+}
+
 // ============ Расширенные конструкторы (специальные случаи) ============
 
 // NewFromJSONWithIntegrity создаёт персонажа из JSON с кастомным проверяющим целостности
@@ -39,6 +85,11 @@ func NewFromYAMLWithIntegrity(data []byte, integrity *entity.IntegrityChecker) (
 	return newFromYAML(data, integrity)
 }
 
+// NewFromBinaryWithIntegrity создаёт персонажа из двоичной записи с кастомным проверяющим целостности
+func NewFromBinaryWithIntegrity(data []byte, integrity *entity.IntegrityChecker) (Monster, error) {
+	return newFromBinary(data, integrity)
+}
+
 // ---------------  Внутренняя реализация конструкторов -------------------------------
 
 func newFromJSON(data []byte, integrity *entity.IntegrityChecker) (Monster, error) {
@@ -51,6 +102,11 @@ func newFromXML(data []byte, integrity *entity.IntegrityChecker) (Monster, error
 	return ser.FromXML(data)
 }
 
+func newFromBinary(data []byte, integrity *entity.IntegrityChecker) (Monster, error) {
+	ser := NewSerializer(integrity)
+	return ser.FromBinary(data)
+}
+
 func newFromYAML(data []byte, integrity *entity.IntegrityChecker) (Monster, error) {
 	ser := NewSerializer(integrity)
 	return ser.FromYAML(data)
@@ -60,6 +116,7 @@ type monsterConverter struct{}
 
 func (c monsterConverter) ToDTO(m Monster) MonsterDTO              { return ToDTO(m) }
 func (c monsterConverter) FromDTO(dto MonsterDTO) (Monster, error) { return FromDTO(dto) }
+func (c monsterConverter) NewEntity() Monster                      { return &monster{} }
 
 func NewSerializer(integrity *entity.IntegrityChecker) *serializer.Serializer[Monster, MonsterDTO] {
 	return serializer.New[Monster, MonsterDTO](monsterConverter{}, integrity)
@@ -1,6 +1,13 @@
 package monster
 
 import (
+	"GamePerson/internal/model/game/creatures/base/entity"
+	"GamePerson/internal/model/game/creatures/base/record"
+	"GamePerson/internal/model/game/creatures/base/serializer"
+	"bytes"
+	"math/rand"
+	"slices"
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -19,7 +26,7 @@ func TestMonsterJSONSerialization(t *testing.T) {
 	)
 	require.NoError(t, err)
 
-	serializer := NewSerializer()
+	serializer := NewSerializer(nil)
 
 	// Сериализация в JSON
 	jsonData, err := serializer.ToJSON(m)
@@ -46,7 +53,7 @@ func TestMonsterXMLSerialization(t *testing.T) {
 	)
 	require.NoError(t, err)
 
-	serializer := NewSerializer()
+	serializer := NewSerializer(nil)
 
 	// Сериализация в XML
 	xmlData, err := serializer.ToXML(m)
@@ -73,7 +80,7 @@ func TestMonsterYAMLSerialization(t *testing.T) {
 	)
 	require.NoError(t, err)
 
-	serializer := NewSerializer()
+	serializer := NewSerializer(nil)
 
 	// Сериализация в YAML
 	yamlData, err := serializer.ToYAML(m)
@@ -96,13 +103,13 @@ func TestMonsterRoundTripAllFormats(t *testing.T) {
 		WithName("RoundTripMonster"),
 		WithHealth(999),
 		WithMana(999),
-		WithGold(1000000),
+		WithGold(10000),
 		WithHouse(true),
 		WithCoordinates(12345, -54321, 99999),
 	)
 	require.NoError(t, err)
 
-	serializer := NewSerializer()
+	serializer := NewSerializer(nil)
 
 	// Тест JSON
 	jsonData, err := serializer.ToJSON(original)
@@ -126,6 +133,112 @@ func TestMonsterRoundTripAllFormats(t *testing.T) {
 	assertMonsterEqual(t, original, fromYAML)
 }
 
+func TestMonsterBinarySerialization(t *testing.T) {
+	m, err := NewMonster(
+		WithName("Binary Dragon"),
+		WithHealth(10000),
+		WithMana(1),
+		WithGold(42),
+		WithHouse(true),
+		WithCoordinates(7, -8, 9),
+	)
+	require.NoError(t, err)
+
+	serializer := NewSerializer(nil)
+	data, err := serializer.ToBinary(m)
+	require.NoError(t, err)
+	require.Len(t, data, 4+1+len("monster")+4+64)
+	assert.Equal(t, m.(*monster).record(), [64]byte(data[16:]))
+
+	restored, err := serializer.FromBinary(data)
+	require.NoError(t, err)
+	assertMonsterEqual(t, m, restored)
+
+	// Повреждение любого байта записи ловит CRC-16
+	for i := 16; i < len(data); i++ {
+		broken := append([]byte(nil), data...)
+		broken[i] ^= 0x10
+		_, err := NewFromBinary(broken)
+		if !assert.ErrorIs(t, err, entity.ErrChecksumMismatch, "byte %d", i) {
+			break
+		}
+	}
+
+	_, err = NewFromBinary(data[:20])
+	assert.ErrorIs(t, err, record.ErrBinarySize)
+}
+
+func TestMonsterCodecs(t *testing.T) {
+	m, err := NewMonster(WithName("Codec Ogre"), WithHealth(4321), WithHouse(true))
+	require.NoError(t, err)
+	ser := NewSerializer(nil)
+
+	for _, codec := range serializer.Default.Codecs() {
+		t.Run(codec.Name(), func(t *testing.T) {
+			data, err := ser.Encode(codec.MIME(), m)
+			require.NoError(t, err)
+
+			restored, err := NewFrom(codec.Extensions()[0], data)
+			require.NoError(t, err)
+			assertMonsterEqual(t, m, restored)
+
+			// CSV не распознаётся по содержимому — только по расширению
+			if _, ok := codec.(serializer.Sniffer); !ok {
+				return
+			}
+			restored, err = ser.DecodeAuto("", data)
+			require.NoError(t, err)
+			assertMonsterEqual(t, m, restored)
+		})
+	}
+}
+
+func TestMonsterNDJSON(t *testing.T) {
+	rng := rand.New(rand.NewSource(24))
+	monsters := make([]Monster, 30)
+	for i := range monsters {
+		monsters[i] = randomMonster(t, rng)
+	}
+
+	var buf bytes.Buffer
+	require.NoError(t, ExportNDJSON(&buf, slices.Values(monsters)))
+	buf.WriteString(`{"name":"Giant","health":20000}` + "\n")
+
+	imported, report, err := ImportNDJSON(&buf, serializer.SkipInvalid)
+	require.NoError(t, err)
+	require.Len(t, imported, len(monsters))
+	for i := range monsters {
+		assert.Equal(t, monsters[i].(*monster).record(), imported[i].(*monster).record(), "record %d", i)
+	}
+	require.Len(t, report.Invalid, 1)
+	assert.Equal(t, len(monsters)+1, report.Invalid[0].Line)
+	assert.ErrorContains(t, &report.Invalid[0], "monster.health")
+}
+
+func TestMonsterCSV(t *testing.T) {
+	rng := rand.New(rand.NewSource(25))
+	monsters := make([]Monster, 50)
+	for i := range monsters {
+		monsters[i] = randomMonster(t, rng)
+	}
+
+	ser := NewSerializer(nil).WithCodec(serializer.MustNewCSVCodec('\t'))
+	var buf bytes.Buffer
+	require.NoError(t, ser.EncodeAll(&buf, slices.Values(monsters)))
+	assert.True(t, strings.HasPrefix(buf.String(), "name\thealth\tmana\tgold\thas_house\tx\ty\tz\n"))
+	buf.WriteString("Giant\t20000\t\t0\tfalse\t0\t0\t0\n")
+
+	imported, report, err := ser.Import(&buf, serializer.SkipInvalid)
+	require.NoError(t, err)
+	require.Len(t, imported, len(monsters))
+	for i := range monsters {
+		assert.Equal(t, monsters[i].(*monster).record(), imported[i].(*monster).record(), "record %d", i)
+	}
+	require.Len(t, report.Invalid, 1)
+	assert.Equal(t, len(monsters)+2, report.Invalid[0].Line)
+	assert.Equal(t, "health", report.Invalid[0].Column)
+}
+
 // assertMonsterEqual проверяет равенство всех полей Monster
 func assertMonsterEqual(t *testing.T, expected, actual Monster) {
 	assert.Equal(t, expected.Name(), actual.Name(), "Name mismatch")
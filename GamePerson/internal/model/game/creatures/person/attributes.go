@@ -1,6 +1,7 @@
 package person
 
 import (
+	"GamePerson/internal/bitpack"
 	personbitpack "GamePerson/internal/model/bitpack/person"
 	"GamePerson/internal/model/config"
 	"GamePerson/internal/model/game/creatures/base/entity"
@@ -10,8 +11,9 @@ import (
 // -----------------   Геттеры --------------------------------------
 
 func (p *person) Name() string {
-	length := personbitpack.GetNameSize(&p.packed)
-	return string(p.name[:length])
+	// Повреждённый буфер даёт прочитанную часть имени, ошибку сообщает Validate
+	name, _ := entity.UnpackName(p.name[:], int(personbitpack.GetNameSize(&p.packed)))
+	return name
 }
 
 func (p *person) Gold() uint32 { return p.gold }
@@ -28,21 +30,26 @@ func (p *person) Health() uint32     { return personbitpack.GetHealth(&p.packed)
 func (p *person) Strength() uint32   { return personbitpack.GetStrength(&p.packed) }
 func (p *person) Experience() uint32 { return personbitpack.GetExperience(&p.packed) }
 func (p *person) Level() uint32      { return personbitpack.GetLevel(&p.packed) }
-func (p *person) Type() PersonType   { return PersonType(personbitpack.GetType(&p.packed)) }
+func (p *person) Type() PersonType   { return bitpack.GetEnumField(p.packed[:], personTypes) }
+
+func (p *person) ManaOptional() (uint32, bool) { return personbitpack.GetManaOptional(&p.packed) }
 
 func (p *person) HasHouse() bool  { return personbitpack.GetHouse(&p.packed) }
 func (p *person) HasWeapon() bool { return personbitpack.GetWeapon(&p.packed) }
 func (p *person) HasFamily() bool { return personbitpack.GetFamily(&p.packed) }
 
+// Каждый сеттер пересчитывает код целостности записи (defer p.seal())
+
 // Сеттеры для строковой (с сохранением в битовой карте длины)
 
 func (p *person) SetName(name string) error {
-	validated, err := entity.ValidateAndCopyName(p.name[:], name)
+	defer p.seal()
+	validated, err := entity.PackName(p.name[:], name)
 	if err != nil {
 		return err
 	}
 	// Обновляем длину в битовом поле
-	if err = personbitpack.SetSizeName(&p.packed, uint32(len(validated))); err != nil {
+	if err = personbitpack.SetNameSize(&p.packed, uint32(len(validated))); err != nil {
 		return fmt.Errorf("failed to update name length: %w", err)
 	}
 	return nil
@@ -51,6 +58,7 @@ func (p *person) SetName(name string) error {
 // ------------- Сеттеры для простых полей --------------------
 
 func (p *person) SetX(x int32) error {
+	defer p.seal()
 	if err := entity.ValidateCoordinate("X", x); err != nil {
 		return err
 	}
@@ -59,6 +67,7 @@ func (p *person) SetX(x int32) error {
 }
 
 func (p *person) SetY(y int32) error {
+	defer p.seal()
 	if err := entity.ValidateCoordinate("Y", y); err != nil {
 		return err
 	}
@@ -67,6 +76,7 @@ func (p *person) SetY(y int32) error {
 }
 
 func (p *person) SetZ(z int32) error {
+	defer p.seal()
 	if err := entity.ValidateCoordinate("Z", z); err != nil {
 		return err
 	}
@@ -75,6 +85,7 @@ func (p *person) SetZ(z int32) error {
 }
 
 func (p *person) SetGold(gold uint32) error {
+	defer p.seal()
 	if gold > config.PersonMaxGold {
 		return fmt.Errorf("gold %d exceeds maximum %d", gold, config.PersonMaxGold)
 	}
@@ -86,6 +97,7 @@ func (p *person) SetGold(gold uint32) error {
 //  Проверки на бизнес слое, далее Unchecked версии
 
 func (p *person) SetMana(mana uint32) error {
+	defer p.seal()
 	// Проверка на логическую корректность
 	if mana > config.PersonMaxMana {
 		return fmt.Errorf("mana %d exceeds maximum %d", mana, config.PersonMaxMana)
@@ -95,7 +107,14 @@ func (p *person) SetMana(mana uint32) error {
 	return nil
 }
 
+// ClearMana переводит ману в состояние «не задана» (SetMana задаёт её снова)
+func (p *person) ClearMana() {
+	defer p.seal()
+	personbitpack.ClearMana(&p.packed)
+}
+
 func (p *person) SetHealth(health uint32) error {
+	defer p.seal()
 	if health > config.PersonMaxHealth {
 		return fmt.Errorf("health %d exceeds maximum %d", health, config.PersonMaxHealth)
 	}
@@ -105,6 +124,7 @@ func (p *person) SetHealth(health uint32) error {
 }
 
 func (p *person) SetStrength(strength uint32) error {
+	defer p.seal()
 	if strength > config.PersonMaxStrength {
 		return fmt.Errorf("strength %d exceeds maximum %d", strength, config.PersonMaxStrength)
 	}
@@ -114,6 +134,7 @@ func (p *person) SetStrength(strength uint32) error {
 }
 
 func (p *person) SetRespect(respect uint32) error {
+	defer p.seal()
 	if respect > config.PersonMaxRespect {
 		return fmt.Errorf("respect %d exceeds maximum %d", respect, config.PersonMaxRespect)
 	}
@@ -123,6 +144,7 @@ func (p *person) SetRespect(respect uint32) error {
 }
 
 func (p *person) SetExperience(exp uint32) error {
+	defer p.seal()
 	if exp > config.PersonMaxExperience {
 		return fmt.Errorf("experience %d exceeds maximum %d", exp, config.PersonMaxExperience)
 	}
@@ -132,6 +154,10 @@ func (p *person) SetExperience(exp uint32) error {
 }
 
 func (p *person) SetLevel(level uint32) error {
+	defer p.seal()
+	if level < config.PersonMinLevel {
+		return fmt.Errorf("level %d is below minimum %d", level, config.PersonMinLevel)
+	}
 	if level > config.PersonMaxLevel {
 		return fmt.Errorf("level %d exceeds maximum %d", level, config.PersonMaxLevel)
 	}
@@ -141,14 +167,58 @@ func (p *person) SetLevel(level uint32) error {
 }
 
 func (p *person) SetType(pt PersonType) error {
-	if pt < PersonTypeBuilder || pt > PersonTypeWarrior {
+	defer p.seal()
+	if !personTypes.Contains(pt) {
 		return fmt.Errorf("invalid person type: %d", pt)
 	}
 	// ГАРАНТИЯ: значение валидно
-	personbitpack.SetTypeUnchecked(&p.packed, uint32(pt))
+	bitpack.SetEnumFieldUnchecked(p.packed[:], personTypes, pt)
 	return nil
 }
 
-func (p *person) SetHouse(has bool) error  { return personbitpack.SetHouse(&p.packed, has) }
-func (p *person) SetWeapon(has bool) error { return personbitpack.SetWeapon(&p.packed, has) }
-func (p *person) SetFamily(has bool) error { return personbitpack.SetFamily(&p.packed, has) }
+func (p *person) SetHouse(has bool) error {
+	defer p.seal()
+	return personbitpack.SetHouse(&p.packed, has)
+}
+
+func (p *person) SetWeapon(has bool) error {
+	defer p.seal()
+	return personbitpack.SetWeapon(&p.packed, has)
+}
+
+func (p *person) SetFamily(has bool) error {
+	defer p.seal()
+	return personbitpack.SetFamily(&p.packed, has)
+}
+
+// ------------- Арифметика над битово-упакованными полями --------------------
+//  Лимиты полей схемы совпадают с лимитами config: Saturating прижимает
+//  результат к границам, checked версии возвращают ошибку и не меняют поле
+
+func (p *person) AddHealth(delta uint32) uint32 {
+	defer p.seal()
+	return personbitpack.AddHealthSaturating(&p.packed, delta)
+}
+
+func (p *person) TakeDamage(damage uint32) uint32 {
+	defer p.seal()
+	return personbitpack.SubHealthSaturating(&p.packed, damage)
+}
+
+func (p *person) AddMana(delta uint32) uint32 {
+	defer p.seal()
+	return personbitpack.AddManaSaturating(&p.packed, delta)
+}
+
+func (p *person) DrainMana(amount uint32) error {
+	defer p.seal()
+	if _, err := personbitpack.SubMana(&p.packed, amount); err != nil {
+		return fmt.Errorf("not enough mana to drain %d: %w", amount, err)
+	}
+	return nil
+}
+
+func (p *person) AddExperience(delta uint32) uint32 {
+	defer p.seal()
+	return personbitpack.AddExperienceSaturating(&p.packed, delta)
+}
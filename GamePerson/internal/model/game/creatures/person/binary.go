@@ -0,0 +1,53 @@
+package person
+
+import (
+	"GamePerson/internal/model/game/creatures/base/entity"
+	"GamePerson/internal/model/game/creatures/base/record"
+	"encoding"
+	"fmt"
+)
+
+// ================  Двоичный формат person ======================================
+//
+// MarshalBinary пишет 64-байтовую запись как есть после заголовка
+// record (magic, вид существа, версия раскладки — см. record.Magic).
+// UnmarshalBinary проверяет только заголовок и размер: значения полей
+// и код целостности проверяет IntegrityChecker (см. Serializer.FromBinary).
+
+var (
+	_ encoding.BinaryMarshaler   = (*person)(nil)
+	_ encoding.BinaryUnmarshaler = (*person)(nil)
+)
+
+// MarshalBinary кодирует запись с заголовком
+func (p *person) MarshalBinary() ([]byte, error) {
+	rec := p.record()
+	return recordLayout.AppendBinary(make([]byte, 0, recordLayout.BinarySize()), &rec), nil
+}
+
+// UnmarshalBinary заменяет запись декодированной; при ошибке запись не меняется
+func (p *person) UnmarshalBinary(data []byte) error {
+	rec, err := recordLayout.ParseBinary(data)
+	if err != nil {
+		return fmt.Errorf("failed to unmarshal person: %w", err)
+	}
+	p.loadRecord(rec)
+	return nil
+}
+
+// record — 64 байта записи: имя, упаковка, золото и координаты в little-endian
+func (p *person) record() [record.Size]byte {
+	var rec [record.Size]byte
+	n := copy(rec[:], p.name[:])
+	n += copy(rec[n:], p.packed[:])
+	tail := entity.RecordTail(p.gold, p.x, p.y, p.z)
+	copy(rec[n:], tail[:])
+	return rec
+}
+
+// loadRecord — обратное к record, без проверок
+func (p *person) loadRecord(rec [record.Size]byte) {
+	n := copy(p.name[:], rec[:])
+	n += copy(p.packed[:], rec[n:])
+	p.gold, p.x, p.y, p.z = entity.ParseRecordTail(rec[n:])
+}
@@ -0,0 +1,79 @@
+package person
+
+import (
+	personbitpack "GamePerson/internal/model/bitpack/person"
+	"GamePerson/internal/model/config"
+	"GamePerson/internal/model/game/creatures/base/record"
+	"fmt"
+)
+
+// ================  Дельты записей person =====================================
+//
+// Для репликации передаются только изменённые поля: Diff строит
+// record.Delta между двумя персонажами, Apply проверяет её и патчит запись.
+// Поля упаковки берутся из дескрипторов схемы personbitpack, поэтому смена
+// схемы меняет версию раскладки и старые дельты отвергаются.
+
+const (
+	nameBits   = 8 * config.MaxNameLength
+	packedBits = 8 * len(personbitpack.Packed48{})
+	goldBit    = nameBits + packedBits
+)
+
+// recordLayout — раскладка 64-байтовой записи person (порядок полей структуры)
+var recordLayout = record.MustNewLayout("person", append(
+	record.SchemaFields(nameBits, personbitpack.Schema()),
+	record.Field{Name: "name", Start: 0, Width: nameBits, Spec: "6-bit chars"},
+	record.Field{Name: "gold", Start: goldBit, Width: 32, Spec: "uint32 LE"},
+	record.Field{Name: "x", Start: goldBit + 32, Width: 32, Spec: "int32 LE"},
+	record.Field{Name: "y", Start: goldBit + 64, Width: 32, Spec: "int32 LE"},
+	record.Field{Name: "z", Start: goldBit + 96, Width: 32, Spec: "int32 LE"},
+)...)
+
+// RecordLayout — раскладка записи person для дельт (вид, версия, поля)
+func RecordLayout() *record.Layout {
+	return recordLayout
+}
+
+// Diff строит дельту, переводящую персонажа from в to
+func Diff(from, to Person) (record.Delta, error) {
+	a, err := asPerson(from)
+	if err != nil {
+		return record.Delta{}, err
+	}
+	b, err := asPerson(to)
+	if err != nil {
+		return record.Delta{}, err
+	}
+	before, after := a.record(), b.record()
+	return recordLayout.Diff(&before, &after), nil
+}
+
+// Apply применяет дельту к персонажу. Пропатченная запись проходит Validate
+// (включая код целостности); при любой ошибке p не меняется.
+func Apply(p Person, d record.Delta) error {
+	target, err := asPerson(p)
+	if err != nil {
+		return err
+	}
+	patched, err := recordLayout.Patch(target.record(), d)
+	if err != nil {
+		return fmt.Errorf("failed to apply delta: %w", err)
+	}
+
+	var next person
+	next.loadRecord(patched)
+	if err := next.Validate(); err != nil {
+		return fmt.Errorf("delta produces invalid person: %w", err)
+	}
+	*target = next
+	return nil
+}
+
+func asPerson(p Person) (*person, error) {
+	impl, ok := p.(*person)
+	if !ok || impl == nil {
+		return nil, fmt.Errorf("unsupported Person implementation %T", p)
+	}
+	return impl, nil
+}
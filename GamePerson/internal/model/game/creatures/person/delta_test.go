@@ -0,0 +1,184 @@
+package person
+
+import (
+	"GamePerson/internal/model/config"
+	"GamePerson/internal/model/game/creatures/base/record"
+	"math/rand"
+	"testing"
+	"unsafe"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPersonRecordMatchesMemory(t *testing.T) {
+	created, err := NewPerson(WithName("Bob"), WithGold(123456), WithCoordinates(-1, 2, -3), WithMana(7))
+	require.NoError(t, err)
+	p := created.(*person)
+
+	rec := p.record()
+	raw := unsafe.Slice((*byte)(unsafe.Pointer(p)), unsafe.Sizeof(*p))
+	assert.Equal(t, raw, rec[:], "record must mirror struct layout")
+
+	var restored person
+	restored.loadRecord(rec)
+	assert.Equal(t, *p, restored)
+}
+
+func TestPersonDeltaRoundTrip(t *testing.T) {
+	rng := rand.New(rand.NewSource(20))
+	for i := 0; i < 300; i++ {
+		from := randomPerson(t, rng)
+		to := clonePerson(from)
+		mutatePerson(t, rng, to)
+
+		d, err := Diff(from, to)
+		require.NoError(t, err)
+
+		data, err := d.MarshalBinary()
+		require.NoError(t, err)
+		var got record.Delta
+		require.NoError(t, got.UnmarshalBinary(data))
+		require.Equal(t, d, got)
+
+		patched := clonePerson(from)
+		require.NoError(t, Apply(patched, got), "iteration %d: %v -> %v", i, from, to)
+		require.Equal(t, to.record(), patched.record(), "iteration %d", i)
+
+		same, err := Diff(to, patched)
+		require.NoError(t, err)
+		assert.True(t, same.Empty())
+	}
+}
+
+func TestPersonDeltaCarriesOnlyChangedFields(t *testing.T) {
+	from, err := NewPerson(WithName("Bob"), WithHealth(100))
+	require.NoError(t, err)
+	to := clonePerson(from.(*person))
+	require.NoError(t, to.SetHealth(90))
+
+	d, err := Diff(from, to)
+	require.NoError(t, err)
+	fields, err := RecordLayout().ChangedFields(d)
+	require.NoError(t, err)
+	// Бит чётности записи хранится в упаковке и меняется вместе с любым нечётным изменением
+	assert.Subset(t, []string{"health", "parity"}, fields)
+	assert.Contains(t, fields, "health")
+	assert.LessOrEqual(t, len(d.Values), 6)
+}
+
+func TestPersonDeltaRejects(t *testing.T) {
+	rng := rand.New(rand.NewSource(21))
+	from := randomPerson(t, rng)
+	to := clonePerson(from)
+	require.NoError(t, to.SetName("Somebody Else"))
+	require.NoError(t, to.SetGold(from.Gold()/2))
+
+	d, err := Diff(from, to)
+	require.NoError(t, err)
+
+	tests := []struct {
+		name   string
+		target *person
+		delta  func(record.Delta) record.Delta
+		want   error
+	}{
+		{"other kind", from, func(d record.Delta) record.Delta { d.Kind = "monster"; return d }, record.ErrDeltaKind},
+		{"other version", from, func(d record.Delta) record.Delta { d.Version ^= 1; return d }, record.ErrDeltaVersion},
+		{"other base", to, func(d record.Delta) record.Delta { return d }, record.ErrDeltaBase},
+		{"truncated values", from, func(d record.Delta) record.Delta { d.Values = d.Values[:len(d.Values)/2]; return d }, record.ErrDeltaCorrupt},
+		{"extra values", from, func(d record.Delta) record.Delta { d.Values = append(d.Values, 0, 0); return d }, record.ErrDeltaCorrupt},
+		{"mask beyond fields", from, func(d record.Delta) record.Delta { d.Changed |= 1 << 63; return d }, record.ErrDeltaCorrupt},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			target := clonePerson(tt.target)
+			before := target.record()
+			err := Apply(target, tt.delta(d))
+			assert.ErrorIs(t, err, tt.want)
+			assert.Equal(t, before, target.record(), "record must not change on error")
+		})
+	}
+}
+
+func TestPersonDeltaValidatesResult(t *testing.T) {
+	from, err := NewPerson(WithName("Bob"))
+	require.NoError(t, err)
+
+	// Запись вне бизнес-лимитов, но с верным кодом целостности
+	bad := clonePerson(from.(*person))
+	bad.x = config.MaxCoord + 1
+	bad.seal()
+
+	d, err := Diff(from, bad)
+	require.NoError(t, err)
+
+	target := clonePerson(from.(*person))
+	err = Apply(target, d)
+	assert.ErrorContains(t, err, "delta produces invalid person")
+	assert.Equal(t, from.(*person).record(), target.record())
+}
+
+// ============ Вспомогательные функции ============
+
+const deltaNameChars = "ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz0123456789_-"
+
+func randomName(rng *rand.Rand) string {
+	b := make([]byte, 1+rng.Intn(config.MaxNameChars))
+	for i := range b {
+		b[i] = deltaNameChars[rng.Intn(len(deltaNameChars))]
+	}
+	return string(b)
+}
+
+func randomCoord(rng *rand.Rand) int32 {
+	return config.MinCoord + int32(rng.Int63n(int64(config.MaxCoord)-int64(config.MinCoord)+1))
+}
+
+func randomPerson(t *testing.T, rng *rand.Rand) *person {
+	t.Helper()
+	created, err := NewPerson(
+		WithName(randomName(rng)),
+		WithType(PersonTypes()[rng.Intn(len(PersonTypes()))]),
+		WithHealth(uint32(rng.Intn(int(config.PersonMaxHealth)+1))),
+		WithMana(uint32(rng.Intn(int(config.PersonMaxMana)+1))),
+		WithGold(uint32(rng.Int63n(int64(config.PersonMaxGold)+1))),
+		WithLevel(config.PersonMinLevel+uint32(rng.Intn(int(config.PersonMaxLevel-config.PersonMinLevel)+1))),
+		WithStrength(uint32(rng.Intn(int(config.PersonMaxStrength)+1))),
+		WithRespect(uint32(rng.Intn(int(config.PersonMaxRespect)+1))),
+		WithExperience(uint32(rng.Intn(int(config.PersonMaxExperience)+1))),
+		WithCoordinates(randomCoord(rng), randomCoord(rng), randomCoord(rng)),
+		WithHouse(rng.Intn(2) == 1),
+		WithWeapon(rng.Intn(2) == 1),
+		WithFamily(rng.Intn(2) == 1),
+	)
+	require.NoError(t, err)
+	return created.(*person)
+}
+
+// mutatePerson меняет случайное подмножество полей (возможно, пустое)
+func mutatePerson(t *testing.T, rng *rand.Rand, p *person) {
+	t.Helper()
+	steps := []func() error{
+		func() error { return p.SetName(randomName(rng)) },
+		func() error { return p.SetHealth(uint32(rng.Intn(int(config.PersonMaxHealth) + 1))) },
+		func() error { p.ClearMana(); return nil },
+		func() error { return p.SetMana(uint32(rng.Intn(int(config.PersonMaxMana) + 1))) },
+		func() error { return p.SetGold(uint32(rng.Int63n(int64(config.PersonMaxGold) + 1))) },
+		func() error { return p.SetLevel(config.PersonMinLevel) },
+		func() error { return p.SetType(PersonTypeWarrior) },
+		func() error { return p.SetFamily(rng.Intn(2) == 1) },
+		func() error { return p.SetX(randomCoord(rng)) },
+		func() error { return p.SetZ(randomCoord(rng)) },
+	}
+	for _, step := range steps {
+		if rng.Intn(3) == 0 {
+			require.NoError(t, step())
+		}
+	}
+}
+
+func clonePerson(p *person) *person {
+	c := *p
+	return &c
+}
@@ -1,22 +1,29 @@
 package person
 
+import (
+	personbitpack "GamePerson/internal/model/bitpack/person"
+	"GamePerson/internal/model/config"
+	"GamePerson/internal/model/game/creatures/base/entity"
+	"fmt"
+)
+
 // PersonDTO - Data Transfer Object для сериализации/десериализации Person
 type PersonDTO struct {
-	Name       string     `json:"name" xml:"Name" yaml:"name"`
-	Type       PersonType `json:"type" xml:"Type" yaml:"type"`
-	Health     uint32     `json:"health" xml:"Health" yaml:"health"`
-	Mana       uint32     `json:"mana" xml:"Mana" yaml:"mana"`
-	Level      uint32     `json:"level" xml:"Level" yaml:"level"`
-	Gold       uint32     `json:"gold" xml:"Gold" yaml:"gold"`
-	Respect    uint32     `json:"respect" xml:"Respect" yaml:"respect"`
-	Strength   uint32     `json:"strength" xml:"Strength" yaml:"strength"`
-	Experience uint32     `json:"experience" xml:"Experience" yaml:"experience"`
-	HasHouse   bool       `json:"has_house" xml:"HasHouse" yaml:"has_house"`
-	HasWeapon  bool       `json:"has_weapon" xml:"HasWeapon" yaml:"has_weapon"`
-	HasFamily  bool       `json:"has_family" xml:"HasFamily" yaml:"has_family"`
-	X          int32      `json:"x" xml:"X" yaml:"x"`
-	Y          int32      `json:"y" xml:"Y" yaml:"y"`
-	Z          int32      `json:"z" xml:"Z" yaml:"z"`
+	Name       string     `json:"name" xml:"Name" yaml:"name" csv:"name"`
+	Type       PersonType `json:"type" xml:"Type" yaml:"type" csv:"type"`
+	Health     uint32     `json:"health" xml:"Health" yaml:"health" csv:"health"`
+	Mana       *uint32    `json:"mana,omitempty" xml:"Mana,omitempty" yaml:"mana,omitempty" csv:"mana"` // nil — мана не задана
+	Level      uint32     `json:"level" xml:"Level" yaml:"level" csv:"level"`
+	Gold       uint32     `json:"gold" xml:"Gold" yaml:"gold" csv:"gold"`
+	Respect    uint32     `json:"respect" xml:"Respect" yaml:"respect" csv:"respect"`
+	Strength   uint32     `json:"strength" xml:"Strength" yaml:"strength" csv:"strength"`
+	Experience uint32     `json:"experience" xml:"Experience" yaml:"experience" csv:"experience"`
+	HasHouse   bool       `json:"has_house" xml:"HasHouse" yaml:"has_house" csv:"has_house"`
+	HasWeapon  bool       `json:"has_weapon" xml:"HasWeapon" yaml:"has_weapon" csv:"has_weapon"`
+	HasFamily  bool       `json:"has_family" xml:"HasFamily" yaml:"has_family" csv:"has_family"`
+	X          int32      `json:"x" xml:"X" yaml:"x" csv:"x"`
+	Y          int32      `json:"y" xml:"Y" yaml:"y" csv:"y"`
+	Z          int32      `json:"z" xml:"Z" yaml:"z" csv:"z"`
 }
 
 // ToDTO преобразует Person в PersonDTO
@@ -25,7 +32,7 @@ func ToDTO(p Person) PersonDTO {
 		Name:       p.Name(),
 		Type:       p.Type(),
 		Health:     p.Health(),
-		Mana:       p.Mana(),
+		Mana:       manaOf(p),
 		Level:      p.Level(),
 		Gold:       p.Gold(),
 		Respect:    p.Respect(),
@@ -40,21 +47,62 @@ func ToDTO(p Person) PersonDTO {
 	}
 }
 
-// FromDTO создает Person из PersonDTO
+// manaOf возвращает ману для DTO: nil, если она не задана
+func manaOf(p Person) *uint32 {
+	if mana, ok := p.ManaOptional(); ok {
+		return &mana
+	}
+	return nil
+}
+
+// FromDTO создает Person из PersonDTO.
+// Отсутствующая в DTO мана (nil) остаётся незаданной, а не превращается в 0.
+// Уровень 0 (нет ключа level в старых сохранениях, нет колонки в CSV)
+// читается как config.PersonDefaultLevel: ниже минимума уровень не бывает.
 func FromDTO(dto PersonDTO) (Person, error) {
 	return NewPerson(
 		WithName(dto.Name),
-		WithType(dto.Type),
-		WithHealth(dto.Health),
-		WithMana(dto.Mana),
-		WithLevel(dto.Level),
 		WithGold(dto.Gold),
-		WithRespect(dto.Respect),
-		WithStrength(dto.Strength),
-		WithExperience(dto.Experience),
-		WithHouse(dto.HasHouse),
-		WithWeapon(dto.HasWeapon),
-		WithFamily(dto.HasFamily),
 		WithCoordinates(dto.X, dto.Y, dto.Z),
+		withPackedFields(dto),
 	)
 }
+
+// withPackedFields применяет битово-упакованные поля DTO через View:
+// буфер распаковывается один раз, проверки выполняются на регистре,
+// а Commit записывает результат одной упаковкой вместо десятка
+// или возвращает первую ошибку с именем поля.
+// Лимиты полей схемы совпадают с лимитами config (см. schema.yaml).
+func withPackedFields(dto PersonDTO) Option {
+	return func(p *person) error {
+		if !personTypes.Contains(dto.Type) {
+			return entity.NewFieldError("type", fmt.Errorf("invalid person type: %d", dto.Type))
+		}
+
+		view := personbitpack.NewView(&p.packed)
+		view.SetTypeUnchecked(uint32(dto.Type))
+		view.SetHouseUnchecked(dto.HasHouse)
+		view.SetWeaponUnchecked(dto.HasWeapon)
+		view.SetFamilyUnchecked(dto.HasFamily)
+		view.ClearMana()
+		if dto.Mana != nil {
+			_ = view.SetMana(*dto.Mana)
+		}
+		// Ошибки checked Set содержат имя поля (person.health ...),
+		// первая из них возвращается из Commit
+		_ = view.SetHealth(dto.Health)
+		_ = view.SetLevel(levelOf(dto))
+		_ = view.SetRespect(dto.Respect)
+		_ = view.SetStrength(dto.Strength)
+		_ = view.SetExperience(dto.Experience)
+		return view.Commit()
+	}
+}
+
+// levelOf — уровень из DTO; 0 означает «не задан» и заменяется уровнем по умолчанию
+func levelOf(dto PersonDTO) uint32 {
+	if dto.Level == 0 {
+		return config.PersonDefaultLevel
+	}
+	return dto.Level
+}
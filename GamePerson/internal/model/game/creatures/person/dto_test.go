@@ -0,0 +1,145 @@
+package person
+
+import (
+	personbitpack "GamePerson/internal/model/bitpack/person"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// ============ FromDTO/ToDTO: поштучные сеттеры против View ============
+
+// benchDTOs — реальные DTO персонажей: с маной и без неё, разные типы и флаги
+func benchDTOs() []PersonDTO {
+	mana := uint32(250)
+	return []PersonDTO{
+		{Name: "Aragorn", Type: PersonTypeWarrior, Health: 900, Mana: &mana, Level: 7, Gold: 15000,
+			Respect: 9, Strength: 8, Experience: 6, HasWeapon: true, X: 120, Y: -40, Z: 3},
+		{Name: "Smith_of-Bree", Type: PersonTypeBlacksmith, Health: 450, Level: 3, Gold: 700,
+			Respect: 4, Strength: 10, Experience: 2, HasHouse: true, HasFamily: true, X: -5, Y: 17, Z: 0},
+	}
+}
+
+// fromDTOSetters — FromDTO через поштучные сеттеры: каждое поле
+// распаковывает и заново упаковывает буфер
+func fromDTOSetters(dto PersonDTO) (Person, error) {
+	mana := func(p *person) error {
+		if dto.Mana == nil {
+			p.ClearMana()
+			return nil
+		}
+		return p.SetMana(*dto.Mana)
+	}
+	return NewPerson(
+		WithName(dto.Name),
+		WithGold(dto.Gold),
+		WithCoordinates(dto.X, dto.Y, dto.Z),
+		WithType(dto.Type),
+		WithHouse(dto.HasHouse),
+		WithWeapon(dto.HasWeapon),
+		WithFamily(dto.HasFamily),
+		mana,
+		WithHealth(dto.Health),
+		WithLevel(levelOf(dto)),
+		WithRespect(dto.Respect),
+		WithStrength(dto.Strength),
+		WithExperience(dto.Experience),
+	)
+}
+
+// toDTOView — ToDTO с одной распаковкой буфера через View
+func toDTOView(p *person) PersonDTO {
+	view := personbitpack.NewView(&p.packed)
+	dto := PersonDTO{
+		Name:       p.Name(),
+		Type:       PersonType(view.GetType()),
+		Health:     view.GetHealth(),
+		Level:      view.GetLevel(),
+		Gold:       p.Gold(),
+		Respect:    view.GetRespect(),
+		Strength:   view.GetStrength(),
+		Experience: view.GetExperience(),
+		HasHouse:   view.GetHouse(),
+		HasWeapon:  view.GetWeapon(),
+		HasFamily:  view.GetFamily(),
+		X:          p.x,
+		Y:          p.y,
+		Z:          p.z,
+	}
+	if mana, ok := view.GetManaOptional(); ok {
+		dto.Mana = &mana
+	}
+	return dto
+}
+
+// Оба пути бенчмарков дают одну и ту же запись и один и тот же DTO
+func TestDTOSetterAndViewPathsAgree(t *testing.T) {
+	for _, dto := range benchDTOs() {
+		viaView, err := FromDTO(dto)
+		require.NoError(t, err)
+		viaSetters, err := fromDTOSetters(dto)
+		require.NoError(t, err)
+		assert.Equal(t, viaView.(*person).record(), viaSetters.(*person).record(), dto.Name)
+
+		assert.Equal(t, dto, ToDTO(viaView), dto.Name)
+		assert.Equal(t, dto, toDTOView(viaView.(*person)), dto.Name)
+	}
+}
+
+// benchPersonSink не даёт компилятору выбросить результат
+var (
+	benchPersonSink Person
+	benchDTOSink    PersonDTO
+)
+
+// BenchmarkFromDTO — создание персонажа из DTO (десериализация)
+func BenchmarkFromDTO(b *testing.B) {
+	dtos := benchDTOs()
+	for _, bc := range []struct {
+		name string
+		from func(PersonDTO) (Person, error)
+	}{
+		{"Setters", fromDTOSetters},
+		{"View", FromDTO},
+	} {
+		b.Run(bc.name, func(b *testing.B) {
+			i := 0
+			for b.Loop() {
+				p, err := bc.from(dtos[i%len(dtos)])
+				if err != nil {
+					b.Fatal(err)
+				}
+				benchPersonSink = p
+				i++
+			}
+		})
+	}
+}
+
+// BenchmarkToDTO — чтение всех полей персонажа в DTO (сериализация)
+func BenchmarkToDTO(b *testing.B) {
+	var persons []*person
+	for _, dto := range benchDTOs() {
+		p, err := FromDTO(dto)
+		if err != nil {
+			b.Fatal(err)
+		}
+		persons = append(persons, p.(*person))
+	}
+	for _, bc := range []struct {
+		name string
+		to   func(*person) PersonDTO
+	}{
+		{"Getters", func(p *person) PersonDTO { return ToDTO(p) }},
+		{"View", toDTOView},
+	} {
+		b.Run(bc.name, func(b *testing.B) {
+			i := 0
+			for b.Loop() {
+				benchDTOSink = bc.to(persons[i%len(persons)])
+				i++
+			}
+		})
+	}
+}
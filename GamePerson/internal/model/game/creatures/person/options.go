@@ -1,11 +1,14 @@
 package person
 
-import "fmt"
+import (
+	"GamePerson/internal/model/game/creatures/base/entity"
+	"fmt"
+)
 
 func WithName(name string) Option {
 	return func(p *person) error {
 		if err := p.SetName(name); err != nil {
-			return fmt.Errorf("WithName: %w", err)
+			return fmt.Errorf("WithName: %w", entity.NewFieldError("name", err))
 		}
 		return nil
 	}
@@ -41,7 +44,7 @@ func WithMana(mana uint32) Option {
 func WithGold(gold uint32) Option {
 	return func(p *person) error {
 		if err := p.SetGold(gold); err != nil {
-			return fmt.Errorf("WithGold: %w", err)
+			return fmt.Errorf("WithGold: %w", entity.NewFieldError("gold", err))
 		}
 		return nil
 	}
@@ -86,13 +89,13 @@ func WithType(pt PersonType) Option {
 func WithCoordinates(x, y, z int32) Option {
 	return func(p *person) error {
 		if err := p.SetX(x); err != nil {
-			return fmt.Errorf("WithCoordinates.X: %w", err)
+			return fmt.Errorf("WithCoordinates.X: %w", entity.NewFieldError("x", err))
 		}
 		if err := p.SetY(y); err != nil {
-			return fmt.Errorf("WithCoordinates.Y: %w", err)
+			return fmt.Errorf("WithCoordinates.Y: %w", entity.NewFieldError("y", err))
 		}
 		if err := p.SetZ(z); err != nil {
-			return fmt.Errorf("WithCoordinates.Z: %w", err)
+			return fmt.Errorf("WithCoordinates.Z: %w", entity.NewFieldError("z", err))
 		}
 		return nil
 	}
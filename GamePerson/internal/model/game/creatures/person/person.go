@@ -1,17 +1,20 @@
 package person
 
 import (
+	"GamePerson/internal/bitpack"
 	personbitpack "GamePerson/internal/model/bitpack/person"
 	"GamePerson/internal/model/config"
 	"GamePerson/internal/model/game/creatures/base/entity"
+	"encoding"
 	"errors"
+	"strconv"
 
 	"fmt"
 )
 
 // ================  GamePerson ==============================================
 
-type PersonType uint
+type PersonType uint8
 
 // GamePersonType — тип игрока
 const (
@@ -20,17 +23,54 @@ const (
 	PersonTypeWarrior                      // 2: воин
 )
 
+// personTypes — допустимые типы игрока и их имена поверх поля схемы.
+// Проверка при записи и строковое представление берутся из EnumBitField.
+var personTypes = bitpack.MustNewEnumBitField(personbitpack.TypeField(),
+	[]PersonType{PersonTypeBuilder, PersonTypeBlacksmith, PersonTypeWarrior},
+	[]string{"Builder", "Blacksmith", "Warrior"})
+
+// PersonTypes возвращает все допустимые типы игрока в порядке объявления
+func PersonTypes() []PersonType {
+	return personTypes.Values()
+}
+
+// ParsePersonType возвращает тип игрока по имени ("Builder", "Warrior" ...)
+func ParsePersonType(name string) (PersonType, error) {
+	pt, err := personTypes.Parse(name)
+	if err != nil {
+		return 0, fmt.Errorf("invalid person type: %w", err)
+	}
+	return pt, nil
+}
+
 func (t PersonType) String() string {
-	switch t {
-	case PersonTypeBuilder:
-		return "Builder"
-	case PersonTypeBlacksmith:
-		return "Blacksmith"
-	case PersonTypeWarrior:
-		return "Warrior"
-	default:
-		return fmt.Sprintf("PersonType(%d)", t)
+	if name, ok := personTypes.Name(t); ok {
+		return name
 	}
+	return fmt.Sprintf("PersonType(%d)", t)
+}
+
+// MarshalCSV пишет тип в ячейку CSV именем, неизвестный тип — числом
+func (t PersonType) MarshalCSV() (string, error) {
+	if name, ok := personTypes.Name(t); ok {
+		return name, nil
+	}
+	return strconv.FormatUint(uint64(t), 10), nil
+}
+
+// UnmarshalCSV принимает тип числом ("2") или именем ("Warrior").
+// Допустимость числа проверяет FromDTO, как и для JSON.
+func (t *PersonType) UnmarshalCSV(cell string) error {
+	if n, err := strconv.ParseUint(cell, 10, 8); err == nil {
+		*t = PersonType(n)
+		return nil
+	}
+	pt, err := ParsePersonType(cell)
+	if err != nil {
+		return err
+	}
+	*t = pt
+	return nil
 }
 
 //==============================================================
@@ -42,14 +82,15 @@ func (t PersonType) String() string {
 //  Сила (0-10)
 //  Опыт (0-10)
 //  Уровень (0-10)
-//  Мана (0-1000)
+//  Мана (0-1000, может быть не задана)
 //  Тип игрока (4 варианта максимум)
 //   Есть дом (булева)
 //  Есть оружие (булева)
 //  Есть семья (булева)
+//  Бит чётности записи
 
 type person struct {
-	name   [config.MaxNameLength]byte // 42 байта: символы имени латиницей (без указателей!)
+	name   [config.MaxNameLength]byte // 42 байта: имя по 6 бит на символ, до 56 символов (без указателей!)
 	packed personbitpack.Packed48     // 6 байт: битовая упаковка мелких полей (см. ниже)
 	gold   uint32                     // 4 байта: золото [0…2_000_000_000]
 	x      int32                      // 4 байта: координата X [-2_000_000_000…2_000_000_000]
@@ -72,6 +113,8 @@ type Person interface {
 	entity.Reputable
 	entity.PropertyOwner
 	entity.FamilyMember
+	encoding.BinaryMarshaler   // 64-байтовая запись с заголовком (см. binary.go)
+	encoding.BinaryUnmarshaler // без проверки значений — её выполняет Serializer.FromBinary
 
 	Type() PersonType
 	SetType(PersonType) error
@@ -95,6 +138,8 @@ func NewPerson(options ...Option) (Person, error) {
 			return nil, fmt.Errorf("failed to apply option: %w", err)
 		}
 	}
+	// Опции вроде withPackedFields пишут буфер напрямую, минуя сеттеры
+	p.seal()
 	return p, nil
 }
 
@@ -115,6 +160,35 @@ func mustSetDefaults(p *person) {
 	}
 }
 
+// ------------- Код целостности записи -----------------------------------
+//  Чётность всей записи (см. entity.RecordParity) хранится в последнем
+//  бите упаковки (parity в schema.yaml), остальные поля её не содержат
+
+// checksum — чётность записи без самого бита чётности
+func (p *person) checksum() uint8 {
+	packed := p.packed
+	personbitpack.SetParityUnchecked(&packed, false)
+	tail := entity.RecordTail(p.gold, p.x, p.y, p.z)
+	return entity.RecordParity(p.name[:], packed[:], tail[:])
+}
+
+// seal пересчитывает бит чётности после изменения записи
+func (p *person) seal() {
+	personbitpack.SetParityUnchecked(&p.packed, p.checksum() == 1)
+}
+
+// VerifyChecksum сообщает entity.ErrChecksumMismatch, если бит чётности не совпадает с записью
+func (p *person) VerifyChecksum() error {
+	var stored uint16
+	if personbitpack.GetParity(&p.packed) {
+		stored = 1
+	}
+	if computed := uint16(p.checksum()); stored != computed {
+		return entity.NewChecksumError(stored, computed)
+	}
+	return nil
+}
+
 func (p *person) rawNameBytes() [config.MaxNameLength]byte {
 	return p.name
 }
@@ -148,7 +222,7 @@ func (p *person) internalData() string {
 		"Raw{name: %q, packed: %v, gold: %d, coords: (%d,%d,%d)}",
 		p.rawNameBytes(),
 		p.packed,
-		p.gold,
+		p.Gold(),
 		p.x, p.y, p.z,
 	)
 }
@@ -157,18 +231,13 @@ func (p *person) internalData() string {
 func (p *person) Validate() error {
 	// Собираем ВСЕ ошибки, а не останавливаемся на первой
 	var errs []error
-	// Инвариант 1: длина имени соответствует буферу
+	// Инвариант 1: длина имени в символах не превышает вместимость буфера
 	nameLen := personbitpack.GetNameSize(&p.packed)
-	if nameLen > config.MaxNameLength {
-		errs = append(errs, fmt.Errorf("name length %d exceeds maximum %d", nameLen, config.MaxNameLength))
-	}
-
-	// Инвариант 2: байты после имени — нулевые (защита от мусора в буфере)
-	for i := nameLen; i < config.MaxNameLength; i++ {
-		if p.name[i] != 0 {
-			errs = append(errs, fmt.Errorf("name buffer contains garbage at position %d", i))
-			break // достаточно одной ошибки для мусора
-		}
+	if nameLen > config.MaxNameChars {
+		errs = append(errs, fmt.Errorf("name length %d exceeds maximum %d", nameLen, config.MaxNameChars))
+	} else if _, err := entity.UnpackName(p.name[:], int(nameLen)); err != nil {
+		// Инвариант 2: коды символов допустимы, биты после имени — нулевые (защита от мусора в буфере)
+		errs = append(errs, fmt.Errorf("invalid name buffer: %w", err))
 	}
 
 	// Инвариант 3: координаты в допустимом диапазоне
@@ -194,8 +263,7 @@ func (p *person) Validate() error {
 		errs = append(errs, fmt.Errorf("gold %d exceeds maximum %d", gold, config.PersonMaxGold))
 	}
 
-	pt := p.Type()
-	if pt < PersonTypeBuilder || pt > PersonTypeWarrior {
+	if pt := p.Type(); !personTypes.Contains(pt) {
 		errs = append(errs, fmt.Errorf("invalid person type: %d", pt))
 	}
 
@@ -213,6 +281,10 @@ func (p *person) Validate() error {
 	if level := p.Level(); level > config.PersonMaxLevel {
 		errs = append(errs, fmt.Errorf("level %d exceeds maximum %d", level, config.PersonMaxLevel))
 	}
+	// Инвариант 5: код целостности совпадает с содержимым записи
+	if err := p.VerifyChecksum(); err != nil {
+		errs = append(errs, err)
+	}
 	if len(errs) > 0 {
 		return errors.Join(errs...)
 	}
@@ -1,6 +1,9 @@
 package person
 
 import (
+	"GamePerson/internal/bitpack"
+	"GamePerson/internal/model/config"
+	"GamePerson/internal/model/game/creatures/base/entity"
 	"testing"
 	"unsafe"
 
@@ -71,3 +74,224 @@ func TestGamePerson(t *testing.T) {
 	assert.False(t, person.HasWeapon())
 	assert.Equal(t, personType, person.Type())
 }
+
+func TestPersonTypeNames(t *testing.T) {
+	for _, pt := range PersonTypes() {
+		parsed, err := ParsePersonType(pt.String())
+		assert.NoError(t, err)
+		assert.Equal(t, pt, parsed)
+	}
+
+	assert.Equal(t, "PersonType(3)", PersonType(3).String())
+	_, err := ParsePersonType("Wizard")
+	assert.Error(t, err)
+
+	p, err := NewPerson(WithType(PersonTypeBlacksmith))
+	assert.NoError(t, err)
+	assert.Error(t, p.SetType(PersonType(3)), "type outside of enum must be rejected")
+	assert.Equal(t, PersonTypeBlacksmith, p.Type())
+}
+
+func TestPersonLevelRange(t *testing.T) {
+	p, err := NewPerson()
+	assert.NoError(t, err)
+	assert.Equal(t, uint32(1), p.Level())
+
+	assert.Error(t, p.SetLevel(0), "level below minimum must be rejected")
+	assert.Error(t, p.SetLevel(11), "level above maximum must be rejected")
+	assert.NoError(t, p.SetLevel(10))
+	assert.Equal(t, uint32(10), p.Level())
+}
+
+func TestFromDTOValidatesPackedFields(t *testing.T) {
+	mana := uint32(20)
+	valid := PersonDTO{Name: "Bob", Type: PersonTypeWarrior, Health: 500, Mana: &mana, Level: 3}
+	p, err := FromDTO(valid)
+	assert.NoError(t, err)
+	assert.Equal(t, valid, ToDTO(p))
+
+	for name, mutate := range map[string]func(*PersonDTO){
+		"health": func(d *PersonDTO) { d.Health = config.PersonMaxHealth + 1 },
+		"level":  func(d *PersonDTO) { d.Level = config.PersonMaxLevel + 1 },
+		"type":   func(d *PersonDTO) { d.Type = PersonType(3) },
+	} {
+		dto := valid
+		mutate(&dto)
+		_, err := FromDTO(dto)
+		assert.Error(t, err, name)
+	}
+}
+
+func TestPersonOptionalMana(t *testing.T) {
+	p, err := NewPerson(WithName("Mute"))
+	assert.NoError(t, err)
+	mana, ok := p.ManaOptional()
+	assert.True(t, ok, "default mana is set")
+	assert.Equal(t, config.PersonDefaultMana, mana)
+
+	p.ClearMana()
+	_, ok = p.ManaOptional()
+	assert.False(t, ok)
+	assert.Equal(t, uint32(0), p.Mana())
+	assert.NoError(t, p.(*person).Validate())
+
+	// Незаданная мана переживает DTO и не превращается в 0
+	dto := ToDTO(p)
+	assert.Nil(t, dto.Mana)
+	restored, err := FromDTO(dto)
+	assert.NoError(t, err)
+	_, ok = restored.ManaOptional()
+	assert.False(t, ok)
+
+	// Заданный ноль отличается от незаданной маны
+	assert.NoError(t, p.SetMana(0))
+	mana, ok = p.ManaOptional()
+	assert.True(t, ok)
+	assert.Equal(t, uint32(0), mana)
+}
+
+func TestPersonArithmetic(t *testing.T) {
+	p, err := NewPerson(WithHealth(900), WithMana(30), WithExperience(9))
+	assert.NoError(t, err)
+
+	assert.Equal(t, config.PersonMaxHealth, p.AddHealth(500), "heal is capped at max")
+	assert.Equal(t, uint32(0), p.TakeDamage(5000), "damage floors at zero")
+	assert.Equal(t, uint32(100), p.AddHealth(100))
+
+	assert.NoError(t, p.DrainMana(30))
+	assert.Equal(t, uint32(0), p.Mana())
+	err = p.DrainMana(1)
+	assert.ErrorIs(t, err, bitpack.ErrValueUnderflow)
+	assert.Equal(t, uint32(0), p.Mana(), "failed drain must not change mana")
+	assert.Equal(t, config.PersonMaxMana, p.AddMana(config.PersonMaxMana+1))
+
+	assert.Equal(t, config.PersonMaxExperience, p.AddExperience(5))
+}
+
+func TestFromDTOErrorNamesField(t *testing.T) {
+	dto := PersonDTO{Name: "Bob", Type: PersonTypeWarrior, Health: config.PersonMaxHealth + 1, Level: 3}
+	_, err := FromDTO(dto)
+
+	var bpErr *bitpack.Error
+	if assert.ErrorAs(t, err, &bpErr) {
+		assert.Equal(t, "health", bpErr.Field())
+		assert.Equal(t, "person", bpErr.Schema())
+	}
+	assert.ErrorIs(t, err, bitpack.ErrValueOverflow)
+	assert.Contains(t, err.Error(), "field person.health:")
+}
+
+func TestPersonPackedName(t *testing.T) {
+	// 56 символов без '_' и '-' — полная вместимость буфера
+	long := "GLD Sir Lancelot of the Round Table and Keeper of Grail1"
+	assert.Len(t, long, config.MaxNameChars)
+
+	created, err := NewPerson(WithName(long))
+	assert.NoError(t, err)
+	p := created.(*person)
+	assert.Equal(t, long, p.Name())
+	assert.NoError(t, p.Validate())
+
+	// Через DTO и JSON имя проходит без потерь
+	data, err := NewSerializer(nil).ToJSON(p)
+	assert.NoError(t, err)
+	restored, err := NewFromJSON(data)
+	assert.NoError(t, err)
+	assert.Equal(t, long, restored.Name())
+
+	// '_' и '-' занимают 12 бит, поэтому имя с ними короче
+	escaped := "GLD_Sir-Lancelot_of_the_Round_Table-and_Keeper"
+	assert.NoError(t, p.SetName(escaped))
+	assert.Equal(t, escaped, p.Name())
+
+	// Не помещающееся имя обрезается по последнему целому символу (46 символов + "_" = 336 бит)
+	assert.NoError(t, p.SetName(long+"xyz"))
+	assert.Equal(t, long, p.Name())
+	assert.NoError(t, p.SetName(escaped+"_Extra_Long_Suffix"))
+	assert.Equal(t, "GLD_Sir-Lancelot_of_the_Round_Table-and_Keeper_", p.Name())
+
+	assert.Error(t, p.SetName("Bad!Name"))
+	assert.Error(t, p.SetName("Имя"))
+	assert.Equal(t, "GLD_Sir-Lancelot_of_the_Round_Table-and_Keeper_", p.Name(), "failed SetName must not change name")
+}
+
+func TestPersonValidateDetectsNameGarbage(t *testing.T) {
+	p, err := NewPerson(WithName("Bob"))
+	assert.NoError(t, err)
+	raw := p.(*person)
+
+	raw.name[config.MaxNameLength-1] = 0x01
+	assert.ErrorContains(t, raw.Validate(), "garbage after name")
+
+	raw.name[config.MaxNameLength-1] = 0
+	raw.name[0] = 0xFF // коды 63, 63: неизвестный экранированный символ
+	assert.ErrorContains(t, raw.Validate(), "invalid name buffer")
+}
+
+func TestPersonChecksum(t *testing.T) {
+	created, err := NewPerson(WithName("Bob"), WithGold(config.PersonMaxGold), WithCoordinates(5, 6, -7))
+	assert.NoError(t, err)
+	p := created.(*person)
+	assert.NoError(t, p.VerifyChecksum())
+	assert.Equal(t, config.PersonMaxGold, p.gold, "parity bit is not part of gold")
+
+	// Каждый мутатор пересчитывает бит чётности, а изменение любого
+	// одного бита после него обнаруживается
+	raw := unsafe.Slice((*byte)(unsafe.Pointer(p)), unsafe.Sizeof(*p))
+	tests := []struct {
+		name   string
+		mutate func()
+	}{
+		{"SetName", func() { _ = p.SetName("Alice") }},
+		{"SetGold", func() { _ = p.SetGold(12345) }},
+		{"SetX", func() { _ = p.SetX(100) }},
+		{"SetY", func() { _ = p.SetY(-9) }},
+		{"SetZ", func() { _ = p.SetZ(42) }},
+		{"SetMana", func() { _ = p.SetMana(3) }},
+		{"ClearMana", func() { p.ClearMana() }},
+		{"AddMana", func() { p.AddMana(15) }},
+		{"DrainMana", func() { _ = p.DrainMana(5) }},
+		{"SetHealth", func() { _ = p.SetHealth(999) }},
+		{"AddHealth", func() { p.AddHealth(1) }},
+		{"TakeDamage", func() { p.TakeDamage(10) }},
+		{"SetStrength", func() { _ = p.SetStrength(7) }},
+		{"SetRespect", func() { _ = p.SetRespect(1) }},
+		{"SetExperience", func() { _ = p.SetExperience(2) }},
+		{"AddExperience", func() { p.AddExperience(1) }},
+		{"SetLevel", func() { _ = p.SetLevel(4) }},
+		{"SetType", func() { _ = p.SetType(PersonTypeWarrior) }},
+		{"SetHouse", func() { _ = p.SetHouse(true) }},
+		{"SetWeapon", func() { _ = p.SetWeapon(true) }},
+		{"SetFamily", func() { _ = p.SetFamily(true) }},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			before := p.record()
+			tt.mutate()
+			assert.NotEqual(t, before, p.record(), "mutator must change the record")
+			assert.NoError(t, p.VerifyChecksum())
+			assert.NoError(t, p.Validate())
+
+			for bit := 0; bit < 8*len(raw); bit++ {
+				raw[bit/8] ^= 1 << (bit % 8)
+				err := p.VerifyChecksum()
+				raw[bit/8] ^= 1 << (bit % 8)
+				if !assert.ErrorIs(t, err, entity.ErrChecksumMismatch, "flipped bit %d", bit) {
+					break
+				}
+			}
+		})
+	}
+
+	// Ошибка сеттера оставляет запись и бит чётности без изменений
+	before := p.record()
+	assert.Error(t, p.SetGold(config.PersonMaxGold+1))
+	assert.Equal(t, before, p.record())
+	assert.NoError(t, p.VerifyChecksum())
+
+	checker := entity.NewIntegrityChecker()
+	assert.NoError(t, checker.Check(p, "raw"))
+	p.x ^= 1
+	assert.ErrorIs(t, checker.Check(p, "raw"), entity.ErrChecksumMismatch)
+	assert.ErrorIs(t, p.Validate(), entity.ErrChecksumMismatch)
+}
@@ -3,6 +3,9 @@ package person
 import (
 	"GamePerson/internal/model/game/creatures/base/entity"
 	"GamePerson/internal/model/game/creatures/base/serializer"
+	"io"
+	"iter"
+	"os"
 )
 
 // ===== Основные конструкторы Person из внешних представлений (99% случаев — без параметров) ============
@@ -22,6 +25,49 @@ func NewFromYAML(data []byte) (Person, error) {
 	return newFromYAML(data, nil)
 }
 
+// NewFromBinary создаёт персонажа из двоичной записи (MarshalBinary) с проверкой целостности по умолчанию
+func NewFromBinary(data []byte) (Person, error) {
+	return newFromBinary(data, nil)
+}
+
+// NewFrom создаёт персонажа из данных формата format — имени, MIME-типа или
+// расширения кодека из serializer.Default ("json", "application/yaml", ".yml")
+func NewFrom(format string, data []byte) (Person, error) {
+	return NewSerializer(nil).Decode(format, data)
+}
+
+// NewFromFile читает персонажа из файла path; формат определяется
+// по расширению, а без него — по содержимому
+func NewFromFile(path string) (Person, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	return NewSerializer(nil).DecodeAuto(path, data)
+}
+
+// ExportNDJSON пишет персонажей в w по одному JSON-объекту на строку
+func ExportNDJSON(w io.Writer, items iter.Seq[Person]) error {
+	return NewSerializer(nil).ExportNDJSON(w, items)
+}
+
+// ImportNDJSON читает персонажей из NDJSON с проверкой целостности каждой строки
+// (см. serializer.ImportPolicy и serializer.ImportReport)
+func ImportNDJSON(r io.Reader, policy serializer.ImportPolicy) ([]Person, *serializer.ImportReport, error) {
+	return NewSerializer(nil).ImportNDJSON(r, policy)
+}
+
+// ExportCSV пишет персонажей в w таблицей CSV с заголовком из полей DTO
+func ExportCSV(w io.Writer, items iter.Seq[Person]) error {
+	return NewSerializer(nil).ExportCSV(w, items)
+}
+
+// ImportCSV читает персонажей из CSV с проверкой целостности каждой строки;
+// ошибки содержат номер строки и колонку (см. serializer.LineError)
+func ImportCSV(r io.Reader, policy serializer.ImportPolicy) ([]Person, *serializer.ImportReport, error) {
+	return NewSerializer(nil).ImportCSV(r, policy)
+}
+
 // ============ Расширенные конструкторы (специальные случаи) ============
 
 // NewFromJSONWithIntegrity создаёт персонажа из JSON с кастомным проверяющим целостности
@@ -39,6 +85,11 @@ func NewFromYAMLWithIntegrity(data []byte, integrity *entity.IntegrityChecker) (
 	return newFromYAML(data, integrity)
 }
 
+// NewFromBinaryWithIntegrity создаёт персонажа из двоичной записи с кастомным проверяющим целостности
+func NewFromBinaryWithIntegrity(data []byte, integrity *entity.IntegrityChecker) (Person, error) {
+	return newFromBinary(data, integrity)
+}
+
 // ---------------  Внутренняя реализация конструкторов -------------------------------
 
 func newFromJSON(data []byte, integrity *entity.IntegrityChecker) (Person, error) {
@@ -51,6 +102,11 @@ func newFromXML(data []byte, integrity *entity.IntegrityChecker) (Person, error)
 	return ser.FromXML(data)
 }
 
+func newFromBinary(data []byte, integrity *entity.IntegrityChecker) (Person, error) {
+	ser := NewSerializer(integrity)
+	return ser.FromBinary(data)
+}
+
 func newFromYAML(data []byte, integrity *entity.IntegrityChecker) (Person, error) {
 	ser := NewSerializer(integrity)
 	return ser.FromYAML(data)
@@ -69,6 +125,11 @@ func (c personConverter) FromDTO(dto PersonDTO) (Person, error) {
 	return FromDTO(dto) // существующая функция из dto.go
 }
 
+// NewEntity — пустой персонаж для Serializer.FromBinary
+func (c personConverter) NewEntity() Person {
+	return &person{}
+}
+
 // NewSerializer — фабрика с типобезопасностью
 func NewSerializer(integrity *entity.IntegrityChecker) *serializer.Serializer[Person, PersonDTO] {
 	return serializer.New[Person, PersonDTO](personConverter{}, integrity)
@@ -1,6 +1,19 @@
 package person
 
 import (
+	"GamePerson/internal/model/config"
+	"GamePerson/internal/model/game/creatures/base/entity"
+	"GamePerson/internal/model/game/creatures/base/record"
+	"GamePerson/internal/model/game/creatures/base/serializer"
+	"GamePerson/internal/model/game/creatures/monster"
+	"bytes"
+	"encoding/gob"
+	"errors"
+	"math/rand"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -26,7 +39,7 @@ func TestPersonJSONSerialization(t *testing.T) {
 	)
 	require.NoError(t, err)
 
-	serializer := NewSerializer()
+	serializer := NewSerializer(nil)
 
 	// Сериализация в JSON
 	jsonData, err := serializer.ToJSON(p)
@@ -60,7 +73,7 @@ func TestPersonXMLSerialization(t *testing.T) {
 	)
 	require.NoError(t, err)
 
-	serializer := NewSerializer()
+	serializer := NewSerializer(nil)
 
 	// Сериализация в XML
 	xmlData, err := serializer.ToXML(p)
@@ -95,7 +108,7 @@ func TestPersonYAMLSerialization(t *testing.T) {
 	)
 	require.NoError(t, err)
 
-	serializer := NewSerializer()
+	serializer := NewSerializer(nil)
 
 	// Сериализация в YAML
 	yamlData, err := serializer.ToYAML(p)
@@ -130,7 +143,7 @@ func TestPersonRoundTripAllFormats(t *testing.T) {
 	)
 	require.NoError(t, err)
 
-	serializer := NewSerializer()
+	serializer := NewSerializer(nil)
 
 	// Тест JSON
 	jsonData, err := serializer.ToJSON(original)
@@ -154,6 +167,509 @@ func TestPersonRoundTripAllFormats(t *testing.T) {
 	assertPersonEqual(t, original, fromYAML)
 }
 
+func TestPersonBinarySerialization(t *testing.T) {
+	p, err := NewPerson(
+		WithName("Binary_Knight-01"),
+		WithType(PersonTypeBlacksmith),
+		WithHealth(321),
+		WithLevel(7),
+		WithGold(1_999_999_999),
+		WithWeapon(true),
+		WithCoordinates(-2_000_000_000, 0, 2_000_000_000),
+	)
+	require.NoError(t, err)
+	p.ClearMana()
+
+	serializer := NewSerializer(nil)
+	data, err := serializer.ToBinary(p)
+	require.NoError(t, err)
+	// Заголовок "GPRC" + длина вида + "person" + версия, затем запись как есть
+	require.Len(t, data, 4+1+len("person")+4+64)
+	assert.Equal(t, record.Magic+"\x06person", string(data[:11]))
+	assert.Equal(t, p.(*person).record(), [64]byte(data[15:]))
+
+	jsonData, err := serializer.ToJSON(p)
+	require.NoError(t, err)
+	assert.Greater(t, len(jsonData), 3*len(data), "binary must be much smaller than JSON")
+
+	restored, err := serializer.FromBinary(data)
+	require.NoError(t, err)
+	assertPersonEqual(t, p, restored)
+	_, ok := restored.ManaOptional()
+	assert.False(t, ok)
+
+	restored, err = NewFromBinary(data)
+	require.NoError(t, err)
+	assert.Equal(t, p.(*person).record(), restored.(*person).record())
+}
+
+func TestPersonBinaryRejects(t *testing.T) {
+	p, err := NewPerson(WithName("Bob"), WithGold(100))
+	require.NoError(t, err)
+	data, err := p.MarshalBinary()
+	require.NoError(t, err)
+
+	m, err := monster.NewMonster(monster.WithName("Bob"))
+	require.NoError(t, err)
+	monsterData, err := m.MarshalBinary()
+	require.NoError(t, err)
+
+	corrupt := func(i int) []byte {
+		c := append([]byte(nil), data...)
+		c[i] ^= 0x01
+		return c
+	}
+	tests := []struct {
+		name string
+		data []byte
+		want error
+	}{
+		{"empty", nil, record.ErrBinaryMagic},
+		{"bad magic", corrupt(0), record.ErrBinaryMagic},
+		{"monster record", monsterData, record.ErrBinaryKind},
+		{"other version", corrupt(11), record.ErrBinaryVersion},
+		{"truncated", data[:len(data)-1], record.ErrBinarySize},
+		{"trailing bytes", append(append([]byte(nil), data...), 0), record.ErrBinarySize},
+		{"flipped gold bit", corrupt(len(data) - 16), entity.ErrChecksumMismatch},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := NewFromBinary(tt.data)
+			assert.ErrorIs(t, err, tt.want)
+		})
+	}
+
+	// UnmarshalBinary не меняет запись при ошибке заголовка
+	before := p.(*person).record()
+	assert.Error(t, p.UnmarshalBinary(monsterData))
+	assert.Equal(t, before, p.(*person).record())
+}
+
+func TestPersonCodecs(t *testing.T) {
+	p, err := NewPerson(WithName("Codec Tester"), WithType(PersonTypeWarrior), WithGold(77), WithCoordinates(1, 2, 3))
+	require.NoError(t, err)
+	ser := NewSerializer(nil)
+
+	tests := []struct {
+		format string // имя, MIME-тип или расширение
+		codec  serializer.Codec
+	}{
+		{"json", serializer.JSON},
+		{"JSON", serializer.JSON},
+		{"application/xml", serializer.XML},
+		{".yml", serializer.YAML},
+		{"yaml", serializer.YAML},
+	}
+	for _, tt := range tests {
+		t.Run(tt.format, func(t *testing.T) {
+			data, err := ser.Encode(tt.format, p)
+			require.NoError(t, err)
+			want, err := tt.codec.Marshal(ToDTO(p))
+			require.NoError(t, err)
+			assert.Equal(t, want, data)
+
+			restored, err := ser.Decode(tt.format, data)
+			require.NoError(t, err)
+			assertPersonEqual(t, p, restored)
+
+			// Формат узнаётся по содержимому
+			sniffed, err := serializer.Default.Sniff(data)
+			require.NoError(t, err)
+			assert.Equal(t, tt.codec.Name(), sniffed.Name())
+			restored, err = ser.DecodeAuto("", data)
+			require.NoError(t, err)
+			assertPersonEqual(t, p, restored)
+		})
+	}
+
+	_, err = ser.Encode("toml", p)
+	assert.ErrorIs(t, err, serializer.ErrUnknownFormat)
+	_, err = NewFrom("toml", nil)
+	assert.ErrorIs(t, err, serializer.ErrUnknownFormat)
+	_, err = ser.DecodeAuto("", []byte("\x00\x01"))
+	assert.ErrorIs(t, err, serializer.ErrUnknownFormat)
+
+	// Валидация работает для любого формата
+	_, err = NewFrom("yaml", []byte("name: Bob\nlevel: 1\nhealth: 5000\n"))
+	assert.ErrorContains(t, err, "YAML deserialization failed")
+}
+
+func TestPersonNewFromFile(t *testing.T) {
+	p, err := NewPerson(WithName("File Keeper"), WithLevel(3))
+	require.NoError(t, err)
+	data, err := NewSerializer(nil).ToYAML(p)
+	require.NoError(t, err)
+
+	dir := t.TempDir()
+	for _, name := range []string{"save.yml", "save.YAML", "save.txt", "save"} {
+		path := filepath.Join(dir, name)
+		require.NoError(t, os.WriteFile(path, data, 0o644))
+		restored, err := NewFromFile(path)
+		require.NoError(t, err, name)
+		assertPersonEqual(t, p, restored)
+	}
+
+	_, err = NewFromFile(filepath.Join(dir, "missing.json"))
+	assert.ErrorIs(t, err, os.ErrNotExist)
+}
+
+// gobCodec — пользовательский кодек для проверки реестра
+type gobCodec struct{}
+
+func (gobCodec) Name() string         { return "gob" }
+func (gobCodec) MIME() string         { return "application/x-gob" }
+func (gobCodec) Extensions() []string { return []string{".gob"} }
+
+func (gobCodec) Marshal(v any) ([]byte, error) {
+	var buf bytes.Buffer
+	err := gob.NewEncoder(&buf).Encode(v)
+	return buf.Bytes(), err
+}
+
+func (gobCodec) Unmarshal(data []byte, v any) error {
+	return gob.NewDecoder(bytes.NewReader(data)).Decode(v)
+}
+
+func TestPersonCustomCodec(t *testing.T) {
+	registry, err := serializer.NewRegistry(serializer.JSON, gobCodec{})
+	require.NoError(t, err)
+
+	p, err := NewPerson(WithName("Gob"), WithHealth(10))
+	require.NoError(t, err)
+	p.ClearMana()
+
+	ser := NewSerializer(nil).WithCodecs(registry)
+	data, err := ser.Encode("gob", p)
+	require.NoError(t, err)
+
+	restored, err := ser.DecodeAuto("npc.gob", data)
+	require.NoError(t, err)
+	assertPersonEqual(t, p, restored)
+	_, ok := restored.ManaOptional()
+	assert.False(t, ok)
+
+	// Свой реестр не меняет реестр по умолчанию и наоборот
+	_, err = NewSerializer(nil).Encode("gob", p)
+	assert.ErrorIs(t, err, serializer.ErrUnknownFormat)
+	_, err = ser.Encode("xml", p)
+	assert.ErrorIs(t, err, serializer.ErrUnknownFormat)
+
+	// Имя, MIME-тип и расширения уникальны в реестре
+	assert.ErrorIs(t, registry.Register(gobCodec{}), serializer.ErrCodecExists)
+	assert.ErrorIs(t, registry.Register(serializer.JSON), serializer.ErrCodecExists)
+	assert.Len(t, registry.Codecs(), 2)
+}
+
+func TestPersonStreaming(t *testing.T) {
+	rng := rand.New(rand.NewSource(23))
+	persons := make([]Person, 50)
+	for i := range persons {
+		persons[i] = randomPerson(t, rng)
+	}
+
+	for _, format := range []string{"json", "xml", "yaml"} {
+		t.Run(format, func(t *testing.T) {
+			ser, err := NewSerializer(nil).WithFormat(format)
+			require.NoError(t, err)
+
+			var buf bytes.Buffer
+			require.NoError(t, ser.EncodeAll(&buf, slices.Values(persons)))
+
+			i := 0
+			for p, err := range ser.DecodeAll(&buf) {
+				require.NoError(t, err, "record %d", i)
+				require.Equal(t, persons[i].(*person).record(), p.(*person).record(), "record %d", i)
+				i++
+			}
+			assert.Equal(t, len(persons), i)
+
+			// Одна сущность
+			buf.Reset()
+			require.NoError(t, ser.EncodeTo(&buf, persons[0]))
+			p, err := ser.DecodeFrom(&buf)
+			require.NoError(t, err)
+			assert.Equal(t, persons[0].(*person).record(), p.(*person).record())
+		})
+	}
+}
+
+func TestPersonStreamingErrors(t *testing.T) {
+	ser := NewSerializer(nil)
+	stream := strings.Join([]string{
+		`{"name":"Alice","level":1}`,
+		`{"name":"Bob","level":1,"health":5000}`, // вне лимита — ошибка одной записи
+		`{"name":"Carol","level":2}`,
+		`{"name":"Dave",`, // обрыв потока — ошибка последняя
+	}, "\n")
+
+	var names []string
+	var errs []error
+	for p, err := range ser.DecodeAll(strings.NewReader(stream)) {
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		names = append(names, p.Name())
+	}
+	assert.Equal(t, []string{"Alice", "Carol"}, names)
+	require.Len(t, errs, 2)
+
+	var recErr *serializer.RecordError
+	require.True(t, errors.As(errs[0], &recErr))
+	assert.Equal(t, 1, recErr.Index)
+	assert.ErrorContains(t, errs[0], "record 1: JSON deserialization failed")
+	require.True(t, errors.As(errs[1], &recErr))
+	assert.Equal(t, 3, recErr.Index)
+	assert.ErrorContains(t, errs[1], "failed to unmarshal from JSON")
+
+	// Прерывание цикла останавливает чтение
+	count := 0
+	for range ser.DecodeAll(strings.NewReader(stream)) {
+		count++
+		break
+	}
+	assert.Equal(t, 1, count)
+
+	// Кодек без StreamCodec: одиночные методы работают через Marshal, коллекции — нет
+	registry := serializer.MustNewRegistry(gobCodec{})
+	gobSer, err := NewSerializer(nil).WithCodecs(registry).WithFormat("gob")
+	require.NoError(t, err)
+	p, err := NewPerson(WithName("Gob"))
+	require.NoError(t, err)
+
+	var buf bytes.Buffer
+	require.NoError(t, gobSer.EncodeTo(&buf, p))
+	restored, err := gobSer.DecodeFrom(&buf)
+	require.NoError(t, err)
+	assertPersonEqual(t, p, restored)
+
+	assert.ErrorIs(t, gobSer.EncodeAll(&buf, slices.Values([]Person{p})), serializer.ErrNotStreamable)
+	for _, err := range gobSer.DecodeAll(&buf) {
+		assert.ErrorIs(t, err, serializer.ErrNotStreamable)
+	}
+
+	_, err = ser.WithFormat("toml")
+	assert.ErrorIs(t, err, serializer.ErrUnknownFormat)
+}
+
+func TestPersonNDJSON(t *testing.T) {
+	rng := rand.New(rand.NewSource(24))
+	persons := make([]Person, 100)
+	for i := range persons {
+		persons[i] = randomPerson(t, rng)
+	}
+
+	var buf bytes.Buffer
+	require.NoError(t, ExportNDJSON(&buf, slices.Values(persons)))
+	assert.Equal(t, len(persons), strings.Count(buf.String(), "\n"), "one object per line")
+
+	imported, report, err := ImportNDJSON(&buf, serializer.StopOnError)
+	require.NoError(t, err)
+	assert.Equal(t, len(persons), report.Imported)
+	assert.Empty(t, report.Invalid)
+	assert.NoError(t, report.Err())
+	require.Len(t, imported, len(persons))
+	for i := range persons {
+		assert.Equal(t, persons[i].(*person).record(), imported[i].(*person).record(), "record %d", i)
+	}
+}
+
+func TestPersonNDJSONImportPolicies(t *testing.T) {
+	input := strings.Join([]string{
+		`{"name":"Alice","level":1}`,
+		``,
+		`{"name":"Broken",`,
+		`{"name":"Carol","level":2,"mana":10}`,
+		`{"name":"Dave","level":1,"health":5000,"gold":1}`,
+		`{"name":"Eve","level":3}` + "\r",
+		`{"name":"Bad Type","level":1,"type":"Wizard"}`,
+	}, "\n")
+
+	t.Run("skip invalid", func(t *testing.T) {
+		imported, report, err := ImportNDJSON(strings.NewReader(input), serializer.SkipInvalid)
+		require.NoError(t, err)
+
+		var names []string
+		for _, p := range imported {
+			names = append(names, p.Name())
+		}
+		assert.Equal(t, []string{"Alice", "Carol", "Eve"}, names)
+		assert.Equal(t, 3, report.Imported)
+
+		require.Len(t, report.Invalid, 3)
+		assert.Equal(t, 3, report.Invalid[0].Line)
+		assert.ErrorContains(t, &report.Invalid[0], "failed to unmarshal from NDJSON")
+		assert.Equal(t, 5, report.Invalid[1].Line)
+		assert.ErrorContains(t, &report.Invalid[1], "health")
+		assert.Equal(t, 7, report.Invalid[2].Line)
+		assert.ErrorContains(t, report.Err(), "line 7: ")
+	})
+
+	t.Run("stop on error", func(t *testing.T) {
+		imported, report, err := ImportNDJSON(strings.NewReader(input), serializer.StopOnError)
+		var lineErr *serializer.LineError
+		require.True(t, errors.As(err, &lineErr))
+		assert.Equal(t, 3, lineErr.Line)
+		require.Len(t, imported, 1)
+		assert.Equal(t, "Alice", imported[0].Name())
+		assert.Equal(t, 1, report.Imported)
+		assert.Len(t, report.Invalid, 1)
+	})
+}
+
+// Сохранения до появления минимума уровня не содержат level:
+// отсутствующий уровень читается как уровень по умолчанию
+func TestPersonSaveWithoutLevel(t *testing.T) {
+	tests := []struct {
+		name string
+		load func() (Person, error)
+	}{
+		{"JSON", func() (Person, error) { return NewFromJSON([]byte(`{"name":"Old","health":100}`)) }},
+		{"YAML", func() (Person, error) { return NewFromYAML([]byte("name: Old\nhealth: 100\n")) }},
+		{"XML", func() (Person, error) {
+			return NewFromXML([]byte(`<Person><Name>Old</Name><Health>100</Health></Person>`))
+		}},
+		{"CSV", func() (Person, error) {
+			imported, _, err := ImportCSV(strings.NewReader("name,health\nOld,100\n"), serializer.StopOnError)
+			if err != nil {
+				return nil, err
+			}
+			return imported[0], nil
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p, err := tt.load()
+			require.NoError(t, err)
+			assert.Equal(t, "Old", p.Name())
+			assert.Equal(t, config.PersonDefaultLevel, p.Level())
+		})
+	}
+}
+
+func TestPersonCSV(t *testing.T) {
+	rng := rand.New(rand.NewSource(25))
+	persons := make([]Person, 100)
+	for i := range persons {
+		persons[i] = randomPerson(t, rng)
+	}
+
+	semicolon := NewSerializer(nil).WithCodec(serializer.MustNewCSVCodec(';'))
+	for _, tc := range []struct {
+		name  string
+		write func(*bytes.Buffer) error
+		read  func(*bytes.Buffer) ([]Person, *serializer.ImportReport, error)
+	}{
+		{
+			name:  "comma",
+			write: func(buf *bytes.Buffer) error { return ExportCSV(buf, slices.Values(persons)) },
+			read: func(buf *bytes.Buffer) ([]Person, *serializer.ImportReport, error) {
+				return ImportCSV(buf, serializer.StopOnError)
+			},
+		},
+		{
+			name:  "semicolon",
+			write: func(buf *bytes.Buffer) error { return semicolon.EncodeAll(buf, slices.Values(persons)) },
+			read: func(buf *bytes.Buffer) ([]Person, *serializer.ImportReport, error) {
+				return semicolon.Import(buf, serializer.StopOnError)
+			},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			require.NoError(t, tc.write(&buf))
+			header, _, _ := strings.Cut(buf.String(), "\n")
+			assert.Equal(t, "name,type,health,mana,level,gold,respect,strength,experience,has_house,has_weapon,has_family,x,y,z",
+				strings.ReplaceAll(header, ";", ","))
+			assert.Equal(t, len(persons)+1, strings.Count(buf.String(), "\n"))
+
+			imported, report, err := tc.read(&buf)
+			require.NoError(t, err)
+			assert.Equal(t, len(persons), report.Imported)
+			require.Len(t, imported, len(persons))
+			for i := range persons {
+				assert.Equal(t, persons[i].(*person).record(), imported[i].(*person).record(), "record %d", i)
+			}
+		})
+	}
+
+	_, err := serializer.NewCSVCodec('"')
+	assert.Error(t, err)
+}
+
+func TestPersonCSVImport(t *testing.T) {
+	input := strings.Join([]string{
+		`Name,Type,Level,Health,Mana,X`,
+		`Alice,Warrior,1,100,,0`,
+		`"Smith, Bob",1,2,100,7,-5`,
+		`"Bob Smith",1,2,100,7,-5`,
+		`Carl,Wizard,1,100,,0`,
+		`Dave,0,1,5000,,0`,
+		`Eve,0,1,100,,abc`,
+		`Fay,7,1,100,,0`,
+		`Gus,0,1,100,,2100000000`,
+		`Hal,0,1`,
+		`Ivy,2,3,50,0,9`,
+		`Jo,,1,100,,0`,
+	}, "\n")
+
+	t.Run("skip invalid", func(t *testing.T) {
+		imported, report, err := ImportCSV(strings.NewReader(input), serializer.SkipInvalid)
+		require.NoError(t, err)
+		require.Len(t, imported, 4)
+
+		assert.Equal(t, "Alice", imported[0].Name())
+		assert.Equal(t, PersonTypeWarrior, imported[0].Type())
+		_, hasMana := imported[0].ManaOptional()
+		assert.False(t, hasMana, "empty cell leaves mana unset")
+
+		assert.Equal(t, "Bob Smith", imported[1].Name())
+		assert.Equal(t, PersonTypeBlacksmith, imported[1].Type())
+		assert.Equal(t, uint32(7), imported[1].Mana())
+		assert.Equal(t, int32(-5), imported[1].X())
+
+		assert.Equal(t, "Ivy", imported[2].Name())
+		assert.Equal(t, PersonTypeWarrior, imported[2].Type())
+
+		assert.Equal(t, "Jo", imported[3].Name())
+		assert.Equal(t, PersonTypeBuilder, imported[3].Type(), "empty type cell gives the zero type")
+
+		want := []struct {
+			line   int
+			column string
+		}{
+			{3, "name"},   // запятая недопустима в имени
+			{5, "type"},   // неизвестное имя типа
+			{6, "health"}, // превышение лимита схемы
+			{7, "x"},      // не число
+			{8, "type"},   // недопустимое значение типа
+			{9, "x"},      // координата вне диапазона
+			{10, ""},      // неверное число колонок
+		}
+		require.Len(t, report.Invalid, len(want))
+		for i, w := range want {
+			assert.Equal(t, w.line, report.Invalid[i].Line, "error %d", i)
+			assert.Equal(t, w.column, report.Invalid[i].Column, "error %d", i)
+		}
+		assert.ErrorContains(t, &report.Invalid[1], `line 5, column "type": failed to unmarshal from CSV`)
+	})
+
+	t.Run("stop on error", func(t *testing.T) {
+		imported, _, err := ImportCSV(strings.NewReader(input), serializer.StopOnError)
+		var lineErr *serializer.LineError
+		require.True(t, errors.As(err, &lineErr))
+		assert.Equal(t, 3, lineErr.Line)
+		assert.Len(t, imported, 1)
+	})
+
+	t.Run("bad header", func(t *testing.T) {
+		_, _, err := ImportCSV(strings.NewReader("name,wings\nAlice,2\n"), serializer.SkipInvalid)
+		assert.ErrorContains(t, err, `unknown column "wings"`)
+		_, _, err = ImportCSV(strings.NewReader("name,NAME\nAlice,Bob\n"), serializer.SkipInvalid)
+		assert.ErrorContains(t, err, `duplicate column "NAME"`)
+	})
+}
+
 // assertPersonEqual проверяет равенство всех полей Person
 func assertPersonEqual(t *testing.T, expected, actual Person) {
 	assert.Equal(t, expected.Name(), actual.Name(), "Name mismatch")